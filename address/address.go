@@ -0,0 +1,407 @@
+// Package address parses single-line postal addresses into the components of
+// a structured geocoding request, and formats geocoding results as postal
+// address blocks.
+//
+// Addresses from the United States, Canada, the United Kingdom, Germany, Italy
+// and France are supported. Parsing is heuristic: when the components cannot
+// be told apart with confidence the result is marked ambiguous, and Geocode
+// falls back to a free-text search.
+//
+// Formatting follows per-country templates: the order of house number and
+// street, the postcode position, and which lines are printed in upper case.
+package address
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+)
+
+// Parsed holds the components of a parsed address.
+type Parsed struct {
+	// Input is the address as given, with whitespace normalized.
+	Input string
+
+	// Country is the ISO 3166-1 alpha-2 code of the address country.
+	Country string
+
+	// AddressNumber is the house number, e.g. "221B" or "55 bis".
+	AddressNumber string
+
+	// Street is the street name with abbreviations expanded.
+	Street string
+
+	// Unit is the apartment, suite or flat designator, e.g. "Apt 4".
+	Unit string
+
+	// POBox is the PO box number.
+	POBox string
+
+	// Locality is any area named between the street and the place, such as
+	// a neighborhood or village.
+	Locality string
+
+	// Place is the city, town or village.
+	Place string
+
+	// Region is the state or province code (US and Canada).
+	Region string
+
+	// District is the province code (Italy).
+	District string
+
+	// Postcode is the postal code in its canonical format.
+	Postcode string
+
+	// Ambiguous is true when the components could not be determined with
+	// confidence. Reasons explains why.
+	Ambiguous bool
+
+	// Reasons lists what made the parse ambiguous.
+	Reasons []string
+}
+
+// Supported returns the ISO codes of the supported countries.
+func Supported() []string {
+	codes := make([]string, 0, len(countries))
+	for code := range countries {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+var (
+	commas      = regexp.MustCompile(`\s*,[\s,]*`)
+	houseNumber = regexp.MustCompile(`(?i)^(?:n\.?\s*)?(\d+[A-Z]?(?:\s?[-/]\s?\d+[A-Z]?)?)$`)
+	numberFirst = regexp.MustCompile(`(?i)^(\d+[A-Z]?(?:[-/]\d+[A-Z]?)?(?:\s+(?:bis|ter|quater)\b)?),?\s+(.+)$`)
+	numberLast  = regexp.MustCompile(`(?i)^(.+?),?\s+(?:n\.?\s*)?(\d+[A-Z]?(?:\s?[-/]\s?\d+[A-Z]?)?)$`)
+	unitNumber  = regexp.MustCompile(`^([A-Za-z0-9]+)-(\d+)$`)
+	fivedigits  = regexp.MustCompile(`\s(\d{5})\s`)
+)
+
+// Parse splits a single-line address into its components. countryCode is an
+// ISO 3166-1 alpha-2 code; when empty the country is taken from a trailing
+// country name or guessed from the postcode and street vocabulary.
+//
+// Parse only returns an error for empty input or an unsupported country. An
+// address that cannot be parsed reliably is returned with Ambiguous set.
+func Parse(input, countryCode string) (*Parsed, error) {
+	s := normalize(input)
+	if s == "" {
+		return nil, fmt.Errorf("input is required")
+	}
+
+	p := &Parsed{Input: s}
+	segments := strings.Split(s, ", ")
+
+	code := strings.ToUpper(countryCode)
+	if len(segments) > 1 {
+		if named, ok := countryNames[strings.ToLower(segments[len(segments)-1])]; ok {
+			segments = segments[:len(segments)-1]
+			if code != "" && code != named {
+				p.ambiguous(fmt.Sprintf("address names country %s", named))
+			}
+			if code == "" {
+				code = named
+			}
+		}
+	}
+
+	var c *country
+	if code != "" {
+		if c = countries[code]; c == nil {
+			return nil, fmt.Errorf("unsupported country %q", countryCode)
+		}
+	} else if c = detectCountry(segments); c == nil {
+		p.ambiguous("country could not be determined")
+		return p, nil
+	}
+	p.Country = c.code
+
+	p.parse(c, segments)
+	return p, nil
+}
+
+// parse fills in the components from the comma-separated segments.
+func (p *Parsed) parse(c *country, segments []string) {
+	// PO boxes and units may appear anywhere; take them out first.
+	var rest []string
+	for _, seg := range segments {
+		if loc := c.poBox.FindStringSubmatchIndex(seg); loc != nil && p.POBox == "" {
+			number := c.poBox.SubexpIndex("number")
+			p.POBox = seg[loc[2*number]:loc[2*number+1]]
+			seg = strings.TrimSpace(seg[:loc[0]] + seg[loc[1]:])
+		}
+		if c.unitWhole.MatchString(seg) && p.Unit == "" {
+			p.Unit = seg
+			continue
+		}
+		if seg != "" {
+			rest = append(rest, seg)
+		}
+	}
+
+	if len(rest) == 0 {
+		p.ambiguous("no place or postcode")
+		return
+	}
+
+	var street string
+	if p.POBox == "" {
+		street, rest = splitStreet(c, rest)
+		if street == "" {
+			p.ambiguous("street could not be separated from place")
+			return
+		}
+		p.parseStreet(c, street)
+	}
+
+	p.parseTail(c, rest)
+}
+
+// splitStreet separates the street from the segments that follow it. The
+// street is the first segment, followed by a bare house number for countries
+// writing it after the street. Addresses without commas are split at the
+// postcode or after the street type.
+func splitStreet(c *country, segments []string) (string, []string) {
+	if len(segments) > 1 {
+		street, rest := segments[0], segments[1:]
+		if !c.numberFirst && houseNumber.MatchString(rest[0]) && !numberLast.MatchString(street) {
+			street += " " + rest[0]
+			rest = rest[1:]
+		}
+		return street, rest
+	}
+
+	s := segments[0]
+	if !c.postcodeFirst {
+		// Split after the last street type word or abbreviation.
+		words := strings.Fields(s)
+		for i := len(words) - 2; i > 0; i-- {
+			w := strings.ToLower(strings.TrimSuffix(words[i], "."))
+			if _, ok := c.suffixes[w]; ok || slices.Contains(c.streetWords, w) {
+				if _, ok := directionals[strings.ToLower(words[i+1])]; ok && c.code != "GB" && i+2 < len(words) {
+					i++
+				}
+				return strings.Join(words[:i+1], " "), []string{strings.Join(words[i+1:], " ")}
+			}
+		}
+		return "", nil
+	}
+
+	// Continental postcodes come first in the tail: split before the last one.
+	locs := fivedigits.FindAllStringIndex(s+" ", -1)
+	if len(locs) == 0 {
+		return "", nil
+	}
+	loc := locs[len(locs)-1]
+	return strings.TrimSpace(s[:loc[0]]), []string{strings.TrimSpace(s[loc[0]:])}
+}
+
+// parseStreet splits the street segment into unit, house number and street.
+func (p *Parsed) parseStreet(c *country, s string) {
+	pattern := numberLast
+	if c.numberFirst {
+		pattern = numberFirst
+	}
+
+	// A unit is only taken out if a numbered street remains, so that street
+	// names such as "Via Piano 3" are kept whole.
+	if m := c.unitLead.FindStringSubmatch(s); m != nil && p.Unit == "" && pattern.MatchString(s[len(m[0]):]) {
+		p.Unit = m[1]
+		s = s[len(m[0]):]
+	}
+	if m := c.unitTrail.FindStringSubmatchIndex(s); m != nil && p.Unit == "" && pattern.MatchString(s[:m[0]]) {
+		p.Unit = s[m[2]:m[3]]
+		s = s[:m[0]]
+	}
+
+	m := pattern.FindStringSubmatch(s)
+	if m == nil {
+		p.Street = expand(c, s)
+		p.ambiguous("no house number")
+		return
+	}
+
+	number, street := m[1], m[2]
+	if !c.numberFirst {
+		number, street = m[2], m[1]
+	}
+
+	// Canadian addresses write the unit before the number: "5-123 Main St".
+	if c.code == "CA" && p.Unit == "" {
+		if um := unitNumber.FindStringSubmatch(number); um != nil {
+			p.Unit, number = um[1], um[2]
+		}
+	}
+
+	p.AddressNumber = strings.Join(strings.Fields(number), " ")
+	p.Street = expand(c, street)
+}
+
+// parseTail reads place, region and postcode from the segments after the
+// street.
+func (p *Parsed) parseTail(c *country, segments []string) {
+	if m := c.unitLead.FindStringSubmatch(segments[0]); m != nil && p.Unit == "" {
+		p.Unit = m[1]
+		segments[0] = segments[0][len(m[0]):]
+	}
+
+	tail := strings.Join(segments, ", ")
+	m := c.tail.FindStringSubmatch(tail)
+	if m == nil {
+		p.ambiguous(fmt.Sprintf("unrecognized place or postcode %q", tail))
+		return
+	}
+
+	group := func(name string) string {
+		if i := c.tail.SubexpIndex(name); i >= 0 {
+			return strings.TrimSpace(m[i])
+		}
+		return ""
+	}
+
+	place := group("place")
+	locality := group("locality")
+	if i := strings.LastIndex(place, ","); i >= 0 {
+		locality = strings.TrimSpace(place[:i])
+		place = strings.TrimSpace(place[i+1:])
+	}
+	p.Locality = locality
+	p.Place = place
+	p.District = strings.ToUpper(group("district"))
+
+	if region := strings.ToUpper(group("region")); region != "" {
+		p.Region = region
+		if !c.regions[region] {
+			p.ambiguous(fmt.Sprintf("unknown region %q", region))
+		}
+	}
+
+	if postcode := group("postcode"); postcode != "" {
+		p.Postcode, _ = NormalizePostcode(c.code, postcode)
+	}
+
+	if p.Place == "" && p.Postcode == "" {
+		p.ambiguous("no place or postcode")
+	}
+}
+
+// ambiguous marks the parse as ambiguous for the given reason.
+func (p *Parsed) ambiguous(reason string) {
+	p.Ambiguous = true
+	p.Reasons = append(p.Reasons, reason)
+}
+
+// StructuredRequest returns a structured forward geocoding request for the
+// parsed components. The Italian province is sent as the region. Units and
+// PO boxes have no structured equivalent and are left out.
+func (p *Parsed) StructuredRequest() *geocoding.StructuredForwardRequest {
+	region := p.Region
+	if region == "" {
+		region = p.District
+	}
+
+	return &geocoding.StructuredForwardRequest{
+		AddressNumber: p.AddressNumber,
+		Street:        p.Street,
+		Place:         p.Place,
+		Region:        region,
+		Postcode:      p.Postcode,
+		Country:       p.Country,
+	}
+}
+
+// NormalizePostcode validates a postcode for a supported country and returns
+// it in canonical form: upper case, with the inward code of British and
+// Canadian postcodes separated by a single space.
+func NormalizePostcode(country, postcode string) (string, bool) {
+	c := countries[strings.ToUpper(country)]
+	if c == nil {
+		return postcode, false
+	}
+
+	pc := strings.ToUpper(strings.Join(strings.Fields(postcode), ""))
+	if !c.postcode.MatchString(pc) {
+		return postcode, false
+	}
+	if c.code == "GB" || c.code == "CA" {
+		pc = pc[:len(pc)-3] + " " + pc[len(pc)-3:]
+	}
+	return pc, true
+}
+
+// expand replaces street abbreviations with the full words.
+func expand(c *country, street string) string {
+	words := strings.Fields(street)
+	if len(words) == 0 {
+		return street
+	}
+
+	key := func(w string) string {
+		return strings.ToLower(strings.TrimSuffix(w, "."))
+	}
+	lookup := func(m map[string]string, w string) (string, bool) {
+		if full, ok := m[strings.ToLower(w)]; ok {
+			return full, true
+		}
+		full, ok := m[key(w)]
+		return full, ok
+	}
+
+	last := len(words) - 1
+
+	// Compass directions around US and Canadian street names.
+	if c.code == "US" || c.code == "CA" {
+		if len(words) >= 3 {
+			if full, ok := directionals[key(words[last])]; ok {
+				words[last] = full
+				last--
+			}
+			if full, ok := directionals[key(words[0])]; ok {
+				words[0] = full
+			}
+		}
+	}
+
+	if len(words) > 1 {
+		if full, ok := lookup(c.prefixes, words[0]); ok {
+			words[0] = full
+		}
+		if full, ok := lookup(c.suffixes, words[last]); ok && last > 0 {
+			words[last] = full
+		}
+		for i, w := range words {
+			if full, ok := lookup(c.anywhere, w); ok {
+				words[i] = full
+			}
+		}
+	}
+
+	for i, w := range words {
+		lower := strings.ToLower(w)
+		for abbr, full := range c.compound {
+			switch {
+			case lower == abbr:
+				words[i] = strings.ToUpper(full[:1]) + full[1:]
+			case strings.HasSuffix(lower, abbr):
+				words[i] = w[:len(w)-len(abbr)] + full
+			}
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// normalize collapses whitespace, tidies commas and trims trailing
+// punctuation.
+func normalize(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	s = commas.ReplaceAllString(s, ", ")
+	s = strings.Trim(s, " ,;")
+	return s
+}
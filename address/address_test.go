@@ -0,0 +1,251 @@
+package address
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		country string
+		want    *Parsed
+		wantErr bool
+	}{
+		{
+			name:  "US with unit",
+			input: "123 Main St Apt 4, Springfield, IL 62704",
+			want: &Parsed{
+				Country: "US", AddressNumber: "123", Street: "Main Street", Unit: "Apt 4",
+				Place: "Springfield", Region: "IL", Postcode: "62704",
+			},
+		},
+		{
+			name:  "US directional and ZIP+4",
+			input: "1600  Pennsylvania Ave NW ,Washington, DC 20500-0003",
+			want: &Parsed{
+				Country: "US", AddressNumber: "1600", Street: "Pennsylvania Avenue Northwest",
+				Place: "Washington", Region: "DC", Postcode: "20500-0003",
+			},
+		},
+		{
+			name:  "US without commas",
+			input: "123 N Main St #5 Los Angeles CA 90012",
+			want: &Parsed{
+				Country: "US", AddressNumber: "123", Street: "North Main Street", Unit: "#5",
+				Place: "Los Angeles", Region: "CA", Postcode: "90012",
+			},
+		},
+		{
+			name:  "US PO box",
+			input: "P.O. Box 123, Springfield, IL 62704",
+			want: &Parsed{
+				Country: "US", POBox: "123", Place: "Springfield", Region: "IL", Postcode: "62704",
+			},
+		},
+		{
+			name:  "US unknown state",
+			input: "123 Main St, Springfield, ZZ 62704",
+			want: &Parsed{
+				Country: "US", AddressNumber: "123", Street: "Main Street",
+				Place: "Springfield", Region: "ZZ", Postcode: "62704",
+				Ambiguous: true, Reasons: []string{`unknown region "ZZ"`},
+			},
+		},
+		{
+			name:  "CA unit before number",
+			input: "5-123 Main St, Toronto, ON m5v2t6",
+			want: &Parsed{
+				Country: "CA", AddressNumber: "123", Street: "Main Street", Unit: "5",
+				Place: "Toronto", Region: "ON", Postcode: "M5V 2T6",
+			},
+		},
+		{
+			name:  "GB flat and locality",
+			input: "Flat 3, 10 Downing St, Westminster, London sw1a2aa",
+			want: &Parsed{
+				Country: "GB", AddressNumber: "10", Street: "Downing Street", Unit: "Flat 3",
+				Locality: "Westminster", Place: "London", Postcode: "SW1A 2AA",
+			},
+		},
+		{
+			name:  "GB trailing country name",
+			input: "221B Baker Street, London NW1 6XE, United Kingdom",
+			want: &Parsed{
+				Country: "GB", AddressNumber: "221B", Street: "Baker Street",
+				Place: "London", Postcode: "NW1 6XE",
+			},
+		},
+		{
+			name:  "GB house name",
+			input: "Rose Cottage, High Street, Oxford OX1 1AA",
+			want: &Parsed{
+				Country: "GB", Street: "Rose Cottage",
+				Locality: "High Street", Place: "Oxford", Postcode: "OX1 1AA",
+				Ambiguous: true, Reasons: []string{"no house number"},
+			},
+		},
+		{
+			name:  "DE abbreviated street",
+			input: "Hauptstr. 5, 80331 München, Deutschland",
+			want: &Parsed{
+				Country: "DE", AddressNumber: "5", Street: "Hauptstraße",
+				Place: "München", Postcode: "80331",
+			},
+		},
+		{
+			name:    "DE without commas",
+			input:   "Unter den Linden 77 10117 Berlin",
+			country: "de",
+			want: &Parsed{
+				Country: "DE", AddressNumber: "77", Street: "Unter den Linden",
+				Place: "Berlin", Postcode: "10117",
+			},
+		},
+		{
+			name:    "DE Postfach",
+			input:   "Postfach 1234, 10117 Berlin",
+			country: "DE",
+			want:    &Parsed{Country: "DE", POBox: "1234", Place: "Berlin", Postcode: "10117"},
+		},
+		{
+			name:  "IT number after comma and province",
+			input: "V. del Corso, 12, 00186 Roma RM",
+			want: &Parsed{
+				Country: "IT", AddressNumber: "12", Street: "Via del Corso",
+				Place: "Roma", District: "RM", Postcode: "00186",
+			},
+		},
+		{
+			name:  "IT unit word in street name",
+			input: "Via Piano 3, 40100 Bologna (BO)",
+			want: &Parsed{
+				Country: "IT", AddressNumber: "3", Street: "Via Piano",
+				Place: "Bologna", District: "BO", Postcode: "40100",
+			},
+		},
+		{
+			name:  "FR cedex",
+			input: "55 Rue du Fbg St Honoré, 75008 Paris Cedex 08",
+			want: &Parsed{
+				Country: "FR", AddressNumber: "55", Street: "Rue du Faubourg Saint Honoré",
+				Place: "Paris", Postcode: "75008",
+			},
+		},
+		{
+			name:  "FR bis",
+			input: "12 bis Av. Victor Hugo, 75016 Paris",
+			want: &Parsed{
+				Country: "FR", AddressNumber: "12 bis", Street: "Avenue Victor Hugo",
+				Place: "Paris", Postcode: "75016",
+			},
+		},
+		{
+			name:  "country not detected",
+			input: "Springfield",
+			want: &Parsed{
+				Ambiguous: true, Reasons: []string{"country could not be determined"},
+			},
+		},
+		{
+			name:    "conflicting country name",
+			input:   "10 Rue de la Paix, 75002 Paris, France",
+			country: "IT",
+			want: &Parsed{
+				Country: "IT", Street: "10 Rue de la Paix", Place: "Paris", Postcode: "75002",
+				Ambiguous: true, Reasons: []string{"address names country FR", "no house number"},
+			},
+		},
+		{
+			name:    "empty input",
+			input:   "  , ",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported country",
+			input:   "1 Main St",
+			country: "ES",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input, tt.country)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got.Input = ""
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizePostcode(t *testing.T) {
+	tests := []struct {
+		country  string
+		postcode string
+		want     string
+		wantOK   bool
+	}{
+		{"US", "62704", "62704", true},
+		{"US", "62704-1234", "62704-1234", true},
+		{"US", "6270", "6270", false},
+		{"ca", "k1a0b1", "K1A 0B1", true},
+		{"CA", "D1A 0B1", "D1A 0B1", false},
+		{"GB", "sw1a 2aa", "SW1A 2AA", true},
+		{"GB", "M11AE", "M1 1AE", true},
+		{"DE", "10117", "10117", true},
+		{"IT", "0018", "0018", false},
+		{"FR", "75 008", "75008", true},
+		{"ES", "28001", "28001", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.country+" "+tt.postcode, func(t *testing.T) {
+			got, ok := NormalizePostcode(tt.country, tt.postcode)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NormalizePostcode() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParsed_StructuredRequest(t *testing.T) {
+	p, err := Parse("123 Main St Apt 4, Springfield, IL 62704", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := p.StructuredRequest()
+	if req.AddressNumber != "123" || req.Street != "Main Street" || req.Place != "Springfield" ||
+		req.Region != "IL" || req.Postcode != "62704" || req.Country != "US" {
+		t.Errorf("unexpected request %+v", req)
+	}
+}
+
+func TestParsed_StructuredRequest_Province(t *testing.T) {
+	p, err := Parse("V. del Corso, 12, 00186 Roma RM", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := p.StructuredRequest()
+	if req.Place != "Roma" || req.Region != "RM" || req.Postcode != "00186" || req.Country != "IT" {
+		t.Errorf("expected the province as the region, got %+v", req)
+	}
+}
+
+func TestSupported(t *testing.T) {
+	want := []string{"CA", "DE", "FR", "GB", "IT", "US"}
+	if got := Supported(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Supported() = %v, want %v", got, want)
+	}
+}
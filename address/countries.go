@@ -0,0 +1,264 @@
+package address
+
+import (
+	"regexp"
+	"strings"
+)
+
+// country describes how addresses are written in a supported country.
+type country struct {
+	// code is the ISO 3166-1 alpha-2 code.
+	code string
+
+	// numberFirst is true when the house number precedes the street name.
+	numberFirst bool
+
+	// postcodeFirst is true when the postcode precedes the place.
+	postcodeFirst bool
+
+	// postcode matches a complete, valid postcode.
+	postcode *regexp.Regexp
+
+	// tail matches the text after the street. The named groups "locality",
+	// "place", "region", "district" and "postcode" are used when present.
+	tail *regexp.Regexp
+
+	// regions lists the valid region codes, when the tail has a region.
+	regions map[string]bool
+
+	// unitWords introduce a unit (apartment, suite, flat) designator.
+	unitWords []string
+
+	// poBox matches a PO box; the "number" group holds the box number.
+	poBox *regexp.Regexp
+
+	// prefixes expands abbreviations at the start of the street name.
+	prefixes map[string]string
+
+	// suffixes expands abbreviations at the end of the street name.
+	suffixes map[string]string
+
+	// anywhere expands abbreviations at any position of the street name.
+	anywhere map[string]string
+
+	// compound expands abbreviations ending a word, as in "Hauptstr.".
+	compound map[string]string
+
+	// streetWords identify street names, used to detect the country and to
+	// split addresses written without commas.
+	streetWords []string
+
+	// Unit patterns built from unitWords.
+	unitWhole, unitLead, unitTrail *regexp.Regexp
+}
+
+// usSuffixes are the street suffix abbreviations used in the US and Canada.
+var usSuffixes = map[string]string{
+	"st": "Street", "ave": "Avenue", "av": "Avenue", "rd": "Road", "blvd": "Boulevard",
+	"dr": "Drive", "ln": "Lane", "ct": "Court", "pl": "Place", "hwy": "Highway",
+	"pkwy": "Parkway", "cir": "Circle", "ter": "Terrace", "sq": "Square", "trl": "Trail",
+	"cres": "Crescent",
+}
+
+// directionals expands compass abbreviations used in US and Canadian streets.
+var directionals = map[string]string{
+	"n": "North", "s": "South", "e": "East", "w": "West",
+	"ne": "Northeast", "nw": "Northwest", "se": "Southeast", "sw": "Southwest",
+}
+
+var usStates = setOf(
+	"AL", "AK", "AZ", "AR", "CA", "CO", "CT", "DE", "DC", "FL", "GA", "HI", "ID", "IL", "IN",
+	"IA", "KS", "KY", "LA", "ME", "MD", "MA", "MI", "MN", "MS", "MO", "MT", "NE", "NV", "NH",
+	"NJ", "NM", "NY", "NC", "ND", "OH", "OK", "OR", "PA", "RI", "SC", "SD", "TN", "TX", "UT",
+	"VT", "VA", "WA", "WV", "WI", "WY", "PR", "GU", "VI", "AS", "MP",
+)
+
+var caProvinces = setOf("AB", "BC", "MB", "NB", "NL", "NS", "NT", "NU", "ON", "PE", "QC", "SK", "YT")
+
+var countries = map[string]*country{
+	"US": {
+		code:        "US",
+		numberFirst: true,
+		postcode:    regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+		tail:        regexp.MustCompile(`(?i)^(?:(?P<place>.*?),?\s+)?(?P<region>[A-Z]{2})(?:\s+(?P<postcode>\d{5}(?:-\d{4})?))?$`),
+		regions:     usStates,
+		unitWords:   []string{"apt", "apartment", "suite", "ste", "unit", "rm", "room", "fl", "floor", "bldg", "building"},
+		poBox:       regexp.MustCompile(`(?i)\b(?:p\.?\s*o\.?\s*box|post\s+office\s+box)\s*(?P<number>\d+)`),
+		suffixes:    usSuffixes,
+		streetWords: []string{"street", "avenue", "road", "boulevard", "drive", "lane", "court", "place", "highway", "parkway", "way"},
+	},
+	"CA": {
+		code:        "CA",
+		numberFirst: true,
+		postcode:    regexp.MustCompile(`(?i)^[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z]\s?\d[ABCEGHJ-NPRSTV-Z]\d$`),
+		tail:        regexp.MustCompile(`(?i)^(?:(?P<place>.*?),?\s+)?(?P<region>[A-Z]{2})(?:\s+(?P<postcode>[A-Z]\d[A-Z]\s?\d[A-Z]\d))?$`),
+		regions:     caProvinces,
+		unitWords:   []string{"apt", "apartment", "suite", "ste", "unit", "rm", "room", "fl", "floor"},
+		poBox:       regexp.MustCompile(`(?i)\b(?:p\.?\s*o\.?\s*box|post\s+office\s+box)\s*(?P<number>\d+)`),
+		suffixes:    usSuffixes,
+		streetWords: []string{"street", "avenue", "road", "boulevard", "drive", "lane", "court", "place", "highway", "crescent"},
+	},
+	"GB": {
+		code:        "GB",
+		numberFirst: true,
+		postcode:    regexp.MustCompile(`(?i)^(GIR\s?0AA|[A-Z]{1,2}\d[A-Z\d]?\s?\d[A-Z]{2})$`),
+		tail:        regexp.MustCompile(`(?i)^(?:(?P<place>.*?),?\s+)?(?P<postcode>GIR\s?0AA|[A-Z]{1,2}\d[A-Z\d]?\s?\d[A-Z]{2})$`),
+		unitWords:   []string{"flat", "apartment", "unit", "suite", "floor"},
+		poBox:       regexp.MustCompile(`(?i)\b(?:p\.?\s*o\.?\s*box)\s*(?P<number>\d+)`),
+		suffixes: map[string]string{
+			"st": "Street", "rd": "Road", "ave": "Avenue", "ln": "Lane", "sq": "Square",
+			"cres": "Crescent", "ct": "Court", "pl": "Place", "dr": "Drive", "gdns": "Gardens",
+			"ter": "Terrace", "terr": "Terrace",
+		},
+		streetWords: []string{"street", "road", "avenue", "lane", "square", "crescent", "gardens", "terrace", "close", "mews"},
+	},
+	"DE": {
+		code:          "DE",
+		postcodeFirst: true,
+		postcode:      regexp.MustCompile(`^\d{5}$`),
+		tail:          regexp.MustCompile(`(?i)^(?:(?P<locality>.*?),\s*)?(?P<postcode>\d{5})\s+(?P<place>.+?)$`),
+		unitWords:     []string{"wohnung", "whg", "etage"},
+		poBox:         regexp.MustCompile(`(?i)\bpostfach\s*(?P<number>\d+(?:\s\d+)*)`),
+		compound:      map[string]string{"str.": "straße"},
+		streetWords: []string{
+			"straße", "strasse", "str.", "weg", "platz", "allee", "gasse", "ring", "damm", "ufer",
+		},
+	},
+	"IT": {
+		code:          "IT",
+		postcodeFirst: true,
+		postcode:      regexp.MustCompile(`^\d{5}$`),
+		tail:          regexp.MustCompile(`(?i)^(?:(?P<locality>.*?),\s*)?(?P<postcode>\d{5})\s+(?P<place>.+?)(?:\s+\(?(?P<district>[A-Z]{2})\)?)?$`),
+		unitWords:     []string{"interno", "int", "scala", "piano"},
+		poBox:         regexp.MustCompile(`(?i)\bcasella\s+postale\s*(?P<number>\d+)`),
+		prefixes: map[string]string{
+			"v.": "Via", "v.le": "Viale", "p.zza": "Piazza", "p.za": "Piazza", "c.so": "Corso",
+			"l.go": "Largo", "str.": "Strada", "vic.": "Vicolo",
+		},
+		streetWords: []string{"via", "viale", "piazza", "corso", "largo", "vicolo", "strada", "piazzale"},
+	},
+	"FR": {
+		code:          "FR",
+		numberFirst:   true,
+		postcodeFirst: true,
+		postcode:      regexp.MustCompile(`^\d{5}$`),
+		tail:          regexp.MustCompile(`(?i)^(?:(?P<locality>.*?),\s*)?(?P<postcode>\d{5})\s+(?P<place>.+?)(?:\s+cedex(?:\s+\d+)?)?$`),
+		unitWords:     []string{"appartement", "appt", "apt", "bâtiment", "bât", "bat", "étage", "escalier", "esc"},
+		poBox:         regexp.MustCompile(`(?i)\b(?:b\.?p\.?|boîte\s+postale)\s*(?P<number>\d+)`),
+		prefixes: map[string]string{
+			"bd": "Boulevard", "boul": "Boulevard", "av": "Avenue", "r.": "Rue", "pl": "Place",
+			"imp": "Impasse", "chem": "Chemin", "all": "Allée", "rte": "Route", "sq": "Square",
+		},
+		anywhere: map[string]string{
+			"fbg": "Faubourg", "fg": "Faubourg", "st": "Saint", "ste": "Sainte",
+		},
+		streetWords: []string{"rue", "avenue", "boulevard", "place", "impasse", "chemin", "allée", "route", "quai"},
+	},
+}
+
+func init() {
+	for _, c := range countries {
+		quoted := make([]string, len(c.unitWords))
+		for i, w := range c.unitWords {
+			quoted[i] = regexp.QuoteMeta(w)
+		}
+		unit := `(?:(?:` + strings.Join(quoted, "|") + `)\.?\s*#?|#)\s*[\p{L}\d-]+`
+		c.unitWhole = regexp.MustCompile(`(?i)^` + unit + `$`)
+		c.unitLead = regexp.MustCompile(`(?i)^(` + unit + `),?\s+`)
+		c.unitTrail = regexp.MustCompile(`(?i),?\s+(` + unit + `)$`)
+	}
+}
+
+// countryNames maps country names written at the end of an address to ISO
+// codes. Codes that double as US state abbreviations (CA, DE) are left out.
+var countryNames = map[string]string{
+	"us": "US", "usa": "US", "u.s.": "US", "u.s.a.": "US", "united states": "US", "united states of america": "US",
+	"canada": "CA",
+	"gb":     "GB", "uk": "GB", "u.k.": "GB", "united kingdom": "GB", "great britain": "GB",
+	"england": "GB", "scotland": "GB", "wales": "GB", "northern ireland": "GB",
+	"germany": "DE", "deutschland": "DE",
+	"it": "IT", "italy": "IT", "italia": "IT",
+	"fr": "FR", "france": "FR",
+}
+
+var (
+	caTail = regexp.MustCompile(`(?i)\b[A-Z]{2}\s+[A-Z]\d[A-Z]\s?\d[A-Z]\d$`)
+	usTail = regexp.MustCompile(`(?i)\b[A-Z]{2}\s+\d{5}(-\d{4})?$`)
+)
+
+// detectCountry guesses the country of an address from its postcode and
+// street vocabulary. It returns nil when no single country matches.
+func detectCountry(segments []string) *country {
+	last := segments[len(segments)-1]
+
+	switch {
+	case caTail.MatchString(last):
+		return countries["CA"]
+	case usTail.MatchString(last):
+		return countries["US"]
+	case countries["GB"].tail.MatchString(last):
+		return countries["GB"]
+	}
+
+	// Continental addresses share five digit postcodes; use street words.
+	lower := strings.ToLower(strings.Join(segments, " "))
+	var match *country
+	for _, code := range []string{"DE", "IT", "FR"} {
+		c := countries[code]
+		if c.mentions(lower) {
+			if match != nil {
+				return nil
+			}
+			match = c
+		}
+	}
+	return match
+}
+
+// mentions reports whether the lower-cased address contains one of the
+// country's street words or abbreviations.
+func (c *country) mentions(lower string) bool {
+	for _, word := range c.streetWords {
+		if containsWord(lower, word, c.code == "DE") {
+			return true
+		}
+	}
+	for abbr := range c.prefixes {
+		if strings.HasPrefix(lower, abbr+" ") || strings.Contains(lower, " "+abbr+" ") ||
+			strings.HasPrefix(lower, abbr+". ") || strings.Contains(lower, " "+abbr+". ") {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWord reports whether s contains word as a whole word. When compound
+// is true the word may also end a longer word, as German street names do.
+func containsWord(s, word string, compound bool) bool {
+	for i := 0; i < len(s); {
+		j := strings.Index(s[i:], word)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(word)
+		startOK := compound || start == 0 || !isLetter(s[start-1])
+		endOK := end == len(s) || !isLetter(s[end]) || strings.HasSuffix(word, ".")
+		if startOK && endOK {
+			return true
+		}
+		i = start + 1
+	}
+	return false
+}
+
+func isLetter(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= 0x80
+}
+
+func setOf(values ...string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,304 @@
+package address
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	"github.com/pettinz/mapbox-go-sdk/searchbox"
+)
+
+// Components are the parts of a postal address, as used by the formatter.
+type Components struct {
+	// Name is the addressee or point of interest name, printed first.
+	Name string
+
+	// AddressNumber is the house number.
+	AddressNumber string
+
+	// Street is the street name.
+	Street string
+
+	// Unit is the apartment, suite or flat designator.
+	Unit string
+
+	// POBox is the PO box number, printed instead of the street.
+	POBox string
+
+	// Locality is a neighborhood or village within the place.
+	Locality string
+
+	// Place is the city, town or village.
+	Place string
+
+	// Region is the state or province, as a code where one is in postal
+	// use (e.g. "IL", "ON").
+	Region string
+
+	// District is the province code in Italian addresses (e.g. "RM").
+	District string
+
+	// Postcode is the postal code.
+	Postcode string
+
+	// Country is the country name printed on the last line. Leave it empty
+	// to omit the country line, as for domestic mail.
+	Country string
+
+	// CountryCode is the ISO 3166-1 alpha-2 code selecting the template.
+	CountryCode string
+}
+
+// templates lay out addresses per country. Each line names components in
+// braces; upper-case names are rendered in upper case. Lines left empty
+// after substitution are dropped.
+var templates = map[string][]string{
+	"US": {"{name}", "{pobox}", "{number} {street} {unit}", "{place}, {region} {postcode}", "{COUNTRY}"},
+	"CA": {"{name}", "{pobox}", "{number} {street} {unit}", "{place} {region} {postcode}", "{COUNTRY}"},
+	"GB": {"{name}", "{pobox}", "{unit}", "{number} {street}", "{locality}", "{PLACE}", "{postcode}", "{COUNTRY}"},
+	"DE": {"{name}", "{pobox}", "{street} {number}", "{unit}", "{postcode} {place}", "{COUNTRY}"},
+	"IT": {"{name}", "{pobox}", "{street} {number}", "{unit}", "{postcode} {place} {district}", "{COUNTRY}"},
+	"FR": {"{name}", "{unit}", "{number} {street}", "{pobox}", "{locality}", "{postcode} {PLACE}", "{COUNTRY}"},
+}
+
+// defaultTemplate is used for countries without a template of their own.
+var defaultTemplate = []string{"{name}", "{pobox}", "{number} {street} {unit}", "{locality}", "{postcode} {place}", "{region}", "{COUNTRY}"}
+
+// poBoxLabels is the PO box wording per country.
+var poBoxLabels = map[string]string{
+	"US": "PO Box", "CA": "PO Box", "GB": "PO Box",
+	"DE": "Postfach", "IT": "Casella Postale", "FR": "BP",
+}
+
+var (
+	placeholder = regexp.MustCompile(`\{(\w+)\}`)
+	dangling    = regexp.MustCompile(`^[\s,-]+|[\s,-]+$`)
+	spaceComma  = regexp.MustCompile(`\s+,`)
+	bareUnit    = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+)
+
+// Lines renders the address as a postal address block, one line per element,
+// following the conventions of its country.
+func (c Components) Lines() []string {
+	template, ok := templates[strings.ToUpper(c.CountryCode)]
+	if !ok {
+		template = defaultTemplate
+	}
+
+	values := map[string]string{
+		"name":     c.Name,
+		"number":   c.AddressNumber,
+		"street":   c.Street,
+		"unit":     c.Unit,
+		"locality": c.Locality,
+		"place":    c.Place,
+		"region":   c.Region,
+		"district": c.District,
+		"postcode": c.Postcode,
+		"country":  c.Country,
+	}
+	// Canada Post writes bare unit numbers before the civic number: "5-123".
+	if strings.EqualFold(c.CountryCode, "CA") && c.Unit != "" && c.AddressNumber != "" && bareUnit.MatchString(c.Unit) {
+		values["number"] = c.Unit + "-" + c.AddressNumber
+		values["unit"] = ""
+	}
+	if c.POBox != "" {
+		label, ok := poBoxLabels[strings.ToUpper(c.CountryCode)]
+		if !ok {
+			label = "PO Box"
+		}
+		values["pobox"] = label + " " + c.POBox
+		values["number"], values["street"] = "", ""
+	}
+
+	var lines []string
+	for _, line := range template {
+		line = placeholder.ReplaceAllStringFunc(line, func(m string) string {
+			key := m[1 : len(m)-1]
+			value := values[strings.ToLower(key)]
+			if key == strings.ToUpper(key) {
+				value = strings.ToUpper(value)
+			}
+			return value
+		})
+		line = strings.Join(strings.Fields(line), " ")
+		line = spaceComma.ReplaceAllString(line, ",")
+		line = dangling.ReplaceAllString(line, "")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Block renders the address as a multi-line postal address block.
+func (c Components) Block() string {
+	return strings.Join(c.Lines(), "\n")
+}
+
+// String renders the address on a single line, with the lines of the postal
+// block separated by commas.
+func (c Components) String() string {
+	return strings.Join(c.Lines(), ", ")
+}
+
+// FromGeocoding returns the address components of a geocoding result.
+func FromGeocoding(props geocoding.Properties) Components {
+	c := Components{
+		AddressNumber: props.AddressNumber,
+		Street:        props.Street,
+		Postcode:      props.Postcode,
+	}
+
+	// Non-address features have no street: print their name instead.
+	if c.Street == "" && props.FeatureType != "address" {
+		c.Name = props.Name
+	}
+
+	ctx := props.Context
+	if ctx == nil {
+		return c
+	}
+
+	name := func(e *geocoding.ContextElement) string {
+		if e == nil {
+			return ""
+		}
+		return e.Name
+	}
+
+	if c.Street == "" {
+		c.Street = name(ctx.Street)
+	}
+	if c.Postcode == "" {
+		c.Postcode = name(ctx.Postcode)
+	}
+	c.Locality = name(ctx.Locality)
+	c.Place = name(ctx.Place)
+	c.Country = name(ctx.Country)
+	if ctx.Country != nil {
+		c.CountryCode = strings.ToUpper(ctx.Country.CountryCode)
+		if c.CountryCode == "" {
+			c.CountryCode = shortCode(ctx.Country.ShortCode)
+		}
+	}
+	if ctx.Region != nil {
+		code := ctx.Region.RegionCode
+		if code == "" {
+			code = ctx.Region.ShortCode
+		}
+		c.Region = regionCode(c.CountryCode, ctx.Region.Name, code)
+	}
+	if c.CountryCode == "IT" && ctx.District != nil {
+		c.District = districtCode(ctx.District.ShortCode)
+	}
+
+	return c
+}
+
+// FromSearchBox returns the address components of a Search Box result.
+// Points of interest get their name as the first line.
+func FromSearchBox(props searchbox.FeatureProperties) Components {
+	c := Components{
+		AddressNumber: props.AddressNumber,
+		Street:        props.Street,
+		Postcode:      props.Postcode,
+	}
+
+	if props.FeatureType == "poi" {
+		c.Name = props.Name
+	}
+
+	ctx := props.Context
+	if ctx == nil {
+		return c
+	}
+
+	name := func(e *searchbox.ContextElement) string {
+		if e == nil {
+			return ""
+		}
+		return e.Name
+	}
+
+	if c.Street == "" {
+		c.Street = name(ctx.Street)
+	}
+	if c.Postcode == "" {
+		c.Postcode = name(ctx.Postcode)
+	}
+	c.Locality = name(ctx.Locality)
+	c.Place = name(ctx.Place)
+	c.Country = name(ctx.Country)
+	if ctx.Country != nil {
+		c.CountryCode = strings.ToUpper(ctx.Country.CountryCode)
+		if c.CountryCode == "" {
+			c.CountryCode = shortCode(ctx.Country.ShortCode)
+		}
+	}
+	if ctx.Region != nil {
+		code := ctx.Region.RegionCode
+		if code == "" {
+			code = ctx.Region.ShortCode
+		}
+		c.Region = regionCode(c.CountryCode, ctx.Region.Name, code)
+	}
+	if c.CountryCode == "IT" && ctx.District != nil {
+		c.District = districtCode(ctx.District.ShortCode)
+	}
+
+	return c
+}
+
+// Components returns the parsed address as formatter components. The
+// country name is left empty, omitting the country line.
+func (p *Parsed) Components() Components {
+	return Components{
+		AddressNumber: p.AddressNumber,
+		Street:        p.Street,
+		Unit:          p.Unit,
+		POBox:         p.POBox,
+		Locality:      p.Locality,
+		Place:         p.Place,
+		Region:        p.Region,
+		District:      p.District,
+		Postcode:      p.Postcode,
+		CountryCode:   p.Country,
+	}
+}
+
+// shortCode returns the upper-case code after any "XX-" prefix of a short
+// code such as "us" or "US-IL".
+func shortCode(code string) string {
+	if i := strings.LastIndex(code, "-"); i >= 0 {
+		code = code[i+1:]
+	}
+	return strings.ToUpper(code)
+}
+
+// regionCode returns the region as printed on mail: the postal code in the
+// US and Canada, nothing in countries that do not print it, and the name
+// elsewhere.
+func regionCode(country, name, code string) string {
+	switch country {
+	case "US", "CA":
+		if code != "" {
+			return shortCode(code)
+		}
+		return name
+	case "GB", "DE", "IT", "FR":
+		return ""
+	default:
+		return name
+	}
+}
+
+// districtCode returns an Italian province code from a short code such as
+// "IT-RM".
+func districtCode(code string) string {
+	code = shortCode(code)
+	if len(code) != 2 {
+		return ""
+	}
+	return code
+}
@@ -0,0 +1,237 @@
+package address
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	"github.com/pettinz/mapbox-go-sdk/searchbox"
+)
+
+func TestComponents_Lines(t *testing.T) {
+	tests := []struct {
+		name       string
+		components Components
+		want       []string
+	}{
+		{
+			name: "US",
+			components: Components{
+				AddressNumber: "123", Street: "Main Street", Unit: "Apt 4",
+				Place: "Springfield", Region: "IL", Postcode: "62704",
+				Country: "United States", CountryCode: "US",
+			},
+			want: []string{"123 Main Street Apt 4", "Springfield, IL 62704", "UNITED STATES"},
+		},
+		{
+			name: "US PO box without country",
+			components: Components{
+				POBox: "123", Place: "Springfield", Region: "IL", Postcode: "62704", CountryCode: "US",
+			},
+			want: []string{"PO Box 123", "Springfield, IL 62704"},
+		},
+		{
+			name: "US missing place",
+			components: Components{
+				AddressNumber: "1", Street: "Main Street", Region: "IL", Postcode: "62704", CountryCode: "US",
+			},
+			want: []string{"1 Main Street", "IL 62704"},
+		},
+		{
+			name: "CA numeric unit",
+			components: Components{
+				AddressNumber: "123", Street: "Main Street", Unit: "5",
+				Place: "Toronto", Region: "ON", Postcode: "M5V 2T6", CountryCode: "CA",
+			},
+			want: []string{"5-123 Main Street", "Toronto ON M5V 2T6"},
+		},
+		{
+			name: "CA suite",
+			components: Components{
+				AddressNumber: "123", Street: "Main Street", Unit: "Suite 200",
+				Place: "Toronto", Region: "ON", Postcode: "M5V 2T6", CountryCode: "CA",
+			},
+			want: []string{"123 Main Street Suite 200", "Toronto ON M5V 2T6"},
+		},
+		{
+			name: "GB",
+			components: Components{
+				Unit: "Flat 3", AddressNumber: "10", Street: "Downing Street", Locality: "Westminster",
+				Place: "London", Postcode: "SW1A 2AA", Country: "United Kingdom", CountryCode: "GB",
+			},
+			want: []string{"Flat 3", "10 Downing Street", "Westminster", "LONDON", "SW1A 2AA", "UNITED KINGDOM"},
+		},
+		{
+			name: "DE",
+			components: Components{
+				Name: "Bundestag", AddressNumber: "1", Street: "Platz der Republik",
+				Place: "Berlin", Postcode: "11011", Country: "Deutschland", CountryCode: "DE",
+			},
+			want: []string{"Bundestag", "Platz der Republik 1", "11011 Berlin", "DEUTSCHLAND"},
+		},
+		{
+			name: "IT",
+			components: Components{
+				AddressNumber: "12", Street: "Via del Corso", Place: "Roma", District: "RM",
+				Postcode: "00186", CountryCode: "IT",
+			},
+			want: []string{"Via del Corso 12", "00186 Roma RM"},
+		},
+		{
+			name: "FR",
+			components: Components{
+				AddressNumber: "55", Street: "Rue du Faubourg Saint-Honoré", Place: "Paris",
+				Postcode: "75008", Country: "France", CountryCode: "FR",
+			},
+			want: []string{"55 Rue du Faubourg Saint-Honoré", "75008 PARIS", "FRANCE"},
+		},
+		{
+			name: "DE Postfach",
+			components: Components{
+				POBox: "1234", Place: "Berlin", Postcode: "10117", CountryCode: "DE",
+			},
+			want: []string{"Postfach 1234", "10117 Berlin"},
+		},
+		{
+			name: "default template",
+			components: Components{
+				AddressNumber: "1", Street: "Calle Mayor", Place: "Madrid", Region: "Comunidad de Madrid",
+				Postcode: "28013", Country: "Spain", CountryCode: "ES",
+			},
+			want: []string{"1 Calle Mayor", "28013 Madrid", "Comunidad de Madrid", "SPAIN"},
+		},
+		{
+			name:       "empty",
+			components: Components{},
+			want:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.components.Lines(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComponents_String(t *testing.T) {
+	c := Components{
+		AddressNumber: "123", Street: "Main Street", Place: "Springfield",
+		Region: "IL", Postcode: "62704", CountryCode: "US",
+	}
+
+	if got, want := c.String(), "123 Main Street, Springfield, IL 62704"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := c.Block(), "123 Main Street\nSpringfield, IL 62704"; got != want {
+		t.Errorf("Block() = %q, want %q", got, want)
+	}
+}
+
+func TestFromGeocoding(t *testing.T) {
+	props := geocoding.Properties{
+		FeatureType:   "address",
+		Name:          "12 Via del Corso",
+		AddressNumber: "12",
+		Street:        "Via del Corso",
+		Context: &geocoding.Context{
+			Postcode: &geocoding.ContextElement{Name: "00186"},
+			Place:    &geocoding.ContextElement{Name: "Roma"},
+			District: &geocoding.ContextElement{Name: "Roma Capitale", ShortCode: "IT-RM"},
+			Region:   &geocoding.ContextElement{Name: "Lazio", RegionCode: "62", RegionCodeFull: "IT-62"},
+			Country:  &geocoding.ContextElement{Name: "Italia", CountryCode: "IT", CountryCodeAlpha3: "ITA"},
+		},
+	}
+
+	want := Components{
+		AddressNumber: "12", Street: "Via del Corso", Place: "Roma", District: "RM",
+		Postcode: "00186", Country: "Italia", CountryCode: "IT",
+	}
+	got := FromGeocoding(props)
+	if got != want {
+		t.Errorf("FromGeocoding() = %+v, want %+v", got, want)
+	}
+
+	if s := got.String(); s != "Via del Corso 12, 00186 Roma RM, ITALIA" {
+		t.Errorf("unexpected formatted address %q", s)
+	}
+}
+
+func TestFromGeocoding_ShortCodes(t *testing.T) {
+	props := geocoding.Properties{
+		FeatureType:   "address",
+		AddressNumber: "123",
+		Street:        "Main Street",
+		Context: &geocoding.Context{
+			Postcode: &geocoding.ContextElement{Name: "62704"},
+			Place:    &geocoding.ContextElement{Name: "Springfield"},
+			Region:   &geocoding.ContextElement{Name: "Illinois", ShortCode: "US-IL"},
+			Country:  &geocoding.ContextElement{Name: "United States", ShortCode: "us"},
+		},
+	}
+
+	want := Components{
+		AddressNumber: "123", Street: "Main Street", Place: "Springfield", Region: "IL",
+		Postcode: "62704", Country: "United States", CountryCode: "US",
+	}
+	if got := FromGeocoding(props); got != want {
+		t.Errorf("FromGeocoding() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFromGeocoding_Place(t *testing.T) {
+	props := geocoding.Properties{
+		FeatureType: "postcode",
+		Name:        "62704",
+		Postcode:    "",
+		Context: &geocoding.Context{
+			Postcode: &geocoding.ContextElement{Name: "62704"},
+			Place:    &geocoding.ContextElement{Name: "Springfield"},
+			Region:   &geocoding.ContextElement{Name: "Illinois", RegionCode: "IL", RegionCodeFull: "US-IL"},
+			Country:  &geocoding.ContextElement{Name: "United States", CountryCode: "US"},
+		},
+	}
+
+	got := FromGeocoding(props)
+	if got.Region != "IL" || got.CountryCode != "US" || got.Postcode != "62704" {
+		t.Errorf("unexpected components %+v", got)
+	}
+}
+
+func TestFromSearchBox(t *testing.T) {
+	props := searchbox.FeatureProperties{
+		FeatureType:   "poi",
+		Name:          "Blue Bottle Coffee",
+		AddressNumber: "66",
+		Street:        "Mint Street",
+		Context: &searchbox.Context{
+			Postcode: &searchbox.ContextElement{Name: "94103"},
+			Place:    &searchbox.ContextElement{Name: "San Francisco"},
+			Region:   &searchbox.ContextElement{Name: "California", RegionCode: "CA"},
+			Country:  &searchbox.ContextElement{Name: "United States", CountryCode: "us"},
+		},
+	}
+
+	want := []string{"Blue Bottle Coffee", "66 Mint Street", "San Francisco, CA 94103", "UNITED STATES"}
+	if got := FromSearchBox(props).Lines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Lines() = %q, want %q", got, want)
+	}
+
+	if got := FromSearchBox(searchbox.FeatureProperties{Street: "Main Street"}); got.Street != "Main Street" {
+		t.Errorf("unexpected components %+v", got)
+	}
+}
+
+func TestParsed_Components(t *testing.T) {
+	p, err := Parse("Flat 3, 10 Downing St, London sw1a2aa", "GB")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "Flat 3\n10 Downing Street\nLONDON\nSW1A 2AA"
+	if got := p.Components().Block(); got != want {
+		t.Errorf("Block() = %q, want %q", got, want)
+	}
+}
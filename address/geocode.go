@@ -0,0 +1,88 @@
+package address
+
+import (
+	"context"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// Options configures Geocode.
+type Options struct {
+	// Country is the ISO 3166-1 alpha-2 code of the address country. When
+	// empty it is detected from the address.
+	Country string
+
+	// Language sets the language for results (IETF language tags).
+	Language string
+
+	// Limit sets the maximum number of results (1-10, default: 5).
+	Limit *int
+
+	// BBox limits results to a bounding box.
+	BBox *geojson.BBox
+
+	// Proximity biases results toward a location.
+	Proximity *geojson.LngLat
+}
+
+// Result is the outcome of Geocode.
+type Result struct {
+	// Response is the geocoding response.
+	Response *geocoding.Response
+
+	// Parsed holds the parsed address components.
+	Parsed *Parsed
+
+	// Structured is true when the response comes from a structured request,
+	// and false when Geocode fell back to a free-text search.
+	Structured bool
+}
+
+// Geocode parses a single-line address and geocodes it with a structured
+// request. It falls back to a free-text forward request when the parse is
+// ambiguous or the structured request finds nothing. A nil opts uses the
+// defaults.
+func Geocode(ctx context.Context, svc geocoding.API, input string, opts *Options) (*Result, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	parsed, err := Parse(input, opts.Country)
+	if err != nil {
+		return nil, err
+	}
+
+	if !parsed.Ambiguous {
+		req := parsed.StructuredRequest()
+		req.Language = opts.Language
+		req.Limit = opts.Limit
+		req.BBox = opts.BBox
+		req.Proximity = opts.Proximity
+
+		resp, err := svc.ForwardStructured(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Features) > 0 {
+			return &Result{Response: resp, Parsed: parsed, Structured: true}, nil
+		}
+	}
+
+	req := &geocoding.ForwardRequest{
+		Query:     parsed.Input,
+		Language:  opts.Language,
+		Limit:     opts.Limit,
+		BBox:      opts.BBox,
+		Proximity: opts.Proximity,
+	}
+	if parsed.Country != "" {
+		req.Country = []string{parsed.Country}
+	}
+
+	resp, err := svc.Forward(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Response: resp, Parsed: parsed}, nil
+}
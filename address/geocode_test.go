@@ -0,0 +1,114 @@
+package address
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
+)
+
+const emptyResponse = `{"type":"FeatureCollection","features":[]}`
+
+func TestGeocode(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		opts           *Options
+		structured     string
+		forward        string
+		mockStatus     int
+		wantErr        bool
+		wantStructured bool
+		wantRequests   []string
+	}{
+		{
+			name:           "structured request",
+			input:          "1600 Pennsylvania Ave NW, Washington, DC 20500",
+			structured:     testutil.ForwardGeocodingResponse,
+			mockStatus:     http.StatusOK,
+			wantStructured: true,
+			wantRequests:   []string{"structured"},
+		},
+		{
+			name:         "ambiguous input falls back to free text",
+			input:        "White House",
+			forward:      testutil.ForwardGeocodingResponse,
+			mockStatus:   http.StatusOK,
+			wantRequests: []string{"forward"},
+		},
+		{
+			name:         "no structured results falls back to free text",
+			input:        "1600 Pennsylvania Ave NW, Washington, DC 20500",
+			structured:   emptyResponse,
+			forward:      testutil.ForwardGeocodingResponse,
+			mockStatus:   http.StatusOK,
+			wantRequests: []string{"structured", "forward"},
+		},
+		{
+			name:       "unsupported country",
+			input:      "Calle Mayor 1, 28013 Madrid",
+			opts:       &Options{Country: "ES"},
+			mockStatus: http.StatusOK,
+			wantErr:    true,
+		},
+		{
+			name:         "API error",
+			input:        "1600 Pennsylvania Ave NW, Washington, DC 20500",
+			structured:   testutil.ErrorResponse,
+			mockStatus:   http.StatusUnauthorized,
+			wantErr:      true,
+			wantRequests: []string{"structured"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				body := tt.structured
+				if r.URL.Query().Has("q") {
+					requests = append(requests, "forward")
+					body = tt.forward
+				} else {
+					requests = append(requests, "structured")
+					testutil.AssertQueryParam(t, r, "address_number", "1600")
+					testutil.AssertQueryParam(t, r, "country", "US")
+				}
+				testutil.MockResponse(tt.mockStatus, body)(w, r)
+			})
+			defer server.Close()
+
+			service := geocoding.New("test-token", internalhttp.New(server.URL, nil))
+
+			result, err := Geocode(context.Background(), service, tt.input, tt.opts)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Geocode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(requests) != len(tt.wantRequests) {
+				t.Fatalf("expected requests %v, got %v", tt.wantRequests, requests)
+			}
+			for i := range requests {
+				if requests[i] != tt.wantRequests[i] {
+					t.Errorf("expected requests %v, got %v", tt.wantRequests, requests)
+				}
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if result.Structured != tt.wantStructured {
+				t.Errorf("expected Structured %v, got %v", tt.wantStructured, result.Structured)
+			}
+			if len(result.Response.Features) == 0 {
+				t.Error("expected features")
+			}
+			if result.Parsed == nil {
+				t.Error("expected parsed address")
+			}
+		})
+	}
+}
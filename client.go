@@ -1,11 +1,16 @@
 package mapbox
 
 import (
+	"log/slog"
 	"net/http"
+	"slices"
 
 	"github.com/pettinz/mapbox-go-sdk/geocoding"
 	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
 	"github.com/pettinz/mapbox-go-sdk/searchbox"
+	"github.com/pettinz/mapbox-go-sdk/tiles"
+	"github.com/pettinz/mapbox-go-sdk/tokens"
 )
 
 const (
@@ -18,6 +23,8 @@ type Client struct {
 	token      string
 	baseURL    string
 	httpClient *http.Client
+	middleware []middleware.Middleware
+	logger     *slog.Logger
 	http       *internalhttp.Client
 }
 
@@ -39,8 +46,13 @@ func NewClient(token string, opts ...Option) *Client {
 		opt(c)
 	}
 
-	// Create internal HTTP client
-	c.http = internalhttp.New(c.baseURL, c.httpClient)
+	// Create internal HTTP client. The logger is the innermost middleware, so
+	// that it sees the headers set by the others.
+	mw := c.middleware
+	if c.logger != nil {
+		mw = append(slices.Clip(mw), middleware.Logging(c.logger))
+	}
+	c.http = internalhttp.New(c.baseURL, c.httpClient, mw...)
 
 	return c
 }
@@ -72,3 +84,13 @@ func (c *Client) Geocoding() *geocoding.Service {
 func (c *Client) SearchBox() *searchbox.Service {
 	return searchbox.New(c.token, c.http)
 }
+
+// Tokens returns a Tokens API service client.
+func (c *Client) Tokens() *tokens.Service {
+	return tokens.New(c.token, c.http)
+}
+
+// Tiles returns a vector and raster Tiles API service client.
+func (c *Client) Tiles() *tiles.Service {
+	return tiles.New(c.token, c.http)
+}
@@ -0,0 +1,80 @@
+package mapbox
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	"github.com/pettinz/mapbox-go-sdk/mapboxtest"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+	"github.com/pettinz/mapbox-go-sdk/searchbox"
+)
+
+// concurrently runs fn n times at once and waits for every call to return.
+func concurrently(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestClient_Dedup(t *testing.T) {
+	srv := mapboxtest.NewServer()
+	defer srv.Close()
+
+	// Hold the first request so that the others arrive while it is in flight.
+	srv.InjectFault(mapboxtest.Fault{Endpoint: mapboxtest.GeocodingForward, Delay: 200 * time.Millisecond, Count: 1})
+	client := NewClient(mapboxtest.DefaultToken, WithBaseURL(srv.URL), WithMiddleware(middleware.Dedup()))
+
+	const n = 10
+	names := make([]string, n)
+	errs := make([]error, n)
+	concurrently(n, func(i int) {
+		resp, err := client.Geocoding().Forward(context.Background(), &geocoding.ForwardRequest{Query: "Washington"})
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		if len(resp.Features) > 0 {
+			names[i] = resp.Features[0].Properties.Name
+		}
+	})
+
+	if got := len(srv.Requests()); got != 1 {
+		t.Errorf("expected 1 request to the server, got %d", got)
+	}
+	for i := range n {
+		if errs[i] != nil || names[i] != "Washington" {
+			t.Errorf("caller %d got %q, %v", i, names[i], errs[i])
+		}
+	}
+}
+
+func TestClient_Dedup_Sessions(t *testing.T) {
+	srv := mapboxtest.NewServer()
+	defer srv.Close()
+	client := NewClient(mapboxtest.DefaultToken, WithBaseURL(srv.URL), WithMiddleware(middleware.Dedup()))
+	id := srv.Gazetteer().Places()[0].MapboxID
+
+	// Retrieve requests carry their session token, so only lookups within
+	// the same session collapse.
+	sessions := []string{searchbox.NewSessionToken(), searchbox.NewSessionToken()}
+	srv.InjectFault(mapboxtest.Fault{Endpoint: mapboxtest.SearchBoxRetrieve, Delay: 200 * time.Millisecond, Count: 2})
+	concurrently(4, func(i int) {
+		req := &searchbox.RetrieveRequest{MapboxID: id, SessionToken: sessions[i%2]}
+		if _, err := client.SearchBox().Retrieve(context.Background(), req); err != nil {
+			t.Errorf("Retrieve() error = %v", err)
+		}
+	})
+
+	if got := len(srv.Requests()); got != 2 {
+		t.Errorf("expected 1 request per session, got %d", got)
+	}
+}
@@ -0,0 +1,218 @@
+package export
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// dBase limits on field names and widths.
+const (
+	dbfNameLength    = 10
+	dbfMaxCharWidth  = 254
+	dbfNumericWidth  = 19
+	dbfMaxDecimals   = 8
+	dbfHeaderVersion = 0x03
+)
+
+// dbfField describes a column of the attribute table.
+type dbfField struct {
+	key      string
+	name     string
+	kind     byte // 'C' character, 'N' numeric or 'L' logical
+	width    int
+	decimals int
+}
+
+// dbfTable is the attribute table of a shapefile: one record per feature.
+type dbfTable struct {
+	fields []dbfField
+	rows   []map[string]any
+}
+
+// newDBFTable derives the column types and widths from the values of each
+// property. Columns holding only numbers or only booleans keep their type;
+// anything else is written as text.
+func newDBFTable(fc *geojson.FeatureCollection, keys []string) (*dbfTable, error) {
+	t := &dbfTable{}
+	used := map[string]bool{}
+	for _, key := range keys {
+		field := dbfField{key: key, name: dbfFieldName(key, used)}
+
+		numeric, logical, present := true, true, false
+		for _, f := range fc.Features {
+			value := f.Properties[key]
+			if value == nil {
+				continue
+			}
+			present = true
+			if _, ok := toFloat(value); !ok {
+				numeric = false
+			}
+			if _, ok := value.(bool); !ok {
+				logical = false
+			}
+		}
+
+		switch {
+		case present && logical:
+			field.kind, field.width = 'L', 1
+		case present && numeric:
+			field.kind, field.width = 'N', dbfNumericWidth
+			for _, f := range fc.Features {
+				if v, ok := toFloat(f.Properties[key]); ok {
+					field.decimals = max(field.decimals, decimals(v))
+				}
+			}
+		default:
+			field.kind, field.width = 'C', 1
+			for _, f := range fc.Features {
+				field.width = max(field.width, len(formatValue(f.Properties[key])))
+			}
+			field.width = min(field.width, dbfMaxCharWidth)
+		}
+		t.fields = append(t.fields, field)
+	}
+
+	for _, f := range fc.Features {
+		t.rows = append(t.rows, f.Properties)
+	}
+	for _, field := range t.fields {
+		if field.kind != 'N' {
+			continue
+		}
+		for i, row := range t.rows {
+			if len(field.format(row[field.key])) > field.width {
+				return nil, fmt.Errorf("feature %d: value of %q does not fit a dBase numeric field", i, field.key)
+			}
+		}
+	}
+	return t, nil
+}
+
+// write encodes the table as a dBase III file.
+func (t *dbfTable) write(out io.Writer) error {
+	w := bufio.NewWriter(out)
+
+	recordLength := 1
+	for _, field := range t.fields {
+		recordLength += field.width
+	}
+	headerLength := 32 + 32*len(t.fields) + 1
+
+	now := time.Now()
+	header := make([]byte, 32)
+	header[0] = dbfHeaderVersion
+	header[1], header[2], header[3] = byte(now.Year()-1900), byte(now.Month()), byte(now.Day())
+	binary.LittleEndian.PutUint32(header[4:], uint32(len(t.rows)))
+	binary.LittleEndian.PutUint16(header[8:], uint16(headerLength))
+	binary.LittleEndian.PutUint16(header[10:], uint16(recordLength))
+	w.Write(header)
+
+	for _, field := range t.fields {
+		descriptor := make([]byte, 32)
+		copy(descriptor[:11], field.name)
+		descriptor[11] = field.kind
+		descriptor[16] = byte(field.width)
+		descriptor[17] = byte(field.decimals)
+		w.Write(descriptor)
+	}
+	w.WriteByte(0x0D)
+
+	for _, row := range t.rows {
+		w.WriteByte(' ')
+		for _, field := range t.fields {
+			w.WriteString(field.format(row[field.key]))
+		}
+	}
+	w.WriteByte(0x1A)
+	return w.Flush()
+}
+
+// format renders a value padded to the field width: numbers right-aligned,
+// text left-aligned and truncated on a character boundary.
+func (f dbfField) format(value any) string {
+	switch f.kind {
+	case 'L':
+		switch value {
+		case true:
+			return "T"
+		case false:
+			return "F"
+		}
+		return "?"
+	case 'N':
+		v, ok := toFloat(value)
+		if !ok {
+			return strings.Repeat(" ", f.width)
+		}
+		return fmt.Sprintf("%*s", f.width, strconv.FormatFloat(v, 'f', f.decimals, 64))
+	}
+
+	s := formatValue(value)
+	for len(s) > f.width {
+		_, size := utf8.DecodeLastRuneInString(s)
+		s = s[:len(s)-size]
+	}
+	return s + strings.Repeat(" ", f.width-len(s))
+}
+
+// dbfFieldName shortens a property name to a unique dBase field name of at
+// most 10 ASCII letters, digits and underscores.
+func dbfFieldName(key string, used map[string]bool) string {
+	var b strings.Builder
+	for _, r := range key {
+		if r < utf8.RuneSelf && (r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	base := b.String()
+	if base == "" {
+		base = "field"
+	}
+
+	name := base[:min(len(base), dbfNameLength)]
+	for n := 1; used[strings.ToUpper(name)]; n++ {
+		suffix := "_" + strconv.Itoa(n)
+		name = base[:min(len(base), dbfNameLength-len(suffix))] + suffix
+	}
+	used[strings.ToUpper(name)] = true
+	return name
+}
+
+// toFloat returns the numeric value of a property.
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, !math.IsNaN(v) && !math.IsInf(v, 0)
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// decimals returns the number of decimal places needed to write v, capped
+// at what a dBase numeric field holds.
+func decimals(v float64) int {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return min(len(s)-i-1, dbfMaxDecimals)
+	}
+	return 0
+}
@@ -0,0 +1,118 @@
+// Package export writes geocoding and search results to formats used by
+// desktop GIS and GPS devices: KML, GPX and ESRI Shapefile.
+//
+// The writers take a geojson.FeatureCollection, as returned by the ToGeoJSON
+// methods of the geocoding and searchbox responses, so property selection and
+// renaming are configured there with geojson.ExportOptions.
+package export
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// Options configures the writers. A nil Options uses the defaults.
+type Options struct {
+	// Name is the document name (KML), metadata name (GPX) or base file
+	// name of a zipped shapefile. Defaults to "results".
+	Name string
+
+	// NameProperty is the property used as the placemark or waypoint name.
+	// Defaults to "name".
+	NameProperty string
+
+	// DescriptionProperty is the property used as the description. Defaults
+	// to "full_address", falling back to "place_name" for geocoding results.
+	DescriptionProperty string
+
+	// Fields lists the properties written as KML extended data and shapefile
+	// attributes. Nil writes every property with a scalar value.
+	Fields []string
+}
+
+func (o *Options) name() string {
+	if o != nil && o.Name != "" {
+		return o.Name
+	}
+	return "results"
+}
+
+// featureName returns the name of a feature.
+func (o *Options) featureName(f *geojson.Feature) string {
+	key := "name"
+	if o != nil && o.NameProperty != "" {
+		key = o.NameProperty
+	}
+	return formatValue(f.Properties[key])
+}
+
+// featureDescription returns the description of a feature.
+func (o *Options) featureDescription(f *geojson.Feature) string {
+	if o != nil && o.DescriptionProperty != "" {
+		return formatValue(f.Properties[o.DescriptionProperty])
+	}
+	if d := formatValue(f.Properties["full_address"]); d != "" {
+		return d
+	}
+	return formatValue(f.Properties["place_name"])
+}
+
+// fields returns the attribute properties, in a stable order.
+func (o *Options) fields(fc *geojson.FeatureCollection) []string {
+	if o != nil && o.Fields != nil {
+		return o.Fields
+	}
+
+	seen := map[string]bool{}
+	var keys []string
+	for _, f := range fc.Features {
+		for key, value := range f.Properties {
+			if seen[key] || !isScalar(value) {
+				continue
+			}
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// isScalar reports whether v is a string, number, boolean or null.
+func isScalar(v any) bool {
+	switch v.(type) {
+	case nil, string, bool, float64, float32, int, int64, int32:
+		return true
+	}
+	return false
+}
+
+// formatValue renders a property value as text. Arrays and objects are
+// rendered as JSON.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// formatCoord renders a coordinate with the shortest exact representation.
+func formatCoord(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,134 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+const gpxNamespace = "http://www.topografix.com/GPX/1/1"
+
+type gpxWaypoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Name string  `xml:"name,omitempty"`
+	Desc string  `xml:"desc,omitempty"`
+	Type string  `xml:"type,omitempty"`
+}
+
+type gpxTrack struct {
+	XMLName  xml.Name     `xml:"trk"`
+	Name     string       `xml:"name,omitempty"`
+	Desc     string       `xml:"desc,omitempty"`
+	Segments []gpxSegment `xml:"trkseg"`
+}
+
+type gpxSegment struct {
+	Points []gpxWaypoint `xml:"trkpt"`
+}
+
+// WriteGPX writes the collection as a GPX 1.1 document, streaming to w. Point
+// and MultiPoint features become waypoints; LineString and MultiLineString
+// features become tracks. Features without a geometry are skipped, and other
+// geometries are rejected since GPX cannot represent areas.
+func WriteGPX(w io.Writer, fc *geojson.FeatureCollection, opts *Options) error {
+	if fc == nil {
+		return fmt.Errorf("feature collection is required")
+	}
+	for i, f := range fc.Features {
+		switch f.Geometry.(type) {
+		case nil, *geojson.Point, *geojson.MultiPoint, *geojson.LineString, *geojson.MultiLineString:
+		default:
+			return fmt.Errorf("feature %d: unsupported geometry %s for GPX", i, f.Geometry.GeometryType())
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	gpx := xml.StartElement{
+		Name: xml.Name{Local: "gpx"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "xmlns"}, Value: gpxNamespace},
+			{Name: xml.Name{Local: "version"}, Value: "1.1"},
+			{Name: xml.Name{Local: "creator"}, Value: "mapbox-go-sdk"},
+		},
+	}
+	if err := enc.EncodeToken(gpx); err != nil {
+		return err
+	}
+	metadata := struct {
+		XMLName xml.Name `xml:"metadata"`
+		Name    string   `xml:"name"`
+	}{Name: opts.name()}
+	if err := enc.Encode(metadata); err != nil {
+		return err
+	}
+
+	// GPX requires every waypoint before the first track.
+	wpt := xml.StartElement{Name: xml.Name{Local: "wpt"}}
+	for _, f := range fc.Features {
+		var positions [][]float64
+		switch g := f.Geometry.(type) {
+		case *geojson.Point:
+			positions = [][]float64{g.Coordinates}
+		case *geojson.MultiPoint:
+			positions = g.Coordinates
+		}
+		for _, pos := range positions {
+			if len(pos) < 2 {
+				continue
+			}
+			waypoint := gpxWaypoint{
+				Lon:  pos[0],
+				Lat:  pos[1],
+				Name: opts.featureName(f),
+				Desc: opts.featureDescription(f),
+				Type: formatValue(f.Properties["feature_type"]),
+			}
+			if err := enc.EncodeElement(waypoint, wpt); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, f := range fc.Features {
+		var lines [][][]float64
+		switch g := f.Geometry.(type) {
+		case *geojson.LineString:
+			lines = [][][]float64{g.Coordinates}
+		case *geojson.MultiLineString:
+			lines = g.Coordinates
+		default:
+			continue
+		}
+		track := gpxTrack{Name: opts.featureName(f), Desc: opts.featureDescription(f)}
+		for _, line := range lines {
+			var segment gpxSegment
+			for _, pos := range line {
+				if len(pos) >= 2 {
+					segment.Points = append(segment.Points, gpxWaypoint{Lon: pos[0], Lat: pos[1]})
+				}
+			}
+			track.Segments = append(track.Segments, segment)
+		}
+		if err := enc.Encode(track); err != nil {
+			return err
+		}
+	}
+
+	if err := enc.EncodeToken(gpx.End()); err != nil {
+		return err
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,95 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+type gpxDocument struct {
+	XMLName   xml.Name `xml:"http://www.topografix.com/GPX/1/1 gpx"`
+	Version   string   `xml:"version,attr"`
+	Name      string   `xml:"metadata>name"`
+	Waypoints []struct {
+		Lat  float64 `xml:"lat,attr"`
+		Lon  float64 `xml:"lon,attr"`
+		Name string  `xml:"name"`
+		Desc string  `xml:"desc"`
+		Type string  `xml:"type"`
+	} `xml:"wpt"`
+	Tracks []struct {
+		Name     string `xml:"name"`
+		Segments []struct {
+			Points []struct {
+				Lat float64 `xml:"lat,attr"`
+				Lon float64 `xml:"lon,attr"`
+			} `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+func TestWriteGPX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGPX(&buf, geocodingResults(t), nil); err != nil {
+		t.Fatalf("WriteGPX() error = %v", err)
+	}
+
+	var doc gpxDocument
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid GPX: %v\n%s", err, buf.String())
+	}
+	if doc.Version != "1.1" || doc.Name != "results" || len(doc.Waypoints) != 1 {
+		t.Fatalf("unexpected document %+v", doc)
+	}
+	wpt := doc.Waypoints[0]
+	if wpt.Lat != 38.897676 || wpt.Lon != -77.036543 {
+		t.Errorf("unexpected position %v,%v", wpt.Lat, wpt.Lon)
+	}
+	if wpt.Name != "1600 Pennsylvania Avenue NW" || wpt.Type != "address" || wpt.Desc == "" {
+		t.Errorf("unexpected waypoint %+v", wpt)
+	}
+}
+
+func TestWriteGPX_WaypointsBeforeTracks(t *testing.T) {
+	line := geojson.NewFeature(geojson.NewMultiLineString([][][]float64{{{0, 0}, {1, 1}}, {{2, 2}, {3, 3}}}))
+	line.Properties = map[string]any{"name": "Route"}
+	fc := geojson.NewFeatureCollection(
+		line,
+		geojson.NewFeature(geojson.NewMultiPoint([][]float64{{5, 6}, {7, 8}})),
+		geojson.NewFeature(nil),
+	)
+
+	var buf bytes.Buffer
+	if err := WriteGPX(&buf, fc, &Options{Name: "Trip"}); err != nil {
+		t.Fatalf("WriteGPX() error = %v", err)
+	}
+	out := buf.String()
+	if strings.Index(out, "<wpt") > strings.Index(out, "<trk>") {
+		t.Errorf("expected waypoints before tracks:\n%s", out)
+	}
+
+	var doc gpxDocument
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Waypoints) != 2 || len(doc.Tracks) != 1 {
+		t.Fatalf("unexpected document %+v", doc)
+	}
+	if doc.Tracks[0].Name != "Route" || len(doc.Tracks[0].Segments) != 2 || doc.Tracks[0].Segments[1].Points[1].Lon != 3 {
+		t.Errorf("unexpected track %+v", doc.Tracks[0])
+	}
+}
+
+func TestWriteGPX_UnsupportedGeometry(t *testing.T) {
+	fc := geojson.NewFeatureCollection(geojson.NewFeature(geojson.NewPolygon([][][]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}})))
+	var buf bytes.Buffer
+	if err := WriteGPX(&buf, fc, nil); err == nil {
+		t.Error("expected error for polygon")
+	}
+	if buf.Len() != 0 {
+		t.Error("expected nothing written on error")
+	}
+}
@@ -0,0 +1,214 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+const kmlNamespace = "http://www.opengis.net/kml/2.2"
+
+type kmlPlacemark struct {
+	XMLName      xml.Name         `xml:"Placemark"`
+	ID           string           `xml:"id,attr,omitempty"`
+	Name         string           `xml:"name,omitempty"`
+	Description  string           `xml:"description,omitempty"`
+	ExtendedData *kmlExtendedData `xml:"ExtendedData,omitempty"`
+	kmlGeometry
+}
+
+type kmlExtendedData struct {
+	Data []kmlData `xml:"Data"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// kmlGeometry holds exactly one KML geometry.
+type kmlGeometry struct {
+	Point         *kmlCoordinates `xml:"Point,omitempty"`
+	LineString    *kmlCoordinates `xml:"LineString,omitempty"`
+	Polygon       *kmlPolygon     `xml:"Polygon,omitempty"`
+	MultiGeometry *kmlMulti       `xml:"MultiGeometry,omitempty"`
+}
+
+type kmlCoordinates struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlPolygon struct {
+	Outer kmlBoundary   `xml:"outerBoundaryIs"`
+	Inner []kmlBoundary `xml:"innerBoundaryIs"`
+}
+
+type kmlBoundary struct {
+	LinearRing kmlCoordinates `xml:"LinearRing"`
+}
+
+type kmlMulti struct {
+	Points      []kmlCoordinates `xml:"Point"`
+	LineStrings []kmlCoordinates `xml:"LineString"`
+	Polygons    []kmlPolygon     `xml:"Polygon"`
+	Geometries  []kmlMulti       `xml:"MultiGeometry"`
+}
+
+// WriteKML writes the collection as a KML 2.2 document with one placemark per
+// feature, streaming placemarks to w as they are encoded. Features without a
+// geometry are written as placemarks without one.
+func WriteKML(w io.Writer, fc *geojson.FeatureCollection, opts *Options) error {
+	if fc == nil {
+		return fmt.Errorf("feature collection is required")
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	kml := xml.StartElement{Name: xml.Name{Local: "kml"}, Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: kmlNamespace}}}
+	document := xml.StartElement{Name: xml.Name{Local: "Document"}}
+	if err := enc.EncodeToken(kml); err != nil {
+		return err
+	}
+	if err := enc.EncodeToken(document); err != nil {
+		return err
+	}
+	if err := enc.EncodeElement(opts.name(), xml.StartElement{Name: xml.Name{Local: "name"}}); err != nil {
+		return err
+	}
+
+	fields := opts.fields(fc)
+	for i, f := range fc.Features {
+		placemark, err := newKMLPlacemark(f, fields, opts)
+		if err != nil {
+			return fmt.Errorf("feature %d: %w", i, err)
+		}
+		if err := enc.Encode(placemark); err != nil {
+			return err
+		}
+	}
+
+	if err := enc.EncodeToken(document.End()); err != nil {
+		return err
+	}
+	if err := enc.EncodeToken(kml.End()); err != nil {
+		return err
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func newKMLPlacemark(f *geojson.Feature, fields []string, opts *Options) (*kmlPlacemark, error) {
+	placemark := &kmlPlacemark{
+		Name:        opts.featureName(f),
+		Description: opts.featureDescription(f),
+	}
+	if f.ID != nil {
+		placemark.ID = formatValue(f.ID)
+	}
+
+	for _, key := range fields {
+		value, ok := f.Properties[key]
+		if !ok || value == nil {
+			continue
+		}
+		if placemark.ExtendedData == nil {
+			placemark.ExtendedData = &kmlExtendedData{}
+		}
+		placemark.ExtendedData.Data = append(placemark.ExtendedData.Data, kmlData{Name: key, Value: formatValue(value)})
+	}
+
+	if f.Geometry == nil {
+		return placemark, nil
+	}
+	multi, err := kmlGeometryOf(f.Geometry)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(multi.Points) == 1 && f.Geometry.GeometryType() == geojson.TypePoint:
+		placemark.Point = &multi.Points[0]
+	case len(multi.LineStrings) == 1 && f.Geometry.GeometryType() == geojson.TypeLineString:
+		placemark.LineString = &multi.LineStrings[0]
+	case len(multi.Polygons) == 1 && f.Geometry.GeometryType() == geojson.TypePolygon:
+		placemark.Polygon = &multi.Polygons[0]
+	default:
+		placemark.MultiGeometry = multi
+	}
+	return placemark, nil
+}
+
+// kmlGeometryOf converts a geometry to KML, wrapped in a MultiGeometry that
+// the caller unwraps for single geometries.
+func kmlGeometryOf(g geojson.Geometry) (*kmlMulti, error) {
+	multi := &kmlMulti{}
+	switch g := g.(type) {
+	case *geojson.Point:
+		multi.Points = append(multi.Points, kmlCoordinates{kmlPositions([][]float64{g.Coordinates})})
+	case *geojson.MultiPoint:
+		for _, p := range g.Coordinates {
+			multi.Points = append(multi.Points, kmlCoordinates{kmlPositions([][]float64{p})})
+		}
+	case *geojson.LineString:
+		multi.LineStrings = append(multi.LineStrings, kmlCoordinates{kmlPositions(g.Coordinates)})
+	case *geojson.MultiLineString:
+		for _, line := range g.Coordinates {
+			multi.LineStrings = append(multi.LineStrings, kmlCoordinates{kmlPositions(line)})
+		}
+	case *geojson.Polygon:
+		multi.Polygons = append(multi.Polygons, newKMLPolygon(g.Coordinates))
+	case *geojson.MultiPolygon:
+		for _, polygon := range g.Coordinates {
+			multi.Polygons = append(multi.Polygons, newKMLPolygon(polygon))
+		}
+	case *geojson.GeometryCollection:
+		for _, member := range g.Geometries {
+			child, err := kmlGeometryOf(member)
+			if err != nil {
+				return nil, err
+			}
+			multi.Geometries = append(multi.Geometries, *child)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported geometry %T", g)
+	}
+	return multi, nil
+}
+
+func newKMLPolygon(rings [][][]float64) kmlPolygon {
+	var polygon kmlPolygon
+	for i, ring := range rings {
+		boundary := kmlBoundary{LinearRing: kmlCoordinates{kmlPositions(ring)}}
+		if i == 0 {
+			polygon.Outer = boundary
+			continue
+		}
+		polygon.Inner = append(polygon.Inner, boundary)
+	}
+	return polygon
+}
+
+// kmlPositions renders positions as KML "lon,lat[,alt]" tuples.
+func kmlPositions(positions [][]float64) string {
+	tuples := make([]string, 0, len(positions))
+	for _, pos := range positions {
+		if len(pos) < 2 {
+			continue
+		}
+		parts := make([]string, 0, 3)
+		for _, v := range pos[:min(len(pos), 3)] {
+			parts = append(parts, formatCoord(v))
+		}
+		tuples = append(tuples, strings.Join(parts, ","))
+	}
+	return strings.Join(tuples, " ")
+}
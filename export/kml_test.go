@@ -0,0 +1,113 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
+)
+
+// geocodingResults returns the forward geocoding fixture as a collection.
+func geocodingResults(t *testing.T) *geojson.FeatureCollection {
+	t.Helper()
+
+	var resp geocoding.Response
+	if err := json.Unmarshal([]byte(testutil.ForwardGeocodingResponse), &resp); err != nil {
+		t.Fatal(err)
+	}
+	fc, err := resp.ToGeoJSON(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fc
+}
+
+func TestWriteKML(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteKML(&buf, geocodingResults(t), &Options{Name: "Offices", Fields: []string{"feature_type", "context.region.region_code"}})
+	if err != nil {
+		t.Fatalf("WriteKML() error = %v", err)
+	}
+
+	var doc struct {
+		XMLName  xml.Name `xml:"http://www.opengis.net/kml/2.2 kml"`
+		Document struct {
+			Name       string `xml:"name"`
+			Placemarks []struct {
+				ID          string `xml:"id,attr"`
+				Name        string `xml:"name"`
+				Description string `xml:"description"`
+				Data        []struct {
+					Name  string `xml:"name,attr"`
+					Value string `xml:"value"`
+				} `xml:"ExtendedData>Data"`
+				Coordinates string `xml:"Point>coordinates"`
+			} `xml:"Placemark"`
+		} `xml:"Document"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid KML: %v\n%s", err, buf.String())
+	}
+
+	if doc.Document.Name != "Offices" || len(doc.Document.Placemarks) != 1 {
+		t.Fatalf("unexpected document %+v", doc.Document)
+	}
+	p := doc.Document.Placemarks[0]
+	if p.ID != "address.123456" || p.Name != "1600 Pennsylvania Avenue NW" {
+		t.Errorf("unexpected placemark %+v", p)
+	}
+	if p.Coordinates != "-77.036543,38.897676" {
+		t.Errorf("Coordinates = %q", p.Coordinates)
+	}
+	if !strings.Contains(p.Description, "Washington") {
+		t.Errorf("expected full address as description, got %q", p.Description)
+	}
+	if len(p.Data) != 2 || p.Data[0].Name != "feature_type" || p.Data[1].Value != "DC" {
+		t.Errorf("unexpected extended data %+v", p.Data)
+	}
+}
+
+func TestWriteKML_Geometries(t *testing.T) {
+	fc := geojson.NewFeatureCollection(
+		geojson.NewFeature(geojson.NewLineString([][]float64{{0, 0}, {1, 1}})),
+		geojson.NewFeature(geojson.NewPolygon([][][]float64{
+			{{0, 0}, {4, 0}, {4, 4}, {0, 0}},
+			{{1, 1}, {2, 1}, {2, 2}, {1, 1}},
+		})),
+		geojson.NewFeature(geojson.NewMultiPoint([][]float64{{1, 2}, {3, 4}})),
+		geojson.NewFeature(nil),
+	)
+
+	var buf bytes.Buffer
+	if err := WriteKML(&buf, fc, nil); err != nil {
+		t.Fatalf("WriteKML() error = %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"<name>results</name>",
+		"<LineString>\n        <coordinates>0,0 1,1</coordinates>",
+		"<outerBoundaryIs>",
+		"<innerBoundaryIs>",
+		"<MultiGeometry>",
+		"<coordinates>3,4</coordinates>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "<Placemark>"); n != 4 {
+		t.Errorf("expected 4 placemarks, got %d", n)
+	}
+}
+
+func TestWriteKML_NilCollection(t *testing.T) {
+	if err := WriteKML(&bytes.Buffer{}, nil, nil); err == nil {
+		t.Error("expected error for nil collection")
+	}
+}
@@ -0,0 +1,339 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// Shape types used by the writer, as defined by the ESRI Shapefile
+// specification.
+const (
+	shapeNull       = 0
+	shapePoint      = 1
+	shapePolyLine   = 3
+	shapePolygon    = 5
+	shapeMultiPoint = 8
+)
+
+// wgs84PRJ is the projection file content for WGS 84 longitude/latitude,
+// the coordinate system of every SDK result.
+const wgs84PRJ = `GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]]`
+
+// ShapefileWriters holds the destinations of the files of a shapefile
+// bundle. SHP, SHX and DBF are required; PRJ and CPG are written when set.
+type ShapefileWriters struct {
+	SHP io.Writer
+	SHX io.Writer
+	DBF io.Writer
+	PRJ io.Writer
+	CPG io.Writer
+}
+
+// WriteShapefile writes the collection as an ESRI Shapefile. All features
+// must share one shape type: points, multipoints, lines (LineString and
+// MultiLineString) or polygons (Polygon and MultiPolygon). Features without
+// a geometry are written as null shapes. Attributes are taken from
+// opts.Fields, with names shortened to the 10 characters dBase allows.
+func WriteShapefile(out ShapefileWriters, fc *geojson.FeatureCollection, opts *Options) error {
+	if out.SHP == nil || out.SHX == nil || out.DBF == nil {
+		return fmt.Errorf("shp, shx and dbf writers are required")
+	}
+	if fc == nil {
+		return fmt.Errorf("feature collection is required")
+	}
+
+	shapes := make([]shape, len(fc.Features))
+	kind := shapeNull
+	for i, f := range fc.Features {
+		s, err := newShape(f.Geometry)
+		if err != nil {
+			return fmt.Errorf("feature %d: %w", i, err)
+		}
+		if s.kind != shapeNull {
+			if kind != shapeNull && kind != s.kind {
+				return fmt.Errorf("feature %d: shapefiles hold a single shape type, got %s after %s", i, shapeName(s.kind), shapeName(kind))
+			}
+			kind = s.kind
+		}
+		shapes[i] = s
+	}
+
+	table, err := newDBFTable(fc, opts.fields(fc))
+	if err != nil {
+		return err
+	}
+
+	if err := writeShapes(out.SHP, out.SHX, kind, shapes); err != nil {
+		return err
+	}
+	if err := table.write(out.DBF); err != nil {
+		return err
+	}
+	if out.PRJ != nil {
+		if _, err := io.WriteString(out.PRJ, wgs84PRJ); err != nil {
+			return err
+		}
+	}
+	if out.CPG != nil {
+		if _, err := io.WriteString(out.CPG, "UTF-8"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteShapefileZip writes the shapefile bundle (shp, shx, dbf, prj and cpg)
+// as a zip archive, the form most GIS tools import directly. The entries are
+// named after opts.Name.
+func WriteShapefileZip(w io.Writer, fc *geojson.FeatureCollection, opts *Options) error {
+	name := opts.name()
+	zw := zip.NewWriter(w)
+
+	// Zip entries are written one at a time while WriteShapefile fills all
+	// files together, so each file is buffered before being archived.
+	var shp, shx, dbf, prj, cpg bytes.Buffer
+	err := WriteShapefile(ShapefileWriters{SHP: &shp, SHX: &shx, DBF: &dbf, PRJ: &prj, CPG: &cpg}, fc, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range []struct {
+		ext  string
+		data *bytes.Buffer
+	}{
+		{"shp", &shp}, {"shx", &shx}, {"dbf", &dbf}, {"prj", &prj}, {"cpg", &cpg},
+	} {
+		f, err := zw.Create(name + "." + entry.ext)
+		if err != nil {
+			return err
+		}
+		if _, err := entry.data.WriteTo(f); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// shape is a geometry in shapefile form: a shape type and its points grouped
+// into parts. Points and multipoints use a single part.
+type shape struct {
+	kind  int
+	parts [][][]float64
+}
+
+func newShape(g geojson.Geometry) (shape, error) {
+	var s shape
+	switch g := g.(type) {
+	case nil:
+	case *geojson.Point:
+		s = shape{kind: shapePoint, parts: [][][]float64{{g.Coordinates}}}
+	case *geojson.MultiPoint:
+		s = shape{kind: shapeMultiPoint, parts: [][][]float64{g.Coordinates}}
+	case *geojson.LineString:
+		s = shape{kind: shapePolyLine, parts: [][][]float64{g.Coordinates}}
+	case *geojson.MultiLineString:
+		s = shape{kind: shapePolyLine, parts: g.Coordinates}
+	case *geojson.Polygon:
+		s = shape{kind: shapePolygon, parts: orientRings(g.Coordinates)}
+	case *geojson.MultiPolygon:
+		s = shape{kind: shapePolygon}
+		for _, polygon := range g.Coordinates {
+			s.parts = append(s.parts, orientRings(polygon)...)
+		}
+	default:
+		return shape{}, fmt.Errorf("unsupported geometry %s for shapefile", g.GeometryType())
+	}
+
+	// Drop positions without both coordinates and parts left empty; a shape
+	// without points is written as a null shape.
+	var parts [][][]float64
+	for _, part := range s.parts {
+		var kept [][]float64
+		for _, pos := range part {
+			if len(pos) >= 2 {
+				kept = append(kept, pos)
+			}
+		}
+		if len(kept) > 0 {
+			parts = append(parts, kept)
+		}
+	}
+	s.parts = parts
+	if len(parts) == 0 {
+		return shape{kind: shapeNull}, nil
+	}
+	return s, nil
+}
+
+// orientRings returns the rings of a polygon in shapefile order: the exterior
+// ring clockwise and holes counterclockwise, the opposite of RFC 7946.
+func orientRings(rings [][][]float64) [][][]float64 {
+	out := make([][][]float64, len(rings))
+	for i, ring := range rings {
+		clockwise := signedArea(ring) < 0
+		if clockwise == (i == 0) {
+			out[i] = ring
+			continue
+		}
+		reversed := make([][]float64, len(ring))
+		for j, pos := range ring {
+			reversed[len(ring)-1-j] = pos
+		}
+		out[i] = reversed
+	}
+	return out
+}
+
+// signedArea returns twice the signed area of a ring, positive when the ring
+// is counterclockwise.
+func signedArea(ring [][]float64) float64 {
+	var area float64
+	for i := 0; i+1 < len(ring); i++ {
+		if len(ring[i]) < 2 || len(ring[i+1]) < 2 {
+			continue
+		}
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return area
+}
+
+// numPoints returns the number of points in the shape.
+func (s shape) numPoints() int {
+	n := 0
+	for _, part := range s.parts {
+		n += len(part)
+	}
+	return n
+}
+
+// contentLength returns the size of the record content in bytes.
+func (s shape) contentLength() int {
+	switch s.kind {
+	case shapePoint:
+		return 20
+	case shapeMultiPoint:
+		return 40 + 16*s.numPoints()
+	case shapePolyLine, shapePolygon:
+		return 44 + 4*len(s.parts) + 16*s.numPoints()
+	}
+	return 4
+}
+
+// bounds returns the shape's bounding box as xmin, ymin, xmax, ymax.
+func (s shape) bounds() [4]float64 {
+	b := [4]float64{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+	for _, part := range s.parts {
+		for _, pos := range part {
+			b[0], b[2] = math.Min(b[0], pos[0]), math.Max(b[2], pos[0])
+			b[1], b[3] = math.Min(b[1], pos[1]), math.Max(b[3], pos[1])
+		}
+	}
+	return b
+}
+
+func shapeName(kind int) string {
+	switch kind {
+	case shapePoint:
+		return "Point"
+	case shapeMultiPoint:
+		return "MultiPoint"
+	case shapePolyLine:
+		return "PolyLine"
+	case shapePolygon:
+		return "Polygon"
+	}
+	return "Null"
+}
+
+// writeShapes streams the main file and its index. Both headers carry sizes
+// and the overall bounding box, which are computed before any record is
+// written.
+func writeShapes(shpOut, shxOut io.Writer, kind int, shapes []shape) error {
+	var box [4]float64
+	first := true
+	shpLength := 100
+	for _, s := range shapes {
+		shpLength += 8 + s.contentLength()
+		if s.kind == shapeNull {
+			continue
+		}
+		b := s.bounds()
+		if first {
+			box, first = b, false
+			continue
+		}
+		box = [4]float64{math.Min(box[0], b[0]), math.Min(box[1], b[1]), math.Max(box[2], b[2]), math.Max(box[3], b[3])}
+	}
+
+	shp := bufio.NewWriter(shpOut)
+	shx := bufio.NewWriter(shxOut)
+	writeShapeHeader(shp, shpLength, kind, box)
+	writeShapeHeader(shx, 100+8*len(shapes), kind, box)
+
+	offset := 100
+	for i, s := range shapes {
+		length := s.contentLength()
+		writeBE(shp, int32(i+1), int32(length/2))
+		writeBE(shx, int32(offset/2), int32(length/2))
+		offset += 8 + length
+
+		writeLE(shp, int32(s.kind))
+		switch s.kind {
+		case shapePoint:
+			pos := s.parts[0][0]
+			writeLE(shp, pos[0], pos[1])
+		case shapeMultiPoint:
+			writeLE(shp, s.bounds(), int32(s.numPoints()))
+			writePoints(shp, s.parts[0])
+		case shapePolyLine, shapePolygon:
+			writeLE(shp, s.bounds(), int32(len(s.parts)), int32(s.numPoints()))
+			start := 0
+			for _, part := range s.parts {
+				writeLE(shp, int32(start))
+				start += len(part)
+			}
+			for _, part := range s.parts {
+				writePoints(shp, part)
+			}
+		}
+	}
+
+	if err := shp.Flush(); err != nil {
+		return err
+	}
+	return shx.Flush()
+}
+
+// writeShapeHeader writes the 100-byte header shared by the main file and
+// the index; length is the file size in bytes.
+func writeShapeHeader(w io.Writer, length, kind int, box [4]float64) {
+	writeBE(w, int32(9994), [5]int32{}, int32(length/2))
+	writeLE(w, int32(1000), int32(kind), box, [4]float64{})
+}
+
+func writePoints(w io.Writer, points [][]float64) {
+	for _, pos := range points {
+		writeLE(w, pos[0], pos[1])
+	}
+}
+
+// writeBE and writeLE write fixed-size values. Errors surface when the
+// buffered writer is flushed.
+func writeBE(w io.Writer, values ...any) {
+	for _, v := range values {
+		_ = binary.Write(w, binary.BigEndian, v)
+	}
+}
+
+func writeLE(w io.Writer, values ...any) {
+	for _, v := range values {
+		_ = binary.Write(w, binary.LittleEndian, v)
+	}
+}
@@ -0,0 +1,192 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+type shapefileBuffers struct {
+	shp, shx, dbf, prj bytes.Buffer
+}
+
+func writeShapefile(t *testing.T, fc *geojson.FeatureCollection, opts *Options) *shapefileBuffers {
+	t.Helper()
+
+	var b shapefileBuffers
+	err := WriteShapefile(ShapefileWriters{SHP: &b.shp, SHX: &b.shx, DBF: &b.dbf, PRJ: &b.prj}, fc, opts)
+	if err != nil {
+		t.Fatalf("WriteShapefile() error = %v", err)
+	}
+	return &b
+}
+
+func TestWriteShapefile_Points(t *testing.T) {
+	a := geojson.NewFeature(geojson.NewPoint(12.5, 41.9))
+	a.Properties = map[string]any{"name": "Roma", "population": 2873000.0, "capital": true, "context.region.name": "Lazio"}
+	b := geojson.NewFeature(geojson.NewPoint(9.19, 45.46))
+	b.Properties = map[string]any{"name": "Milano", "population": 1352000.5, "capital": false, "context.region.name": "Lombardia"}
+
+	out := writeShapefile(t, geojson.NewFeatureCollection(a, b), nil)
+
+	shp := out.shp.Bytes()
+	if code := binary.BigEndian.Uint32(shp[0:]); code != 9994 {
+		t.Errorf("file code = %d", code)
+	}
+	if length := binary.BigEndian.Uint32(shp[24:]); int(length)*2 != len(shp) {
+		t.Errorf("header length %d words, file is %d bytes", length, len(shp))
+	}
+	if kind := binary.LittleEndian.Uint32(shp[32:]); kind != shapePoint {
+		t.Errorf("shape type = %d", kind)
+	}
+	var box [4]float64
+	for i := range box {
+		box[i] = math.Float64frombits(binary.LittleEndian.Uint64(shp[36+8*i:]))
+	}
+	if box != [4]float64{9.19, 41.9, 12.5, 45.46} {
+		t.Errorf("bbox = %v", box)
+	}
+	if len(shp) != 100+2*28 {
+		t.Errorf("unexpected shp size %d", len(shp))
+	}
+	if x := math.Float64frombits(binary.LittleEndian.Uint64(shp[100+8+4:])); x != 12.5 {
+		t.Errorf("first point x = %v", x)
+	}
+
+	shx := out.shx.Bytes()
+	if len(shx) != 100+2*8 || binary.BigEndian.Uint32(shx[24:])*2 != uint32(len(shx)) {
+		t.Errorf("unexpected shx size %d", len(shx))
+	}
+	if offset := binary.BigEndian.Uint32(shx[108:]); offset != (100+28)/2 {
+		t.Errorf("second record offset = %d words", offset)
+	}
+
+	dbf := out.dbf.Bytes()
+	if records := binary.LittleEndian.Uint32(dbf[4:]); records != 2 {
+		t.Errorf("dbf records = %d", records)
+	}
+	headerLength := int(binary.LittleEndian.Uint16(dbf[8:]))
+	recordLength := int(binary.LittleEndian.Uint16(dbf[10:]))
+	if len(dbf) != headerLength+2*recordLength+1 || dbf[len(dbf)-1] != 0x1A {
+		t.Fatalf("unexpected dbf size %d", len(dbf))
+	}
+
+	type column struct {
+		name     string
+		kind     byte
+		width    int
+		decimals int
+	}
+	var columns []column
+	for off := 32; dbf[off] != 0x0D; off += 32 {
+		name := strings.TrimRight(string(dbf[off:off+11]), "\x00")
+		columns = append(columns, column{name, dbf[off+11], int(dbf[off+16]), int(dbf[off+17])})
+	}
+	want := []column{
+		{"capital", 'L', 1, 0},
+		{"context_re", 'C', 9, 0},
+		{"name", 'C', 6, 0},
+		{"population", 'N', 19, 1},
+	}
+	if !reflect.DeepEqual(columns, want) {
+		t.Errorf("columns = %+v, want %+v", columns, want)
+	}
+
+	second := string(dbf[headerLength+recordLength : headerLength+2*recordLength])
+	if second != " FLombardiaMilano"+strings.Repeat(" ", 10)+"1352000.5" {
+		t.Errorf("unexpected record %q", second)
+	}
+
+	if !strings.HasPrefix(out.prj.String(), `GEOGCS["GCS_WGS_1984"`) {
+		t.Errorf("unexpected prj %q", out.prj.String())
+	}
+}
+
+func TestWriteShapefile_Polygons(t *testing.T) {
+	// RFC 7946 exterior rings are counterclockwise; shapefiles expect them
+	// clockwise.
+	exterior := [][]float64{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}}
+	polygon := geojson.NewMultiPolygon([][][][]float64{{exterior}, {{{10, 10}, {11, 10}, {11, 11}, {10, 10}}}})
+	fc := geojson.NewFeatureCollection(geojson.NewFeature(polygon), geojson.NewFeature(nil))
+
+	out := writeShapefile(t, fc, nil)
+	shp := out.shp.Bytes()
+	if kind := binary.LittleEndian.Uint32(shp[32:]); kind != shapePolygon {
+		t.Fatalf("shape type = %d", kind)
+	}
+
+	record := shp[108:]
+	parts := binary.LittleEndian.Uint32(record[36:])
+	points := binary.LittleEndian.Uint32(record[40:])
+	if parts != 2 || points != 9 {
+		t.Fatalf("parts = %d, points = %d", parts, points)
+	}
+	// Reading the first ring in file order, the second point follows the
+	// clockwise direction from the origin.
+	pointsAt := 44 + 4*int(parts)
+	y := math.Float64frombits(binary.LittleEndian.Uint64(record[pointsAt+16+8:]))
+	if y != 4 {
+		t.Errorf("expected clockwise exterior ring, second point y = %v", y)
+	}
+	if exterior[1][0] != 4 {
+		t.Error("input geometry was modified")
+	}
+
+	if kind := binary.LittleEndian.Uint32(shp[len(shp)-4:]); kind != shapeNull {
+		t.Errorf("expected null shape for feature without geometry, got %d", kind)
+	}
+}
+
+func TestWriteShapefile_Errors(t *testing.T) {
+	mixed := geojson.NewFeatureCollection(
+		geojson.NewFeature(geojson.NewPoint(0, 0)),
+		geojson.NewFeature(geojson.NewLineString([][]float64{{0, 0}, {1, 1}})),
+	)
+	var shp, shx, dbf bytes.Buffer
+	if err := WriteShapefile(ShapefileWriters{SHP: &shp, SHX: &shx, DBF: &dbf}, mixed, nil); err == nil {
+		t.Error("expected error for mixed shape types")
+	}
+	if err := WriteShapefile(ShapefileWriters{SHP: &shp}, mixed, nil); err == nil {
+		t.Error("expected error for missing writers")
+	}
+}
+
+func TestDBFFieldName(t *testing.T) {
+	used := map[string]bool{}
+	got := []string{
+		dbfFieldName("context.region.name", used),
+		dbfFieldName("context.region.code", used),
+		dbfFieldName("Context.Region", used),
+		dbfFieldName("città", used),
+	}
+	want := []string{"context_re", "context__1", "Context__2", "citt_"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dbfFieldName() = %v, want %v", got, want)
+	}
+}
+
+func TestWriteShapefileZip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteShapefileZip(&buf, geocodingResults(t), &Options{Name: "offices"}); err != nil {
+		t.Fatalf("WriteShapefileZip() error = %v", err)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range r.File {
+		names = append(names, f.Name)
+	}
+	want := []string{"offices.shp", "offices.shx", "offices.dbf", "offices.prj", "offices.cpg"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("entries = %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,55 @@
+package geo
+
+import (
+	"math"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// Bearing returns the initial bearing from a to b along the great circle, in
+// degrees clockwise from north within [0, 360).
+func Bearing(a, b geojson.LngLat) float64 {
+	lat1, lat2 := toRadians(a.Latitude), toRadians(b.Latitude)
+	dLon := toRadians(b.Longitude - a.Longitude)
+
+	y := math.Sin(dLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+	return math.Mod(toDegrees(math.Atan2(y, x))+360, 360)
+}
+
+// FinalBearing returns the bearing on arrival at b when travelling from a
+// along the great circle, in degrees clockwise from north within [0, 360).
+func FinalBearing(a, b geojson.LngLat) float64 {
+	return math.Mod(Bearing(b, a)+180, 360)
+}
+
+// Destination returns the point reached by travelling distance meters from
+// origin on the given initial bearing along a great circle.
+func Destination(origin geojson.LngLat, bearing, distance float64) geojson.LngLat {
+	lat1, lon1 := toRadians(origin.Latitude), toRadians(origin.Longitude)
+	theta := toRadians(bearing)
+	delta := distance / EarthRadius
+
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(delta) + math.Cos(lat1)*math.Sin(delta)*math.Cos(theta))
+	lon2 := lon1 + math.Atan2(
+		math.Sin(theta)*math.Sin(delta)*math.Cos(lat1),
+		math.Cos(delta)-math.Sin(lat1)*math.Sin(lat2),
+	)
+
+	return geojson.LngLat{Longitude: normalizeLongitude(toDegrees(lon2)), Latitude: toDegrees(lat2)}
+}
+
+// Midpoint returns the point halfway between a and b along the great circle.
+func Midpoint(a, b geojson.LngLat) geojson.LngLat {
+	lat1, lon1 := toRadians(a.Latitude), toRadians(a.Longitude)
+	lat2 := toRadians(b.Latitude)
+	dLon := toRadians(b.Longitude - a.Longitude)
+
+	bx := math.Cos(lat2) * math.Cos(dLon)
+	by := math.Cos(lat2) * math.Sin(dLon)
+
+	lat := math.Atan2(math.Sin(lat1)+math.Sin(lat2), math.Sqrt(math.Pow(math.Cos(lat1)+bx, 2)+by*by))
+	lon := lon1 + math.Atan2(by, math.Cos(lat1)+bx)
+
+	return geojson.LngLat{Longitude: normalizeLongitude(toDegrees(lon)), Latitude: toDegrees(lat)}
+}
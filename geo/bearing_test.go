@@ -0,0 +1,75 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+func TestBearing(t *testing.T) {
+	origin := geojson.LngLat{}
+
+	tests := []struct {
+		name     string
+		to       geojson.LngLat
+		expected float64
+	}{
+		{name: "north", to: geojson.LngLat{Latitude: 1}, expected: 0},
+		{name: "east", to: geojson.LngLat{Longitude: 1}, expected: 90},
+		{name: "south", to: geojson.LngLat{Latitude: -1}, expected: 180},
+		{name: "west", to: geojson.LngLat{Longitude: -1}, expected: 270},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Bearing(origin, tt.to); !within(got, tt.expected, 1e-9) {
+				t.Errorf("Bearing() = %f, want %f", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFinalBearing(t *testing.T) {
+	// A great circle heading south-east from London arrives in Rome on a
+	// more easterly heading than it set off on.
+	initial := Bearing(london, rome)
+	final := FinalBearing(london, rome)
+
+	if !within(initial, 133.2, 0.1) {
+		t.Errorf("Bearing() = %f, want about 133.2", initial)
+	}
+	if final <= initial {
+		t.Errorf("FinalBearing() = %f, want greater than initial %f", final, initial)
+	}
+
+	// Along the equator the bearing does not change.
+	if got := FinalBearing(geojson.LngLat{}, geojson.LngLat{Longitude: 10}); !within(got, 90, 1e-9) {
+		t.Errorf("FinalBearing() on equator = %f, want 90", got)
+	}
+}
+
+func TestDestination(t *testing.T) {
+	got := Destination(london, Bearing(london, paris), Distance(london, paris))
+	if Distance(got, paris) > 1 {
+		t.Errorf("Destination() = %v, want %v", got, paris)
+	}
+
+	// Crossing the antimeridian wraps the longitude.
+	got = Destination(geojson.LngLat{Longitude: 179.5}, 90, Distance(geojson.LngLat{}, geojson.LngLat{Longitude: 1}))
+	if !within(got.Longitude, -179.5, 1e-6) || !within(got.Latitude, 0, 1e-6) {
+		t.Errorf("Destination() across antimeridian = %v, want -179.5, 0", got)
+	}
+}
+
+func TestMidpoint(t *testing.T) {
+	mid := Midpoint(london, paris)
+
+	if d1, d2 := Distance(london, mid), Distance(mid, paris); !within(d1, d2, 1) {
+		t.Errorf("midpoint distances = %f and %f, want equal", d1, d2)
+	}
+
+	got := Midpoint(geojson.LngLat{Longitude: 170}, geojson.LngLat{Longitude: -170})
+	if !within(got.Longitude, -180, 1e-6) && !within(got.Longitude, 180, 1e-6) {
+		t.Errorf("Midpoint() across antimeridian = %v, want longitude 180", got)
+	}
+}
@@ -0,0 +1,80 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// vincentyIterations bounds the iterations of VincentyDistance before it
+// gives up, which only happens for nearly antipodal points.
+const vincentyIterations = 200
+
+// Distance returns the great-circle (haversine) distance between a and b in
+// meters. It is accurate to about 0.5% and is suitable for most filtering.
+func Distance(a, b geojson.LngLat) float64 {
+	lat1, lat2 := toRadians(a.Latitude), toRadians(b.Latitude)
+	dLat := lat2 - lat1
+	dLon := toRadians(b.Longitude - a.Longitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// VincentyDistance returns the ellipsoidal distance between a and b on the
+// WGS84 ellipsoid in meters, accurate to within a millimeter. It returns an
+// error when the iteration fails to converge, which can happen for nearly
+// antipodal points; fall back to Distance in that case.
+func VincentyDistance(a, b geojson.LngLat) (float64, error) {
+	L := toRadians(b.Longitude - a.Longitude)
+	U1 := math.Atan((1 - wgs84F) * math.Tan(toRadians(a.Latitude)))
+	U2 := math.Atan((1 - wgs84F) * math.Tan(toRadians(b.Latitude)))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	lambda := L
+	for i := 0; i < vincentyIterations; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma := math.Sqrt(math.Pow(cosU2*sinLambda, 2) +
+			math.Pow(cosU1*sinU2-sinU1*cosU2*cosLambda, 2))
+		if sinSigma == 0 {
+			// Coincident points.
+			return 0, nil
+		}
+
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha := 1 - sinAlpha*sinAlpha
+
+		cos2SigmaM := 0.0
+		if cosSqAlpha != 0 {
+			// Both points on the equator otherwise.
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+
+		C := wgs84F / 16 * cosSqAlpha * (4 + wgs84F*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = L + (1-C)*wgs84F*sinAlpha*
+			(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if math.Abs(lambda-prev) < 1e-12 {
+			uSq := cosSqAlpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+			A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+			B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+			deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+				B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+			return wgs84B * A * (sigma - deltaSigma), nil
+		}
+	}
+
+	return 0, fmt.Errorf("vincenty formula failed to converge for %v and %v", a, b)
+}
+
+// WithinRadius reports whether p lies within radius meters of center, using
+// Distance.
+func WithinRadius(center, p geojson.LngLat, radius float64) bool {
+	return Distance(center, p) <= radius
+}
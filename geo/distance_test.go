@@ -0,0 +1,79 @@
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+var (
+	rome   = geojson.LngLat{Longitude: 12.4964, Latitude: 41.9028}
+	milan  = geojson.LngLat{Longitude: 9.1900, Latitude: 45.4642}
+	london = geojson.LngLat{Longitude: -0.1276, Latitude: 51.5072}
+	paris  = geojson.LngLat{Longitude: 2.3522, Latitude: 48.8566}
+)
+
+// within reports whether got is within tolerance of want.
+func within(got, want, tolerance float64) bool {
+	return math.Abs(got-want) <= tolerance
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     geojson.LngLat
+		expected float64
+	}{
+		{name: "same point", a: rome, b: rome, expected: 0},
+		{name: "Rome to Milan", a: rome, b: milan, expected: 477_000},
+		{name: "London to Paris", a: london, b: paris, expected: 343_500},
+		{name: "one degree on the equator", a: geojson.LngLat{}, b: geojson.LngLat{Longitude: 1}, expected: 111_195},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Distance(tt.a, tt.b); !within(got, tt.expected, 1000) {
+				t.Errorf("Distance() = %f, want about %f", got, tt.expected)
+			}
+			if got := Distance(tt.b, tt.a); !within(got, tt.expected, 1000) {
+				t.Errorf("Distance() reversed = %f, want about %f", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestVincentyDistance(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     geojson.LngLat
+		expected float64
+		wantErr  bool
+	}{
+		{name: "same point", a: rome, b: rome, expected: 0},
+		{name: "one degree on the equator", a: geojson.LngLat{}, b: geojson.LngLat{Longitude: 1}, expected: 111_319.491},
+		{name: "pole to pole", a: geojson.LngLat{Latitude: 90}, b: geojson.LngLat{Latitude: -90}, expected: 20_003_931.459},
+		{name: "nearly antipodal", a: geojson.LngLat{}, b: geojson.LngLat{Longitude: 179.7, Latitude: 0.5}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VincentyDistance(tt.a, tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VincentyDistance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !within(got, tt.expected, 0.01) {
+				t.Errorf("VincentyDistance() = %f, want %f", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWithinRadius(t *testing.T) {
+	if !WithinRadius(london, paris, 350_000) {
+		t.Error("expected Paris within 350km of London")
+	}
+	if WithinRadius(london, paris, 300_000) {
+		t.Error("expected Paris outside 300km of London")
+	}
+}
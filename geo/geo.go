@@ -0,0 +1,42 @@
+// Package geo provides geodesic calculations on WGS84 positions: distances,
+// bearings, destination points, bounding boxes, point-in-polygon tests,
+// polygon areas and simple buffers.
+//
+// Functions take geojson.LngLat values. The coordinate types returned by the
+// service packages convert with their LngLat method:
+//
+//	d := geo.Distance(origin, feature.Properties.Coordinates.LngLat())
+//
+// Distances are in meters, bearings in degrees clockwise from north and areas
+// in square meters.
+package geo
+
+import "math"
+
+// EarthRadius is the mean radius of the Earth in meters, used by the
+// spherical calculations.
+const EarthRadius = 6371008.8
+
+// WGS84 ellipsoid parameters, used by VincentyDistance.
+const (
+	wgs84A = 6378137.0
+	wgs84F = 1 / 298.257223563
+	wgs84B = wgs84A * (1 - wgs84F)
+)
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func toDegrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// normalizeLongitude wraps a longitude into [-180, 180).
+func normalizeLongitude(lon float64) float64 {
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	return lon - 180
+}
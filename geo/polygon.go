@@ -0,0 +1,167 @@
+package geo
+
+import (
+	"math"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// defaultBufferSteps is the number of vertices used by Buffer when steps is
+// not positive.
+const defaultBufferSteps = 64
+
+// BoundingBox returns the smallest box containing points, or nil when points
+// is empty. The box does not wrap around the antimeridian.
+//
+// The box of a single point, or of points sharing a longitude or latitude,
+// has no width or no height. BBox.Validate rejects such boxes, so pad them
+// with Expand before using them as a request bbox.
+func BoundingBox(points []geojson.LngLat) *geojson.BBox {
+	if len(points) == 0 {
+		return nil
+	}
+
+	bbox := geojson.NewBBox(points[0].Longitude, points[0].Latitude, points[0].Longitude, points[0].Latitude)
+	for _, p := range points[1:] {
+		bbox.West = math.Min(bbox.West, p.Longitude)
+		bbox.South = math.Min(bbox.South, p.Latitude)
+		bbox.East = math.Max(bbox.East, p.Longitude)
+		bbox.North = math.Max(bbox.North, p.Latitude)
+	}
+	return bbox
+}
+
+// Expand returns bbox grown by meters on every side. Latitudes are clamped to
+// the poles and longitudes wrap around the antimeridian; a box growing past
+// the full width of the world spans all longitudes.
+func Expand(bbox geojson.BBox, meters float64) *geojson.BBox {
+	dLat := toDegrees(meters / EarthRadius)
+	south := math.Max(-90, bbox.South-dLat)
+	north := math.Min(90, bbox.North+dLat)
+
+	width := bbox.East - bbox.West
+	if bbox.CrossesAntimeridian() {
+		width += 360
+	}
+
+	// Widen by the longitude span of meters at the latitude farthest from
+	// the equator, where degrees of longitude are shortest.
+	cos := math.Cos(toRadians(math.Max(math.Abs(south), math.Abs(north))))
+	dLon := 360.0
+	if cos > 0 {
+		dLon = dLat / cos
+	}
+	if width+2*dLon >= 360 {
+		return geojson.NewBBox(-180, south, 180, north)
+	}
+
+	east := normalizeLongitude(bbox.East + dLon)
+	if east == -180 {
+		east = 180
+	}
+	return geojson.NewBBox(normalizeLongitude(bbox.West-dLon), south, east, north)
+}
+
+// Contains reports whether p lies inside the polygon. Points inside a hole
+// are outside the polygon; points exactly on an edge may go either way.
+func Contains(polygon *geojson.Polygon, p geojson.LngLat) bool {
+	if polygon == nil || len(polygon.Coordinates) == 0 {
+		return false
+	}
+
+	if !ringContains(polygon.Coordinates[0], p) {
+		return false
+	}
+
+	for _, hole := range polygon.Coordinates[1:] {
+		if ringContains(hole, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsMulti reports whether p lies inside any polygon of the
+// multipolygon.
+func ContainsMulti(multi *geojson.MultiPolygon, p geojson.LngLat) bool {
+	if multi == nil {
+		return false
+	}
+
+	for _, rings := range multi.Coordinates {
+		if Contains(&geojson.Polygon{Coordinates: rings}, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Area returns the area of the polygon on the sphere in square meters, with
+// holes subtracted.
+func Area(polygon *geojson.Polygon) float64 {
+	if polygon == nil || len(polygon.Coordinates) == 0 {
+		return 0
+	}
+
+	area := math.Abs(ringArea(polygon.Coordinates[0]))
+	for _, hole := range polygon.Coordinates[1:] {
+		area -= math.Abs(ringArea(hole))
+	}
+	return area
+}
+
+// Buffer returns a polygon approximating the circle of radius meters around
+// center, with steps vertices (64 when steps is not positive). The ring is
+// closed and wound counterclockwise.
+func Buffer(center geojson.LngLat, radius float64, steps int) *geojson.Polygon {
+	if steps <= 0 {
+		steps = defaultBufferSteps
+	}
+
+	ring := make([][]float64, 0, steps+1)
+	for i := 0; i < steps; i++ {
+		// Walking bearings counterclockwise gives the RFC 7946 exterior winding.
+		bearing := 360 - float64(i)*360/float64(steps)
+		ring = append(ring, Destination(center, bearing, radius).Position())
+	}
+	ring = append(ring, ring[0])
+
+	return geojson.NewPolygon([][][]float64{ring})
+}
+
+// ringContains reports whether p lies inside the ring using ray casting.
+func ringContains(ring [][]float64, p geojson.LngLat) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		if len(ring[i]) < 2 || len(ring[j]) < 2 {
+			continue
+		}
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > p.Latitude) != (yj > p.Latitude) &&
+			p.Longitude < (xj-xi)*(p.Latitude-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// ringArea returns the signed spherical area of a ring in square meters,
+// following "Some Algorithms for Polygons on a Sphere" (Chamberlain and
+// Duquette, JPL 2007). Counterclockwise rings are positive.
+func ringArea(ring [][]float64) float64 {
+	n := len(ring)
+	if n < 3 {
+		return 0
+	}
+
+	total := 0.0
+	for i := 0; i < n; i++ {
+		lower, middle, upper := ring[i], ring[(i+1)%n], ring[(i+2)%n]
+		if len(lower) < 2 || len(middle) < 2 || len(upper) < 2 {
+			continue
+		}
+		total += (toRadians(upper[0]) - toRadians(lower[0])) * math.Sin(toRadians(middle[1]))
+	}
+	return -total * EarthRadius * EarthRadius / 2
+}
@@ -0,0 +1,163 @@
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// square returns a closed counterclockwise ring from (x0, y0) to (x1, y1).
+func square(x0, y0, x1, y1 float64) [][]float64 {
+	return [][]float64{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}, {x0, y0}}
+}
+
+func TestBoundingBox(t *testing.T) {
+	got := BoundingBox([]geojson.LngLat{rome, milan, london, paris})
+	expected := geojson.BBox{West: london.Longitude, South: rome.Latitude, East: rome.Longitude, North: london.Latitude}
+
+	if got == nil || *got != expected {
+		t.Fatalf("BoundingBox() = %v, want %v", got, expected)
+	}
+
+	if got := BoundingBox(nil); got != nil {
+		t.Errorf("BoundingBox(nil) = %v, want nil", got)
+	}
+
+	for _, points := range [][]geojson.LngLat{{rome}, {rome, {Longitude: rome.Longitude, Latitude: 45}}} {
+		got := BoundingBox(points)
+		if err := got.Validate(); err == nil {
+			t.Errorf("BoundingBox(%v) = %v, expected a degenerate box", points, got)
+		}
+		if err := Expand(*got, 100).Validate(); err != nil {
+			t.Errorf("Expand(%v) is not valid: %v", got, err)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	// One degree of latitude is about 111195 m.
+	const degree = EarthRadius * math.Pi / 180
+
+	tests := []struct {
+		name     string
+		bbox     geojson.BBox
+		meters   float64
+		expected geojson.BBox
+	}{
+		{
+			name:     "point on the equator",
+			bbox:     geojson.BBox{West: 10, South: 0, East: 10, North: 0},
+			meters:   degree,
+			expected: geojson.BBox{West: 9, South: -1, East: 11, North: 1},
+		},
+		{
+			name:     "across the antimeridian",
+			bbox:     geojson.BBox{West: 179.5, South: -1, East: 179.5, North: 1},
+			meters:   degree,
+			expected: geojson.BBox{West: 178.5, South: -2, East: -179.5, North: 2},
+		},
+		{
+			name:     "clamped to the pole",
+			bbox:     geojson.BBox{West: 0, South: 89.5, East: 10, North: 89.5},
+			meters:   degree,
+			expected: geojson.BBox{West: -180, South: 88.5, East: 180, North: 90},
+		},
+		{
+			name:     "wider than the world",
+			bbox:     geojson.BBox{West: -170, South: -10, East: 170, North: 10},
+			meters:   20 * degree,
+			expected: geojson.BBox{West: -180, South: -30, East: 180, North: 30},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Expand(tt.bbox, tt.meters)
+			for i, v := range got.Slice() {
+				if !within(v, tt.expected.Slice()[i], 1e-3) {
+					t.Fatalf("Expand() = %v, want %v", got, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	polygon := geojson.NewPolygon([][][]float64{square(0, 0, 10, 10), square(4, 4, 6, 6)})
+
+	tests := []struct {
+		name     string
+		point    geojson.LngLat
+		expected bool
+	}{
+		{name: "inside", point: geojson.LngLat{Longitude: 2, Latitude: 2}, expected: true},
+		{name: "outside", point: geojson.LngLat{Longitude: 12, Latitude: 2}, expected: false},
+		{name: "inside hole", point: geojson.LngLat{Longitude: 5, Latitude: 5}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Contains(polygon, tt.point); got != tt.expected {
+				t.Errorf("Contains() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	multi := geojson.NewMultiPolygon([][][][]float64{{square(0, 0, 1, 1)}, {square(5, 5, 6, 6)}})
+	if !ContainsMulti(multi, geojson.LngLat{Longitude: 5.5, Latitude: 5.5}) {
+		t.Error("expected ContainsMulti() to match the second polygon")
+	}
+	if ContainsMulti(multi, geojson.LngLat{Longitude: 3, Latitude: 3}) {
+		t.Error("expected ContainsMulti() to reject a point between polygons")
+	}
+}
+
+func TestArea(t *testing.T) {
+	// A one degree square on the equator is about 12,364 km².
+	oneDegree := geojson.NewPolygon([][][]float64{square(0, 0, 1, 1)})
+	if got := Area(oneDegree); !within(got, 12_364e6, 10e6) {
+		t.Errorf("Area() = %f, want about 12364 km²", got)
+	}
+
+	// Winding order does not change the result.
+	clockwise := geojson.NewPolygon([][][]float64{{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}})
+	if got, want := Area(clockwise), Area(oneDegree); !within(got, want, 1e-3) {
+		t.Errorf("Area() clockwise = %f, want %f", got, want)
+	}
+
+	withHole := geojson.NewPolygon([][][]float64{square(0, 0, 1, 1), square(0.25, 0.25, 0.75, 0.75)})
+	if got, want := Area(withHole), Area(oneDegree)*0.75; !within(got, want, want*0.01) {
+		t.Errorf("Area() with hole = %f, want about %f", got, want)
+	}
+}
+
+func TestBuffer(t *testing.T) {
+	buffer := Buffer(rome, 1000, 32)
+
+	ring := buffer.Coordinates[0]
+	if len(ring) != 33 {
+		t.Fatalf("expected 33 positions, got %d", len(ring))
+	}
+	if ring[0][0] != ring[32][0] || ring[0][1] != ring[32][1] {
+		t.Error("expected a closed ring")
+	}
+
+	for _, pos := range ring {
+		if d := Distance(rome, geojson.LngLat{Longitude: pos[0], Latitude: pos[1]}); !within(d, 1000, 1e-6) {
+			t.Errorf("vertex %v is %f m from the center, want 1000", pos, d)
+		}
+	}
+
+	if !Contains(buffer, rome) {
+		t.Error("expected buffer to contain its center")
+	}
+
+	if got, want := Area(buffer), math.Pi*1000*1000; !within(got, want, want*0.01) {
+		t.Errorf("Area() = %f, want about %f", got, want)
+	}
+
+	if got := len(Buffer(rome, 1000, 0).Coordinates[0]); got != defaultBufferSteps+1 {
+		t.Errorf("expected default steps, got %d positions", got)
+	}
+}
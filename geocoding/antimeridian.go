@@ -0,0 +1,26 @@
+package geocoding
+
+import "github.com/pettinz/mapbox-go-sdk/internal/antimeridian"
+
+// defaultForwardLimit is the number of results forward requests return when
+// no limit is set.
+const defaultForwardLimit = 5
+
+// mergeResponses merges the responses of split requests. Features are
+// interleaved so each half contributes its best matches first, duplicates are
+// dropped by mapbox_id, and the result is truncated to limit, or to the API
+// default when limit is nil.
+func mergeResponses(responses []*Response, limit *int) *Response {
+	if len(responses) == 1 {
+		return responses[0]
+	}
+
+	lists := make([][]Feature, len(responses))
+	for i, resp := range responses {
+		lists[i] = resp.Features
+	}
+
+	merged := *responses[0]
+	merged.Features = antimeridian.Interleave(lists, func(f Feature) string { return f.Properties.MapboxID }, limit, defaultForwardLimit)
+	return &merged
+}
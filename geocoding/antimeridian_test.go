@@ -0,0 +1,139 @@
+package geocoding
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
+)
+
+// featuresResponse returns a response body with one feature per mapbox_id.
+func featuresResponse(ids ...string) string {
+	features := ""
+	for i, id := range ids {
+		if i > 0 {
+			features += ","
+		}
+		features += fmt.Sprintf(`{"type":"Feature","id":%q,"geometry":{"type":"Point","coordinates":[179,-17]},"properties":{"mapbox_id":%q,"name":%q}}`, id, id, id)
+	}
+	return fmt.Sprintf(`{"type":"FeatureCollection","features":[%s],"attribution":"test"}`, features)
+}
+
+func TestService_Forward_Antimeridian(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bboxes []string
+	)
+
+	server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		bbox := r.URL.Query().Get("bbox")
+		mu.Lock()
+		bboxes = append(bboxes, bbox)
+		mu.Unlock()
+
+		switch bbox {
+		case "170,-20,180,-10":
+			testutil.MockResponse(http.StatusOK, featuresResponse("east-1", "shared", "east-2"))(w, r)
+		case "-180,-20,-170,-10":
+			testutil.MockResponse(http.StatusOK, featuresResponse("west-1", "shared"))(w, r)
+		default:
+			t.Errorf("unexpected bbox %q", bbox)
+			testutil.MockResponse(http.StatusBadRequest, testutil.ValidationErrorResponse)(w, r)
+		}
+	})
+	defer server.Close()
+
+	service := New("test-token", internalhttp.New(server.URL, nil))
+
+	resp, err := service.Forward(context.Background(), &ForwardRequest{
+		Query: "Suva",
+		BBox:  geojson.NewBBox(170, -20, -170, -10),
+	})
+	if err != nil {
+		t.Fatalf("Forward() error = %v", err)
+	}
+
+	if len(bboxes) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(bboxes))
+	}
+
+	var ids []string
+	for _, f := range resp.Features {
+		ids = append(ids, f.Properties.MapboxID)
+	}
+	expected := []string{"east-1", "west-1", "shared", "east-2"}
+	if fmt.Sprint(ids) != fmt.Sprint(expected) {
+		t.Errorf("merged features = %v, want %v", ids, expected)
+	}
+
+	if resp.Attribution != "test" {
+		t.Errorf("expected attribution to be kept, got %q", resp.Attribution)
+	}
+
+	resp, err = service.ForwardStructured(context.Background(), &StructuredForwardRequest{
+		Place: "Suva",
+		BBox:  geojson.NewBBox(170, -20, -170, -10),
+		Limit: intPtr(2),
+	})
+	if err != nil {
+		t.Fatalf("ForwardStructured() error = %v", err)
+	}
+	if len(resp.Features) != 2 {
+		t.Errorf("expected limit to truncate merged features to 2, got %d", len(resp.Features))
+	}
+}
+
+func TestService_Forward_AntimeridianDefaultLimit(t *testing.T) {
+	server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		prefix := "east"
+		if r.URL.Query().Get("bbox") == "-180,-20,-170,-10" {
+			prefix = "west"
+		}
+		var ids []string
+		for i := range 5 {
+			ids = append(ids, fmt.Sprintf("%s-%d", prefix, i+1))
+		}
+		testutil.MockResponse(http.StatusOK, featuresResponse(ids...))(w, r)
+	})
+	defer server.Close()
+
+	service := New("test-token", internalhttp.New(server.URL, nil))
+
+	// Without a limit each half returns the API default of 5 features.
+	resp, err := service.Forward(context.Background(), &ForwardRequest{
+		Query: "Suva",
+		BBox:  geojson.NewBBox(170, -20, -170, -10),
+	})
+	if err != nil {
+		t.Fatalf("Forward() error = %v", err)
+	}
+	if len(resp.Features) != 5 {
+		t.Errorf("expected the merged features capped at 5, got %d", len(resp.Features))
+	}
+}
+
+func TestService_Forward_AntimeridianError(t *testing.T) {
+	server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("bbox") == "-180,-20,-170,-10" {
+			testutil.MockResponse(http.StatusUnauthorized, testutil.ErrorResponse)(w, r)
+			return
+		}
+		testutil.MockResponse(http.StatusOK, featuresResponse("east-1"))(w, r)
+	})
+	defer server.Close()
+
+	service := New("test-token", internalhttp.New(server.URL, nil))
+
+	_, err := service.Forward(context.Background(), &ForwardRequest{
+		Query: "Suva",
+		BBox:  geojson.NewBBox(170, -20, -170, -10),
+	})
+	if err == nil {
+		t.Fatal("expected error when one half fails")
+	}
+}
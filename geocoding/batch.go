@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
 )
 
 const (
@@ -13,6 +16,8 @@ const (
 // Batch performs batch geocoding for multiple queries in a single request.
 // It supports up to 1000 queries per batch and can include both forward and reverse geocoding queries.
 func (s *Service) Batch(ctx context.Context, req *BatchRequest) (*BatchResponse, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.GeocodingBatch, req)
+
 	// Validate batch size
 	if len(req.Queries) == 0 {
 		return nil, fmt.Errorf("at least one query is required")
@@ -31,6 +36,9 @@ func (s *Service) Batch(ctx context.Context, req *BatchRequest) (*BatchResponse,
 	// Add access token to query
 	query := url.Values{}
 	query.Set("access_token", s.token)
+	if req.Permanent {
+		query.Set("permanent", "true")
+	}
 
 	// Build request body
 	body := map[string]any{
@@ -38,7 +46,7 @@ func (s *Service) Batch(ctx context.Context, req *BatchRequest) (*BatchResponse,
 	}
 
 	var result BatchResponse
-	if err := s.httpClient.Post(ctx, batchPath, body, &result); err != nil {
+	if err := s.httpClient.Post(ctx, batchPath, query, body, &result); err != nil {
 		return nil, fmt.Errorf("batch geocoding failed: %w", err)
 	}
 
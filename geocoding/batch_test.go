@@ -160,6 +160,24 @@ func TestService_Batch(t *testing.T) {
 	}
 }
 
+func TestService_BatchPermanent(t *testing.T) {
+	var permanent string
+	server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		permanent = r.URL.Query().Get("permanent")
+		w.Write([]byte(testutil.BatchGeocodingResponse))
+	})
+	defer server.Close()
+
+	service := New("test-token", internalhttp.New(server.URL, nil))
+	req := &BatchRequest{Queries: []BatchQuery{{Query: "New York"}}, Permanent: true}
+	if _, err := service.Batch(context.Background(), req); err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+	if permanent != "true" {
+		t.Errorf("expected permanent=true, got %q", permanent)
+	}
+}
+
 func TestValidateBatchQuery(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,123 @@
+package geocoding
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// contextFields are the context types decoded into Context fields.
+var contextFields = map[string]func(*Context) **ContextElement{
+	"country":      func(c *Context) **ContextElement { return &c.Country },
+	"region":       func(c *Context) **ContextElement { return &c.Region },
+	"postcode":     func(c *Context) **ContextElement { return &c.Postcode },
+	"district":     func(c *Context) **ContextElement { return &c.District },
+	"place":        func(c *Context) **ContextElement { return &c.Place },
+	"locality":     func(c *Context) **ContextElement { return &c.Locality },
+	"neighborhood": func(c *Context) **ContextElement { return &c.Neighborhood },
+	"street":       func(c *Context) **ContextElement { return &c.Street },
+	"address":      func(c *Context) **ContextElement { return &c.Address },
+}
+
+// UnmarshalJSON decodes the context, keeping entries of unknown types in
+// Extra. Country and region codes are filled in from short_code when the
+// response only carries the legacy field.
+func (c *Context) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*c = Context{}
+	for key, value := range raw {
+		field, ok := contextFields[key]
+		if !ok {
+			if c.Extra == nil {
+				c.Extra = make(map[string]json.RawMessage)
+			}
+			c.Extra[key] = value
+			continue
+		}
+
+		element, err := decodeElement(value)
+		if err != nil {
+			return err
+		}
+		*field(c) = element
+	}
+
+	if c.Country != nil && c.Country.CountryCode == "" && c.Country.ShortCode != "" {
+		c.Country.CountryCode = strings.ToUpper(c.Country.ShortCode)
+	}
+	if r := c.Region; r != nil && r.RegionCodeFull == "" && strings.Contains(r.ShortCode, "-") {
+		r.RegionCodeFull = strings.ToUpper(r.ShortCode)
+		if r.RegionCode == "" {
+			r.RegionCode = r.RegionCodeFull[strings.Index(r.RegionCodeFull, "-")+1:]
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the context, including the entries in Extra.
+func (c Context) MarshalJSON() ([]byte, error) {
+	out := make(map[string]any, len(contextFields)+len(c.Extra))
+	for key, value := range c.Extra {
+		out[key] = value
+	}
+	for key, field := range contextFields {
+		if element := *field(&c); element != nil {
+			out[key] = element
+		}
+	}
+	return json.Marshal(out)
+}
+
+// Get returns the context element of the given type (e.g. "region"), or nil
+// if the context has none. Types without a field of their own are decoded
+// from Extra.
+func (c *Context) Get(key string) *ContextElement {
+	if c == nil {
+		return nil
+	}
+	if field, ok := contextFields[key]; ok {
+		return *field(c)
+	}
+
+	value, ok := c.Extra[key]
+	if !ok {
+		return nil
+	}
+	element, err := decodeElement(value)
+	if err != nil {
+		return nil
+	}
+	return element
+}
+
+// decodeElement decodes a context element, filling in whichever of the
+// current and deprecated Wikidata fields the response lacks.
+func decodeElement(data []byte) (*ContextElement, error) {
+	var element ContextElement
+	if err := json.Unmarshal(data, &element); err != nil {
+		return nil, err
+	}
+	if element.WikidataID == "" {
+		element.WikidataID = element.Wikidata
+	}
+	if element.Wikidata == "" {
+		element.Wikidata = element.WikidataID
+	}
+	return &element, nil
+}
+
+// NameIn returns the name of the element in the given language, falling back
+// to Name when no translation is available. It is safe to call on nil.
+func (e *ContextElement) NameIn(language string) string {
+	if e == nil {
+		return ""
+	}
+	if t, ok := e.Translations[language]; ok && t.Name != "" {
+		return t.Name
+	}
+	return e.Name
+}
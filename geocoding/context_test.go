@@ -0,0 +1,187 @@
+package geocoding
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
+)
+
+const contextV6 = `{
+  "address": {
+    "mapbox_id": "address.1",
+    "address_number": "1600",
+    "street_name": "Pennsylvania Avenue Northwest",
+    "name": "1600 Pennsylvania Avenue Northwest"
+  },
+  "street": {"mapbox_id": "street.1", "name": "Pennsylvania Avenue Northwest"},
+  "postcode": {"mapbox_id": "postcode.1", "name": "20500"},
+  "place": {
+    "mapbox_id": "place.1",
+    "name": "Washington",
+    "wikidata_id": "Q61",
+    "translations": {
+      "de": {"language": "de", "name": "Washington, D.C."}
+    }
+  },
+  "region": {
+    "mapbox_id": "region.1",
+    "name": "District of Columbia",
+    "region_code": "DC",
+    "region_code_full": "US-DC"
+  },
+  "country": {
+    "mapbox_id": "country.1",
+    "name": "United States",
+    "country_code": "US",
+    "country_code_alpha_3": "USA",
+    "translations": {
+      "de": {"language": "de", "name": "Vereinigte Staaten"}
+    }
+  },
+  "secondary_address": {"mapbox_id": "secondary.1", "name": "Suite 200"}
+}`
+
+func TestContext_UnmarshalJSON(t *testing.T) {
+	var ctx Context
+	if err := json.Unmarshal([]byte(contextV6), &ctx); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if ctx.Address == nil || ctx.Address.AddressNumber != "1600" || ctx.Address.StreetName != "Pennsylvania Avenue Northwest" {
+		t.Errorf("unexpected address %+v", ctx.Address)
+	}
+	if ctx.Region == nil || ctx.Region.RegionCode != "DC" || ctx.Region.RegionCodeFull != "US-DC" {
+		t.Errorf("unexpected region %+v", ctx.Region)
+	}
+	if ctx.Country == nil || ctx.Country.CountryCode != "US" || ctx.Country.CountryCodeAlpha3 != "USA" {
+		t.Errorf("unexpected country %+v", ctx.Country)
+	}
+	if ctx.Place == nil || ctx.Place.WikidataID != "Q61" {
+		t.Errorf("unexpected place %+v", ctx.Place)
+	}
+	if ctx.District != nil || ctx.Locality != nil || ctx.Neighborhood != nil {
+		t.Error("expected missing elements to be nil")
+	}
+	if _, ok := ctx.Extra["secondary_address"]; !ok || len(ctx.Extra) != 1 {
+		t.Errorf("expected secondary_address in Extra, got %v", ctx.Extra)
+	}
+}
+
+func TestContext_UnmarshalJSON_ShortCode(t *testing.T) {
+	var resp Response
+	if err := json.Unmarshal([]byte(testutil.ForwardGeocodingResponse), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	ctx := resp.Features[0].Properties.Context
+	if ctx.Region.RegionCode != "DC" || ctx.Region.RegionCodeFull != "US-DC" {
+		t.Errorf("expected region codes from short_code, got %+v", ctx.Region)
+	}
+	if ctx.Country.CountryCode != "US" {
+		t.Errorf("expected country code from short_code, got %+v", ctx.Country)
+	}
+}
+
+func TestContext_UnmarshalJSON_Wikidata(t *testing.T) {
+	var ctx Context
+	data := `{"place": {"name": "Washington", "wikidata": "Q61"}, "region": {"name": "District of Columbia", "wikidata_id": "Q3551781"}}`
+	if err := json.Unmarshal([]byte(data), &ctx); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if ctx.Place.WikidataID != "Q61" || ctx.Place.Wikidata != "Q61" {
+		t.Errorf("expected WikidataID from the deprecated field, got %+v", ctx.Place)
+	}
+	if ctx.Region.WikidataID != "Q3551781" || ctx.Region.Wikidata != "Q3551781" {
+		t.Errorf("expected deprecated Wikidata from WikidataID, got %+v", ctx.Region)
+	}
+}
+
+func TestContext_UnmarshalJSON_Invalid(t *testing.T) {
+	var ctx Context
+	if err := json.Unmarshal([]byte(`{"region": "DC"}`), &ctx); err == nil {
+		t.Error("expected error for malformed element")
+	}
+	if err := json.Unmarshal([]byte(`[]`), &ctx); err == nil {
+		t.Error("expected error for non-object context")
+	}
+}
+
+func TestContext_MarshalJSON(t *testing.T) {
+	var ctx Context
+	if err := json.Unmarshal([]byte(contextV6), &ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(ctx)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var roundTrip Context
+	if err := json.Unmarshal(data, &roundTrip); err != nil {
+		t.Fatal(err)
+	}
+	if roundTrip.Region.RegionCodeFull != "US-DC" || roundTrip.Address.AddressNumber != "1600" {
+		t.Errorf("round trip lost fields: %s", data)
+	}
+	if secondary := roundTrip.Get("secondary_address"); secondary == nil || secondary.Name != "Suite 200" {
+		t.Errorf("round trip lost extra entries: %s", data)
+	}
+}
+
+func TestContext_Get(t *testing.T) {
+	var ctx Context
+	if err := json.Unmarshal([]byte(contextV6), &ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"region", "District of Columbia"},
+		{"postcode", "20500"},
+		{"secondary_address", "Suite 200"},
+		{"district", ""},
+		{"block", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got := ctx.Get(tt.key)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("Get(%q) = %+v, want nil", tt.key, got)
+				}
+				return
+			}
+			if got == nil || got.Name != tt.want {
+				t.Errorf("Get(%q) = %+v, want name %q", tt.key, got, tt.want)
+			}
+		})
+	}
+
+	var nilCtx *Context
+	if nilCtx.Get("region") != nil {
+		t.Error("expected nil from nil context")
+	}
+}
+
+func TestContextElement_NameIn(t *testing.T) {
+	var ctx Context
+	if err := json.Unmarshal([]byte(contextV6), &ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ctx.Country.NameIn("de"); got != "Vereinigte Staaten" {
+		t.Errorf("NameIn(de) = %q", got)
+	}
+	if got := ctx.Country.NameIn("fr"); got != "United States" {
+		t.Errorf("NameIn(fr) = %q, want fallback to Name", got)
+	}
+	if got := ctx.District.NameIn("de"); got != "" {
+		t.Errorf("NameIn on nil element = %q", got)
+	}
+}
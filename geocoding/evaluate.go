@@ -0,0 +1,341 @@
+package geocoding
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pettinz/mapbox-go-sdk/geo"
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// Decision is the outcome of evaluating a feature against a Policy.
+type Decision string
+
+// Decisions returned by Evaluate.
+const (
+	DecisionAccept Decision = "accept"
+	DecisionReview Decision = "review"
+	DecisionReject Decision = "reject"
+)
+
+// Weights sets the relative importance of each signal in the score. Signals
+// that do not apply to a feature (e.g. location when the policy sets no
+// expected area) are left out and the remaining weights rescaled.
+type Weights struct {
+	Confidence  float64
+	Components  float64
+	Accuracy    float64
+	FeatureType float64
+	Location    float64
+}
+
+// DefaultWeights returns the weights used by DefaultPolicy.
+func DefaultWeights() Weights {
+	return Weights{
+		Confidence:  0.35,
+		Components:  0.25,
+		Accuracy:    0.2,
+		FeatureType: 0.1,
+		Location:    0.1,
+	}
+}
+
+// Policy configures how features are scored and which decision a score maps
+// to.
+type Policy struct {
+	// AcceptScore is the minimum score, between 0 and 1, to accept a feature.
+	// When both AcceptScore and ReviewScore are zero, the thresholds of
+	// DefaultPolicy are used.
+	AcceptScore float64
+
+	// ReviewScore is the minimum score to send a feature to review; lower
+	// scores are rejected. It must not exceed AcceptScore.
+	ReviewScore float64
+
+	// Weights sets the importance of each signal. The zero value uses
+	// DefaultWeights.
+	Weights Weights
+
+	// MinConfidence rejects features whose match confidence is below this
+	// level, one of the Confidence* constants in any case. Features without
+	// a known confidence are sent to review at best. Empty disables the
+	// check.
+	MinConfidence string
+
+	// FeatureTypes rejects features of any other type. Empty allows all types.
+	FeatureTypes []string
+
+	// RequireMatched sends features to review at best when any of these
+	// components (e.g. "street", "postcode") is present but unmatched.
+	RequireMatched []string
+
+	// BBox is the area the result is expected in. Features outside it score
+	// zero for location.
+	BBox *geojson.BBox
+
+	// Proximity is the location the result is expected near. The location
+	// score falls linearly to zero at MaxDistance meters.
+	Proximity *geojson.LngLat
+
+	// MaxDistance is the distance from Proximity, in meters, at which the
+	// location score reaches zero. Zero disables the distance check.
+	MaxDistance float64
+}
+
+// Default policy thresholds.
+const (
+	defaultAcceptScore = 0.8
+	defaultReviewScore = 0.5
+)
+
+// DefaultPolicy returns a policy accepting scores of 0.8 and above and
+// reviewing scores of 0.5 and above.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		AcceptScore: defaultAcceptScore,
+		ReviewScore: defaultReviewScore,
+		Weights:     DefaultWeights(),
+	}
+}
+
+// Validate reports whether the policy is well formed.
+func (p *Policy) Validate() error {
+	if p.ReviewScore < 0 || p.ReviewScore > p.AcceptScore || p.AcceptScore > 1 {
+		return fmt.Errorf("scores must satisfy 0 <= review <= accept <= 1, got review %v and accept %v", p.ReviewScore, p.AcceptScore)
+	}
+	if p.MinConfidence != "" {
+		if _, ok := confidenceScores[strings.ToLower(p.MinConfidence)]; !ok {
+			return fmt.Errorf("invalid minimum confidence %q", p.MinConfidence)
+		}
+	}
+	return nil
+}
+
+// Evaluation is the result of evaluating a feature against a Policy.
+type Evaluation struct {
+	// Feature is the evaluated feature.
+	Feature Feature
+
+	// Score is the weighted quality score between 0 and 1.
+	Score float64
+
+	// Decision is the outcome of applying the policy.
+	Decision Decision
+
+	// Distance is the distance in meters from Policy.Proximity, if set.
+	Distance *float64
+
+	// Reasons explains what lowered the score or forced the decision.
+	Reasons []string
+}
+
+// Evaluate scores a feature and applies the policy. A nil policy uses
+// DefaultPolicy. It returns an error if the policy is not valid.
+func Evaluate(feature Feature, policy *Policy) (Evaluation, error) {
+	if policy == nil {
+		policy = DefaultPolicy()
+	}
+	if err := policy.Validate(); err != nil {
+		return Evaluation{}, fmt.Errorf("invalid policy: %w", err)
+	}
+
+	w := policy.Weights
+	if w == (Weights{}) {
+		w = DefaultWeights()
+	}
+
+	e := Evaluation{Feature: feature}
+	props := feature.Properties
+
+	var total, weights float64
+	add := func(weight, score float64) {
+		if weight > 0 {
+			total += weight * score
+			weights += weight
+		}
+	}
+
+	// Confidence
+	confidence := ""
+	if props.MatchCode != nil {
+		confidence = strings.ToLower(props.MatchCode.Confidence)
+	}
+	confidenceScore, known := confidenceScores[confidence]
+	switch {
+	case !known:
+		confidenceScore = 0.5
+		e.Reasons = append(e.Reasons, "no match confidence")
+	case confidenceScore < 1:
+		e.Reasons = append(e.Reasons, fmt.Sprintf("confidence is %q", confidence))
+	}
+	add(w.Confidence, confidenceScore)
+
+	// Per-component match codes
+	if props.MatchCode != nil {
+		components := props.MatchCode.Components()
+		var sum float64
+		var n int
+		for _, name := range componentOrder {
+			status, ok := components[name]
+			if !ok || status == MatchNotApplicable {
+				continue
+			}
+			sum += statusScores[status]
+			n++
+			if status != MatchMatched {
+				e.Reasons = append(e.Reasons, fmt.Sprintf("%s is %s", name, status))
+			}
+		}
+		if n > 0 {
+			add(w.Components, sum/float64(n))
+		}
+	}
+
+	// Accuracy only applies to address features.
+	if props.Accuracy != "" {
+		score, ok := accuracyScores[props.Accuracy]
+		if !ok {
+			score = 0.5
+		}
+		if score < 1 {
+			e.Reasons = append(e.Reasons, fmt.Sprintf("accuracy is %q", props.Accuracy))
+		}
+		add(w.Accuracy, score)
+	}
+
+	// Feature type
+	typeScore, ok := featureTypeScores[props.FeatureType]
+	if !ok {
+		typeScore = 0.5
+	}
+	add(w.FeatureType, typeScore)
+
+	// Location
+	point := feature.Geometry.Coordinates
+	if point == (geojson.LngLat{}) {
+		point = props.Coordinates.LngLat()
+	}
+	if policy.BBox != nil || (policy.Proximity != nil && policy.MaxDistance > 0) {
+		score := 1.0
+		if policy.BBox != nil && !policy.BBox.Contains(point) {
+			score = 0
+			e.Reasons = append(e.Reasons, "outside the expected bbox")
+		}
+		if policy.Proximity != nil && policy.MaxDistance > 0 {
+			d := geo.Distance(*policy.Proximity, point)
+			e.Distance = &d
+			score = min(score, max(0, 1-d/policy.MaxDistance))
+			if d > policy.MaxDistance {
+				e.Reasons = append(e.Reasons, fmt.Sprintf("%.0f m from the expected location", d))
+			}
+		}
+		add(w.Location, score)
+	}
+
+	if weights > 0 {
+		e.Score = total / weights
+	}
+
+	e.Decision = policy.decide(e.Score)
+
+	// Hard rules override the score.
+	if policy.MinConfidence != "" {
+		switch {
+		case !known:
+			if e.Decision == DecisionAccept {
+				e.Decision = DecisionReview
+			}
+		case confidenceScore < confidenceScores[strings.ToLower(policy.MinConfidence)]:
+			e.Decision = DecisionReject
+			e.Reasons = append(e.Reasons, fmt.Sprintf("confidence below %q", policy.MinConfidence))
+		}
+	}
+	if len(policy.FeatureTypes) > 0 && !slices.Contains(policy.FeatureTypes, props.FeatureType) {
+		e.Decision = DecisionReject
+		e.Reasons = append(e.Reasons, fmt.Sprintf("feature type %q not allowed", props.FeatureType))
+	}
+	if e.Decision == DecisionAccept && props.MatchCode != nil {
+		components := props.MatchCode.Components()
+		for _, name := range policy.RequireMatched {
+			if components[name] == MatchUnmatched {
+				e.Decision = DecisionReview
+				e.Reasons = append(e.Reasons, fmt.Sprintf("required component %s is unmatched", name))
+			}
+		}
+	}
+
+	return e, nil
+}
+
+// EvaluateResponse evaluates every feature of a response, in order.
+func EvaluateResponse(resp *Response, policy *Policy) ([]Evaluation, error) {
+	evaluations := make([]Evaluation, len(resp.Features))
+	for i, feature := range resp.Features {
+		e, err := Evaluate(feature, policy)
+		if err != nil {
+			return nil, err
+		}
+		evaluations[i] = e
+	}
+	return evaluations, nil
+}
+
+// decide maps a score to a decision using the policy thresholds.
+func (p *Policy) decide(score float64) Decision {
+	accept, review := p.AcceptScore, p.ReviewScore
+	if accept == 0 && review == 0 {
+		accept, review = defaultAcceptScore, defaultReviewScore
+	}
+
+	switch {
+	case score >= accept:
+		return DecisionAccept
+	case score >= review:
+		return DecisionReview
+	default:
+		return DecisionReject
+	}
+}
+
+// componentOrder lists match code components from most to least specific,
+// which keeps Reasons in a stable order.
+var componentOrder = []string{"address_number", "street", "postcode", "locality", "place", "region", "country"}
+
+var confidenceScores = map[string]float64{
+	ConfidenceExact:  1,
+	ConfidenceHigh:   0.85,
+	ConfidenceMedium: 0.6,
+	ConfidenceLow:    0.3,
+}
+
+var statusScores = map[MatchStatus]float64{
+	MatchMatched:   1,
+	MatchInferred:  0.8,
+	MatchPlausible: 0.6,
+	MatchUnmatched: 0,
+}
+
+var accuracyScores = map[string]float64{
+	AccuracyRooftop:      1,
+	AccuracyParcel:       0.9,
+	AccuracyPoint:        0.85,
+	AccuracyInterpolated: 0.7,
+	AccuracyIntersection: 0.7,
+	AccuracyStreet:       0.5,
+	AccuracyApproximate:  0.3,
+}
+
+var featureTypeScores = map[string]float64{
+	"address":           1,
+	"secondary_address": 1,
+	"street":            0.7,
+	"block":             0.7,
+	"neighborhood":      0.6,
+	"postcode":          0.6,
+	"locality":          0.5,
+	"place":             0.5,
+	"district":          0.4,
+	"region":            0.3,
+	"country":           0.2,
+}
@@ -0,0 +1,288 @@
+package geocoding
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// addressFeature returns an address feature in Washington, DC with the given
+// match code and accuracy.
+func addressFeature(matchCode *MatchCode, accuracy string) Feature {
+	return Feature{
+		Type:     "Feature",
+		Geometry: Geometry{Type: "Point", Coordinates: geojson.LngLat{Longitude: -77.0365, Latitude: 38.8977}},
+		Properties: Properties{
+			MapboxID:    "dXJuOm1ieGFkcjo",
+			FeatureType: "address",
+			MatchCode:   matchCode,
+			Accuracy:    accuracy,
+		},
+	}
+}
+
+func allMatched() *MatchCode {
+	return &MatchCode{
+		Confidence:    ConfidenceExact,
+		AddressNumber: MatchMatched,
+		Street:        MatchMatched,
+		Postcode:      MatchMatched,
+		Place:         MatchMatched,
+		Region:        MatchMatched,
+		Locality:      MatchNotApplicable,
+		Country:       MatchInferred,
+	}
+}
+
+func TestMatchCode_UnmarshalJSON(t *testing.T) {
+	data := `{"address_number":"matched","street":"unmatched","postcode":"plausible","place":"matched","region":"matched","locality":"not_applicable","country":"inferred","confidence":"medium"}`
+
+	var m MatchCode
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if m.Confidence != ConfidenceMedium {
+		t.Errorf("expected confidence medium, got %q", m.Confidence)
+	}
+	if m.Street != MatchUnmatched || m.Postcode != MatchPlausible || m.Country != MatchInferred {
+		t.Errorf("unexpected component statuses %+v", m)
+	}
+
+	if got := len(m.Components()); got != 7 {
+		t.Errorf("expected 7 components, got %d", got)
+	}
+	if got := len((MatchCode{Street: MatchMatched}).Components()); got != 1 {
+		t.Errorf("expected absent components to be omitted, got %d", got)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	whiteHouse := geojson.LngLat{Longitude: -77.0365, Latitude: 38.8977}
+
+	tests := []struct {
+		name     string
+		feature  Feature
+		policy   *Policy
+		expected Decision
+		reason   string
+	}{
+		{
+			name:     "exact rooftop match",
+			feature:  addressFeature(allMatched(), AccuracyRooftop),
+			expected: DecisionAccept,
+		},
+		{
+			name: "medium confidence interpolated",
+			feature: addressFeature(&MatchCode{
+				Confidence:    ConfidenceMedium,
+				AddressNumber: MatchPlausible,
+				Street:        MatchMatched,
+				Postcode:      MatchUnmatched,
+			}, AccuracyInterpolated),
+			expected: DecisionReview,
+			reason:   "postcode is unmatched",
+		},
+		{
+			name: "low confidence approximate",
+			feature: addressFeature(&MatchCode{
+				Confidence:    ConfidenceLow,
+				AddressNumber: MatchUnmatched,
+				Street:        MatchUnmatched,
+			}, AccuracyApproximate),
+			expected: DecisionReject,
+		},
+		{
+			name:     "outside the expected bbox",
+			feature:  addressFeature(allMatched(), AccuracyRooftop),
+			policy:   withPolicy(func(p *Policy) { p.BBox = geojson.NewBBox(-122.5, 37.7, -122.3, 37.8); p.Weights.Location = 0.5 }),
+			expected: DecisionReview,
+			reason:   "outside the expected bbox",
+		},
+		{
+			name:     "near the expected location",
+			feature:  addressFeature(allMatched(), AccuracyRooftop),
+			policy:   withPolicy(func(p *Policy) { p.Proximity = &whiteHouse; p.MaxDistance = 1000 }),
+			expected: DecisionAccept,
+		},
+		{
+			name:     "feature type not allowed",
+			feature:  addressFeature(allMatched(), AccuracyRooftop),
+			policy:   withPolicy(func(p *Policy) { p.FeatureTypes = []string{"poi"} }),
+			expected: DecisionReject,
+			reason:   `feature type "address" not allowed`,
+		},
+		{
+			name:     "below minimum confidence",
+			feature:  addressFeature(&MatchCode{Confidence: ConfidenceHigh}, AccuracyRooftop),
+			policy:   withPolicy(func(p *Policy) { p.MinConfidence = ConfidenceExact }),
+			expected: DecisionReject,
+			reason:   `confidence below "exact"`,
+		},
+		{
+			name:     "minimum confidence in any case",
+			feature:  addressFeature(&MatchCode{Confidence: ConfidenceHigh}, AccuracyRooftop),
+			policy:   withPolicy(func(p *Policy) { p.MinConfidence = "Exact" }),
+			expected: DecisionReject,
+			reason:   `confidence below "Exact"`,
+		},
+		{
+			name:     "zero weights use the defaults",
+			feature:  addressFeature(allMatched(), AccuracyRooftop),
+			policy:   withPolicy(func(p *Policy) { p.Weights = Weights{} }),
+			expected: DecisionAccept,
+		},
+		{
+			name:     "only a filter set",
+			feature:  addressFeature(allMatched(), AccuracyRooftop),
+			policy:   &Policy{MinConfidence: ConfidenceHigh},
+			expected: DecisionAccept,
+		},
+		{
+			name: "only a filter set uses the default thresholds",
+			feature: addressFeature(&MatchCode{
+				Confidence:    ConfidenceHigh,
+				AddressNumber: MatchPlausible,
+				Street:        MatchMatched,
+				Postcode:      MatchUnmatched,
+			}, AccuracyInterpolated),
+			policy:   &Policy{MinConfidence: ConfidenceHigh},
+			expected: DecisionReview,
+			reason:   "postcode is unmatched",
+		},
+		{
+			name:     "unknown confidence without minimum",
+			feature:  addressFeature(nil, AccuracyRooftop),
+			policy:   withPolicy(func(p *Policy) { p.AcceptScore = 0.7 }),
+			expected: DecisionAccept,
+			reason:   "no match confidence",
+		},
+		{
+			name:     "unknown confidence below any minimum",
+			feature:  addressFeature(nil, AccuracyRooftop),
+			policy:   withPolicy(func(p *Policy) { p.AcceptScore = 0.7; p.MinConfidence = ConfidenceLow }),
+			expected: DecisionReview,
+			reason:   "no match confidence",
+		},
+		{
+			name:     "confidence in any case",
+			feature:  addressFeature(&MatchCode{Confidence: "High"}, AccuracyRooftop),
+			policy:   withPolicy(func(p *Policy) { p.MinConfidence = ConfidenceHigh }),
+			expected: DecisionAccept,
+			reason:   `confidence is "high"`,
+		},
+		{
+			name: "required component unmatched",
+			feature: addressFeature(&MatchCode{
+				Confidence:    ConfidenceExact,
+				AddressNumber: MatchMatched,
+				Street:        MatchMatched,
+				Place:         MatchMatched,
+				Region:        MatchMatched,
+				Postcode:      MatchUnmatched,
+			}, AccuracyRooftop),
+			policy:   withPolicy(func(p *Policy) { p.RequireMatched = []string{"postcode"} }),
+			expected: DecisionReview,
+			reason:   "required component postcode is unmatched",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := Evaluate(tt.feature, tt.policy)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+
+			if e.Decision != tt.expected {
+				t.Errorf("Decision = %s (score %.2f, reasons %v), want %s", e.Decision, e.Score, e.Reasons, tt.expected)
+			}
+			if e.Score < 0 || e.Score > 1 {
+				t.Errorf("Score = %f, want between 0 and 1", e.Score)
+			}
+			if tt.reason != "" && !containsString(e.Reasons, tt.reason) {
+				t.Errorf("Reasons = %v, want %q", e.Reasons, tt.reason)
+			}
+		})
+	}
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	for _, level := range []string{"", ConfidenceExact, ConfidenceHigh, "Medium", "LOW"} {
+		if err := (&Policy{MinConfidence: level}).Validate(); err != nil {
+			t.Errorf("Validate() with MinConfidence %q: %v", level, err)
+		}
+	}
+
+	for _, scores := range [][2]float64{{-0.1, 0.5}, {0.9, 0.8}, {0.5, 1.1}} {
+		p := withPolicy(func(p *Policy) { p.ReviewScore, p.AcceptScore = scores[0], scores[1] })
+		if err := p.Validate(); err == nil {
+			t.Errorf("Validate() with review %v and accept %v: expected error", scores[0], scores[1])
+		}
+	}
+
+	for _, level := range []string{"hi", "none", " high"} {
+		p := withPolicy(func(p *Policy) { p.MinConfidence = level })
+		if err := p.Validate(); err == nil {
+			t.Errorf("Validate() with MinConfidence %q: expected error", level)
+		}
+		if _, err := Evaluate(addressFeature(allMatched(), AccuracyRooftop), p); err == nil {
+			t.Errorf("Evaluate() with MinConfidence %q: expected error", level)
+		}
+		if _, err := EvaluateResponse(&Response{Features: []Feature{addressFeature(allMatched(), AccuracyRooftop)}}, p); err == nil {
+			t.Errorf("EvaluateResponse() with MinConfidence %q: expected error", level)
+		}
+	}
+}
+
+func TestEvaluate_Distance(t *testing.T) {
+	proximity := geojson.LngLat{Longitude: -77.0, Latitude: 38.8977}
+	policy := withPolicy(func(p *Policy) { p.Proximity = &proximity; p.MaxDistance = 1000 })
+
+	e, err := Evaluate(addressFeature(allMatched(), AccuracyRooftop), policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Distance == nil || *e.Distance < 3000 {
+		t.Fatalf("Distance = %v, want over 3 km", e.Distance)
+	}
+	if !containsString(e.Reasons, fmt.Sprintf("%.0f m from the expected location", *e.Distance)) {
+		t.Errorf("Reasons = %v, want distance reason", e.Reasons)
+	}
+}
+
+func TestEvaluateResponse(t *testing.T) {
+	resp := &Response{Features: []Feature{
+		addressFeature(allMatched(), AccuracyRooftop),
+		addressFeature(&MatchCode{Confidence: ConfidenceLow}, AccuracyApproximate),
+	}}
+
+	evaluations, err := EvaluateResponse(resp, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evaluations) != 2 {
+		t.Fatalf("expected 2 evaluations, got %d", len(evaluations))
+	}
+	if evaluations[0].Score <= evaluations[1].Score {
+		t.Errorf("expected first feature to score higher: %f <= %f", evaluations[0].Score, evaluations[1].Score)
+	}
+}
+
+// withPolicy returns DefaultPolicy modified by fn.
+func withPolicy(fn func(*Policy)) *Policy {
+	p := DefaultPolicy()
+	fn(p)
+	return p
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
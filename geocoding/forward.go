@@ -6,42 +6,70 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/internal/antimeridian"
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
 )
 
 // Forward performs forward geocoding using text-based search.
 // It converts a search query (like "1600 Pennsylvania Avenue NW") into geographic coordinates.
 func (s *Service) Forward(ctx context.Context, req *ForwardRequest) (*Response, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.GeocodingForward, req)
+
 	if req.Query == "" {
 		return nil, fmt.Errorf("query is required")
 	}
 
-	query := s.buildForwardQuery(req)
+	if err := validateBias(req.BBox, req.Proximity); err != nil {
+		return nil, err
+	}
+
+	// A bbox crossing the antimeridian is searched as two requests.
+	var responses []*Response
+	for _, bbox := range antimeridian.Split(req.BBox) {
+		split := *req
+		split.BBox = bbox
 
-	var result Response
-	if err := s.httpClient.Get(ctx, forwardPath, query, &result); err != nil {
-		return nil, fmt.Errorf("forward geocoding failed: %w", err)
+		var result Response
+		if err := s.httpClient.Get(ctx, forwardPath, s.buildForwardQuery(&split), &result); err != nil {
+			return nil, fmt.Errorf("forward geocoding failed: %w", err)
+		}
+		responses = append(responses, &result)
 	}
 
-	return &result, nil
+	return mergeResponses(responses, req.Limit), nil
 }
 
 // ForwardStructured performs forward geocoding using structured address components.
 // It converts address components (street, city, etc.) into geographic coordinates.
 func (s *Service) ForwardStructured(ctx context.Context, req *StructuredForwardRequest) (*Response, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.GeocodingForwardStructured, req)
+
 	// At least one address component is required
 	if req.AddressNumber == "" && req.Street == "" && req.Block == "" &&
 	   req.Place == "" && req.Region == "" && req.Postcode == "" && req.Country == "" {
 		return nil, fmt.Errorf("at least one address component is required")
 	}
 
-	query := s.buildStructuredForwardQuery(req)
+	if err := validateBias(req.BBox, req.Proximity); err != nil {
+		return nil, err
+	}
+
+	var responses []*Response
+	for _, bbox := range antimeridian.Split(req.BBox) {
+		split := *req
+		split.BBox = bbox
 
-	var result Response
-	if err := s.httpClient.Get(ctx, forwardPath, query, &result); err != nil {
-		return nil, fmt.Errorf("structured forward geocoding failed: %w", err)
+		var result Response
+		if err := s.httpClient.Get(ctx, forwardPath, s.buildStructuredForwardQuery(&split), &result); err != nil {
+			return nil, fmt.Errorf("structured forward geocoding failed: %w", err)
+		}
+		responses = append(responses, &result)
 	}
 
-	return &result, nil
+	return mergeResponses(responses, req.Limit), nil
 }
 
 // buildForwardQuery builds query parameters for forward geocoding.
@@ -54,8 +82,8 @@ func (s *Service) buildForwardQuery(req *ForwardRequest) url.Values {
 		q.Set("autocomplete", strconv.FormatBool(*req.Autocomplete))
 	}
 
-	if len(req.BBox) == 4 {
-		q.Set("bbox", formatFloatArray(req.BBox))
+	if req.BBox != nil {
+		q.Set("bbox", req.BBox.String())
 	}
 
 	if len(req.Country) > 0 {
@@ -70,8 +98,8 @@ func (s *Service) buildForwardQuery(req *ForwardRequest) url.Values {
 		q.Set("limit", strconv.Itoa(*req.Limit))
 	}
 
-	if len(req.Proximity) == 2 {
-		q.Set("proximity", formatFloatArray(req.Proximity))
+	if req.Proximity != nil {
+		q.Set("proximity", req.Proximity.String())
 	}
 
 	if len(req.Types) > 0 {
@@ -82,6 +110,10 @@ func (s *Service) buildForwardQuery(req *ForwardRequest) url.Values {
 		q.Set("worldview", req.Worldview)
 	}
 
+	if req.Permanent {
+		q.Set("permanent", "true")
+	}
+
 	return q
 }
 
@@ -122,8 +154,8 @@ func (s *Service) buildStructuredForwardQuery(req *StructuredForwardRequest) url
 		q.Set("autocomplete", strconv.FormatBool(*req.Autocomplete))
 	}
 
-	if len(req.BBox) == 4 {
-		q.Set("bbox", formatFloatArray(req.BBox))
+	if req.BBox != nil {
+		q.Set("bbox", req.BBox.String())
 	}
 
 	if req.Language != "" {
@@ -134,22 +166,34 @@ func (s *Service) buildStructuredForwardQuery(req *StructuredForwardRequest) url
 		q.Set("limit", strconv.Itoa(*req.Limit))
 	}
 
-	if len(req.Proximity) == 2 {
-		q.Set("proximity", formatFloatArray(req.Proximity))
+	if req.Proximity != nil {
+		q.Set("proximity", req.Proximity.String())
 	}
 
 	if req.Worldview != "" {
 		q.Set("worldview", req.Worldview)
 	}
 
+	if req.Permanent {
+		q.Set("permanent", "true")
+	}
+
 	return q
 }
 
-// formatFloatArray formats a float array as a comma-separated string.
-func formatFloatArray(arr []float64) string {
-	parts := make([]string, len(arr))
-	for i, v := range arr {
-		parts[i] = strconv.FormatFloat(v, 'f', -1, 64)
+// validateBias validates the optional bbox and proximity of a forward request.
+func validateBias(bbox *geojson.BBox, proximity *geojson.LngLat) error {
+	if bbox != nil {
+		if err := bbox.Validate(); err != nil {
+			return fmt.Errorf("invalid bbox: %w", err)
+		}
 	}
-	return strings.Join(parts, ",")
+
+	if proximity != nil {
+		if err := proximity.Validate(); err != nil {
+			return fmt.Errorf("invalid proximity: %w", err)
+		}
+	}
+
+	return nil
 }
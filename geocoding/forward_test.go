@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/pettinz/mapbox-go-sdk/geojson"
 	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
 	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
 )
@@ -40,7 +41,7 @@ func TestService_Forward(t *testing.T) {
 					if feature.Properties.PlaceName == "" {
 						t.Error("expected non-empty place name")
 					}
-					if feature.Geometry.Coordinates[0] == 0 && feature.Geometry.Coordinates[1] == 0 {
+					if feature.Geometry.Coordinates == (geojson.LngLat{}) {
 						t.Error("expected non-zero coordinates")
 					}
 				}
@@ -53,6 +54,20 @@ func TestService_Forward(t *testing.T) {
 			mockResponse: "{}",
 			wantErr:      true,
 		},
+		{
+			name:         "invalid bbox",
+			request:      &ForwardRequest{Query: "test", BBox: geojson.NewBBox(-122.3, 37.8, -122.5, 37.7)},
+			mockStatus:   http.StatusOK,
+			mockResponse: "{}",
+			wantErr:      true,
+		},
+		{
+			name:         "invalid proximity",
+			request:      &ForwardRequest{Query: "test", Proximity: &geojson.LngLat{Longitude: 37.7749, Latitude: -122.4194}},
+			mockStatus:   http.StatusOK,
+			mockResponse: "{}",
+			wantErr:      true,
+		},
 		{
 			name: "API error",
 			request: &ForwardRequest{
@@ -173,13 +188,14 @@ func TestBuildForwardQuery(t *testing.T) {
 	req := &ForwardRequest{
 		Query:        "San Francisco",
 		Autocomplete: boolPtr(true),
-		BBox:         []float64{-122.5, 37.7, -122.3, 37.8},
+		BBox:         geojson.NewBBox(-122.5, 37.7, -122.3, 37.8),
 		Country:      []string{"US", "CA"},
 		Language:     "en",
 		Limit:        intPtr(10),
-		Proximity:    []float64{-122.4194, 37.7749},
+		Proximity:    &geojson.LngLat{Longitude: -122.4194, Latitude: 37.7749},
 		Types:        []string{"place", "region"},
 		Worldview:    "US",
+		Permanent:    true,
 	}
 
 	query := service.buildForwardQuery(req)
@@ -198,6 +214,7 @@ func TestBuildForwardQuery(t *testing.T) {
 		{"proximity", "-122.4194,37.7749"},
 		{"types", "place,region"},
 		{"worldview", "US"},
+		{"permanent", "true"},
 	}
 
 	for _, tt := range tests {
@@ -222,11 +239,12 @@ func TestBuildStructuredForwardQuery(t *testing.T) {
 		Postcode:      "20500",
 		Country:       "US",
 		Autocomplete:  boolPtr(false),
-		BBox:          []float64{-77.1, 38.8, -77.0, 38.9},
+		BBox:          geojson.NewBBox(-77.1, 38.8, -77.0, 38.9),
 		Language:      "en",
 		Limit:         intPtr(5),
-		Proximity:     []float64{-77.0365, 38.8977},
+		Proximity:     &geojson.LngLat{Longitude: -77.0365, Latitude: 38.8977},
 		Worldview:     "US",
+		Permanent:     true,
 	}
 
 	query := service.buildStructuredForwardQuery(req)
@@ -249,6 +267,7 @@ func TestBuildStructuredForwardQuery(t *testing.T) {
 		{"limit", "5"},
 		{"proximity", "-77.0365,38.8977"},
 		{"worldview", "US"},
+		{"permanent", "true"},
 	}
 
 	for _, tt := range tests {
@@ -1,6 +1,8 @@
 package geocoding
 
 import (
+	"context"
+
 	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
 )
 
@@ -11,6 +13,18 @@ const (
 	batchPath   = "/search/geocode/v6/batch"
 )
 
+// API is the set of Geocoding API calls. *Service implements it; accept an
+// API in your own code to substitute a fake, such as the one in the
+// geocodingfakes package, in unit tests.
+type API interface {
+	Forward(ctx context.Context, req *ForwardRequest) (*Response, error)
+	ForwardStructured(ctx context.Context, req *StructuredForwardRequest) (*Response, error)
+	Reverse(ctx context.Context, req *ReverseRequest) (*Response, error)
+	Batch(ctx context.Context, req *BatchRequest) (*BatchResponse, error)
+}
+
+var _ API = (*Service)(nil)
+
 // Service provides access to the Mapbox Geocoding API.
 type Service struct {
 	token      string
@@ -1,9 +1,12 @@
 package geocoding
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
 )
 
 func TestNew(t *testing.T) {
@@ -25,6 +28,68 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestService_MiddlewareEndpoint(t *testing.T) {
+	errStop := errors.New("stop")
+	var endpoint string
+	var params any
+	httpClient := internalhttp.New("https://api.mapbox.com", nil, func(next middleware.RoundTrip) middleware.RoundTrip {
+		return func(ctx context.Context, req *middleware.Request) (*middleware.Response, error) {
+			endpoint, params = req.Endpoint, req.Params
+			return nil, errStop
+		}
+	})
+	service := New("test-token", httpClient)
+
+	tests := []struct {
+		name   string
+		want   string
+		params any
+	}{
+		{
+			name:   "forward",
+			want:   middleware.GeocodingForward,
+			params: &ForwardRequest{Query: "Rome"},
+		},
+		{
+			name:   "structured",
+			want:   middleware.GeocodingForwardStructured,
+			params: &StructuredForwardRequest{Place: "Rome"},
+		},
+		{
+			name:   "reverse",
+			want:   middleware.GeocodingReverse,
+			params: &ReverseRequest{Longitude: 12.5, Latitude: 41.9},
+		},
+		{
+			name:   "batch",
+			want:   middleware.GeocodingBatch,
+			params: &BatchRequest{Queries: []BatchQuery{{Query: "Rome"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			switch req := tt.params.(type) {
+			case *ForwardRequest:
+				_, err = service.Forward(context.Background(), req)
+			case *StructuredForwardRequest:
+				_, err = service.ForwardStructured(context.Background(), req)
+			case *ReverseRequest:
+				_, err = service.Reverse(context.Background(), req)
+			case *BatchRequest:
+				_, err = service.Batch(context.Background(), req)
+			}
+			if !errors.Is(err, errStop) {
+				t.Fatalf("expected the middleware error, got %v", err)
+			}
+			if endpoint != tt.want || params != tt.params {
+				t.Errorf("middleware saw %q with %v, want %q with %v", endpoint, params, tt.want, tt.params)
+			}
+		})
+	}
+}
+
 func TestValidateCoordinates(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -86,44 +151,6 @@ func TestValidateCoordinates(t *testing.T) {
 	}
 }
 
-func TestFormatFloatArray(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    []float64
-		expected string
-	}{
-		{
-			name:     "two floats",
-			input:    []float64{-122.4194, 37.7749},
-			expected: "-122.4194,37.7749",
-		},
-		{
-			name:     "four floats (bbox)",
-			input:    []float64{-122.5, 37.7, -122.3, 37.8},
-			expected: "-122.5,37.7,-122.3,37.8",
-		},
-		{
-			name:     "integers",
-			input:    []float64{1, 2, 3},
-			expected: "1,2,3",
-		},
-		{
-			name:     "empty array",
-			input:    []float64{},
-			expected: "",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := formatFloatArray(tt.input)
-			if result != tt.expected {
-				t.Errorf("formatFloatArray() = %q, want %q", result, tt.expected)
-			}
-		})
-	}
-}
-
 func boolPtr(b bool) *bool {
 	return &b
 }
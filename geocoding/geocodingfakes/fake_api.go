@@ -0,0 +1,403 @@
+// Package geocodingfakes provides a fake geocoding.API for unit tests. FakeAPI
+// records every call and returns canned responses, so code that accepts a
+// geocoding.API can be tested without a server:
+//
+//	fake := new(geocodingfakes.FakeAPI)
+//	fake.ForwardReturns(&geocoding.Response{}, nil)
+//	...
+//	if fake.ForwardCallCount() != 1 {
+//		t.Error("expected one call")
+//	}
+package geocodingfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+)
+
+// FakeAPI is a geocoding.API that records calls and returns canned
+// responses. For each method M:
+//
+//   - MStub, set directly or with MCalls, is called and its results are
+//     returned.
+//   - MReturnsOnCall and MReturns set the results of a given call or of
+//     every call. Without either, M returns zero values.
+//   - MCallCount and MArgsForCall report the calls received.
+//
+// A FakeAPI is safe for concurrent use; the zero value is ready to use.
+type FakeAPI struct {
+	ForwardStub        func(context.Context, *geocoding.ForwardRequest) (*geocoding.Response, error)
+	forwardMutex       sync.RWMutex
+	forwardArgsForCall []struct {
+		ctx context.Context
+		req *geocoding.ForwardRequest
+	}
+	forwardReturns struct {
+		result *geocoding.Response
+		err    error
+	}
+	forwardReturnsOnCall map[int]struct {
+		result *geocoding.Response
+		err    error
+	}
+
+	ForwardStructuredStub        func(context.Context, *geocoding.StructuredForwardRequest) (*geocoding.Response, error)
+	forwardStructuredMutex       sync.RWMutex
+	forwardStructuredArgsForCall []struct {
+		ctx context.Context
+		req *geocoding.StructuredForwardRequest
+	}
+	forwardStructuredReturns struct {
+		result *geocoding.Response
+		err    error
+	}
+	forwardStructuredReturnsOnCall map[int]struct {
+		result *geocoding.Response
+		err    error
+	}
+
+	ReverseStub        func(context.Context, *geocoding.ReverseRequest) (*geocoding.Response, error)
+	reverseMutex       sync.RWMutex
+	reverseArgsForCall []struct {
+		ctx context.Context
+		req *geocoding.ReverseRequest
+	}
+	reverseReturns struct {
+		result *geocoding.Response
+		err    error
+	}
+	reverseReturnsOnCall map[int]struct {
+		result *geocoding.Response
+		err    error
+	}
+
+	BatchStub        func(context.Context, *geocoding.BatchRequest) (*geocoding.BatchResponse, error)
+	batchMutex       sync.RWMutex
+	batchArgsForCall []struct {
+		ctx context.Context
+		req *geocoding.BatchRequest
+	}
+	batchReturns struct {
+		result *geocoding.BatchResponse
+		err    error
+	}
+	batchReturnsOnCall map[int]struct {
+		result *geocoding.BatchResponse
+		err    error
+	}
+
+	invocationsMutex sync.RWMutex
+	invocations      map[string][][]any
+}
+
+// Forward implements geocoding.API.
+func (fake *FakeAPI) Forward(ctx context.Context, req *geocoding.ForwardRequest) (*geocoding.Response, error) {
+	fake.forwardMutex.Lock()
+	ret, specificReturn := fake.forwardReturnsOnCall[len(fake.forwardArgsForCall)]
+	fake.forwardArgsForCall = append(fake.forwardArgsForCall, struct {
+		ctx context.Context
+		req *geocoding.ForwardRequest
+	}{ctx, req})
+	stub := fake.ForwardStub
+	fakeReturns := fake.forwardReturns
+	fake.recordInvocation("Forward", []any{ctx, req})
+	fake.forwardMutex.Unlock()
+	if stub != nil {
+		return stub(ctx, req)
+	}
+	if specificReturn {
+		return ret.result, ret.err
+	}
+	return fakeReturns.result, fakeReturns.err
+}
+
+// ForwardCallCount returns the number of calls to Forward.
+func (fake *FakeAPI) ForwardCallCount() int {
+	fake.forwardMutex.RLock()
+	defer fake.forwardMutex.RUnlock()
+	return len(fake.forwardArgsForCall)
+}
+
+// ForwardCalls sets the stub called by Forward.
+func (fake *FakeAPI) ForwardCalls(stub func(context.Context, *geocoding.ForwardRequest) (*geocoding.Response, error)) {
+	fake.forwardMutex.Lock()
+	defer fake.forwardMutex.Unlock()
+	fake.ForwardStub = stub
+}
+
+// ForwardArgsForCall returns the arguments of the i-th call to Forward.
+func (fake *FakeAPI) ForwardArgsForCall(i int) (context.Context, *geocoding.ForwardRequest) {
+	fake.forwardMutex.RLock()
+	defer fake.forwardMutex.RUnlock()
+	args := fake.forwardArgsForCall[i]
+	return args.ctx, args.req
+}
+
+// ForwardReturns sets the results of every call to Forward.
+func (fake *FakeAPI) ForwardReturns(result *geocoding.Response, err error) {
+	fake.forwardMutex.Lock()
+	defer fake.forwardMutex.Unlock()
+	fake.ForwardStub = nil
+	fake.forwardReturns = struct {
+		result *geocoding.Response
+		err    error
+	}{result, err}
+}
+
+// ForwardReturnsOnCall sets the results of the i-th call to Forward, counting
+// from zero.
+func (fake *FakeAPI) ForwardReturnsOnCall(i int, result *geocoding.Response, err error) {
+	fake.forwardMutex.Lock()
+	defer fake.forwardMutex.Unlock()
+	fake.ForwardStub = nil
+	if fake.forwardReturnsOnCall == nil {
+		fake.forwardReturnsOnCall = make(map[int]struct {
+			result *geocoding.Response
+			err    error
+		})
+	}
+	fake.forwardReturnsOnCall[i] = struct {
+		result *geocoding.Response
+		err    error
+	}{result, err}
+}
+
+// ForwardStructured implements geocoding.API.
+func (fake *FakeAPI) ForwardStructured(ctx context.Context, req *geocoding.StructuredForwardRequest) (*geocoding.Response, error) {
+	fake.forwardStructuredMutex.Lock()
+	ret, specificReturn := fake.forwardStructuredReturnsOnCall[len(fake.forwardStructuredArgsForCall)]
+	fake.forwardStructuredArgsForCall = append(fake.forwardStructuredArgsForCall, struct {
+		ctx context.Context
+		req *geocoding.StructuredForwardRequest
+	}{ctx, req})
+	stub := fake.ForwardStructuredStub
+	fakeReturns := fake.forwardStructuredReturns
+	fake.recordInvocation("ForwardStructured", []any{ctx, req})
+	fake.forwardStructuredMutex.Unlock()
+	if stub != nil {
+		return stub(ctx, req)
+	}
+	if specificReturn {
+		return ret.result, ret.err
+	}
+	return fakeReturns.result, fakeReturns.err
+}
+
+// ForwardStructuredCallCount returns the number of calls to ForwardStructured.
+func (fake *FakeAPI) ForwardStructuredCallCount() int {
+	fake.forwardStructuredMutex.RLock()
+	defer fake.forwardStructuredMutex.RUnlock()
+	return len(fake.forwardStructuredArgsForCall)
+}
+
+// ForwardStructuredCalls sets the stub called by ForwardStructured.
+func (fake *FakeAPI) ForwardStructuredCalls(stub func(context.Context, *geocoding.StructuredForwardRequest) (*geocoding.Response, error)) {
+	fake.forwardStructuredMutex.Lock()
+	defer fake.forwardStructuredMutex.Unlock()
+	fake.ForwardStructuredStub = stub
+}
+
+// ForwardStructuredArgsForCall returns the arguments of the i-th call to ForwardStructured.
+func (fake *FakeAPI) ForwardStructuredArgsForCall(i int) (context.Context, *geocoding.StructuredForwardRequest) {
+	fake.forwardStructuredMutex.RLock()
+	defer fake.forwardStructuredMutex.RUnlock()
+	args := fake.forwardStructuredArgsForCall[i]
+	return args.ctx, args.req
+}
+
+// ForwardStructuredReturns sets the results of every call to ForwardStructured.
+func (fake *FakeAPI) ForwardStructuredReturns(result *geocoding.Response, err error) {
+	fake.forwardStructuredMutex.Lock()
+	defer fake.forwardStructuredMutex.Unlock()
+	fake.ForwardStructuredStub = nil
+	fake.forwardStructuredReturns = struct {
+		result *geocoding.Response
+		err    error
+	}{result, err}
+}
+
+// ForwardStructuredReturnsOnCall sets the results of the i-th call to ForwardStructured, counting
+// from zero.
+func (fake *FakeAPI) ForwardStructuredReturnsOnCall(i int, result *geocoding.Response, err error) {
+	fake.forwardStructuredMutex.Lock()
+	defer fake.forwardStructuredMutex.Unlock()
+	fake.ForwardStructuredStub = nil
+	if fake.forwardStructuredReturnsOnCall == nil {
+		fake.forwardStructuredReturnsOnCall = make(map[int]struct {
+			result *geocoding.Response
+			err    error
+		})
+	}
+	fake.forwardStructuredReturnsOnCall[i] = struct {
+		result *geocoding.Response
+		err    error
+	}{result, err}
+}
+
+// Reverse implements geocoding.API.
+func (fake *FakeAPI) Reverse(ctx context.Context, req *geocoding.ReverseRequest) (*geocoding.Response, error) {
+	fake.reverseMutex.Lock()
+	ret, specificReturn := fake.reverseReturnsOnCall[len(fake.reverseArgsForCall)]
+	fake.reverseArgsForCall = append(fake.reverseArgsForCall, struct {
+		ctx context.Context
+		req *geocoding.ReverseRequest
+	}{ctx, req})
+	stub := fake.ReverseStub
+	fakeReturns := fake.reverseReturns
+	fake.recordInvocation("Reverse", []any{ctx, req})
+	fake.reverseMutex.Unlock()
+	if stub != nil {
+		return stub(ctx, req)
+	}
+	if specificReturn {
+		return ret.result, ret.err
+	}
+	return fakeReturns.result, fakeReturns.err
+}
+
+// ReverseCallCount returns the number of calls to Reverse.
+func (fake *FakeAPI) ReverseCallCount() int {
+	fake.reverseMutex.RLock()
+	defer fake.reverseMutex.RUnlock()
+	return len(fake.reverseArgsForCall)
+}
+
+// ReverseCalls sets the stub called by Reverse.
+func (fake *FakeAPI) ReverseCalls(stub func(context.Context, *geocoding.ReverseRequest) (*geocoding.Response, error)) {
+	fake.reverseMutex.Lock()
+	defer fake.reverseMutex.Unlock()
+	fake.ReverseStub = stub
+}
+
+// ReverseArgsForCall returns the arguments of the i-th call to Reverse.
+func (fake *FakeAPI) ReverseArgsForCall(i int) (context.Context, *geocoding.ReverseRequest) {
+	fake.reverseMutex.RLock()
+	defer fake.reverseMutex.RUnlock()
+	args := fake.reverseArgsForCall[i]
+	return args.ctx, args.req
+}
+
+// ReverseReturns sets the results of every call to Reverse.
+func (fake *FakeAPI) ReverseReturns(result *geocoding.Response, err error) {
+	fake.reverseMutex.Lock()
+	defer fake.reverseMutex.Unlock()
+	fake.ReverseStub = nil
+	fake.reverseReturns = struct {
+		result *geocoding.Response
+		err    error
+	}{result, err}
+}
+
+// ReverseReturnsOnCall sets the results of the i-th call to Reverse, counting
+// from zero.
+func (fake *FakeAPI) ReverseReturnsOnCall(i int, result *geocoding.Response, err error) {
+	fake.reverseMutex.Lock()
+	defer fake.reverseMutex.Unlock()
+	fake.ReverseStub = nil
+	if fake.reverseReturnsOnCall == nil {
+		fake.reverseReturnsOnCall = make(map[int]struct {
+			result *geocoding.Response
+			err    error
+		})
+	}
+	fake.reverseReturnsOnCall[i] = struct {
+		result *geocoding.Response
+		err    error
+	}{result, err}
+}
+
+// Batch implements geocoding.API.
+func (fake *FakeAPI) Batch(ctx context.Context, req *geocoding.BatchRequest) (*geocoding.BatchResponse, error) {
+	fake.batchMutex.Lock()
+	ret, specificReturn := fake.batchReturnsOnCall[len(fake.batchArgsForCall)]
+	fake.batchArgsForCall = append(fake.batchArgsForCall, struct {
+		ctx context.Context
+		req *geocoding.BatchRequest
+	}{ctx, req})
+	stub := fake.BatchStub
+	fakeReturns := fake.batchReturns
+	fake.recordInvocation("Batch", []any{ctx, req})
+	fake.batchMutex.Unlock()
+	if stub != nil {
+		return stub(ctx, req)
+	}
+	if specificReturn {
+		return ret.result, ret.err
+	}
+	return fakeReturns.result, fakeReturns.err
+}
+
+// BatchCallCount returns the number of calls to Batch.
+func (fake *FakeAPI) BatchCallCount() int {
+	fake.batchMutex.RLock()
+	defer fake.batchMutex.RUnlock()
+	return len(fake.batchArgsForCall)
+}
+
+// BatchCalls sets the stub called by Batch.
+func (fake *FakeAPI) BatchCalls(stub func(context.Context, *geocoding.BatchRequest) (*geocoding.BatchResponse, error)) {
+	fake.batchMutex.Lock()
+	defer fake.batchMutex.Unlock()
+	fake.BatchStub = stub
+}
+
+// BatchArgsForCall returns the arguments of the i-th call to Batch.
+func (fake *FakeAPI) BatchArgsForCall(i int) (context.Context, *geocoding.BatchRequest) {
+	fake.batchMutex.RLock()
+	defer fake.batchMutex.RUnlock()
+	args := fake.batchArgsForCall[i]
+	return args.ctx, args.req
+}
+
+// BatchReturns sets the results of every call to Batch.
+func (fake *FakeAPI) BatchReturns(result *geocoding.BatchResponse, err error) {
+	fake.batchMutex.Lock()
+	defer fake.batchMutex.Unlock()
+	fake.BatchStub = nil
+	fake.batchReturns = struct {
+		result *geocoding.BatchResponse
+		err    error
+	}{result, err}
+}
+
+// BatchReturnsOnCall sets the results of the i-th call to Batch, counting
+// from zero.
+func (fake *FakeAPI) BatchReturnsOnCall(i int, result *geocoding.BatchResponse, err error) {
+	fake.batchMutex.Lock()
+	defer fake.batchMutex.Unlock()
+	fake.BatchStub = nil
+	if fake.batchReturnsOnCall == nil {
+		fake.batchReturnsOnCall = make(map[int]struct {
+			result *geocoding.BatchResponse
+			err    error
+		})
+	}
+	fake.batchReturnsOnCall[i] = struct {
+		result *geocoding.BatchResponse
+		err    error
+	}{result, err}
+}
+
+// Invocations returns the arguments of every call, keyed by method name.
+func (fake *FakeAPI) Invocations() map[string][][]any {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copied := make(map[string][][]any, len(fake.invocations))
+	for key, value := range fake.invocations {
+		copied[key] = append([][]any(nil), value...)
+	}
+	return copied
+}
+
+func (fake *FakeAPI) recordInvocation(key string, args []any) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]any{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ geocoding.API = new(FakeAPI)
@@ -0,0 +1,91 @@
+package geocodingfakes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+)
+
+func TestFakeAPI_Returns(t *testing.T) {
+	fake := new(FakeAPI)
+	resp := &geocoding.Response{Type: "FeatureCollection"}
+	errFailed := errors.New("failed")
+
+	fake.ForwardReturns(resp, nil)
+	fake.ForwardReturnsOnCall(1, nil, errFailed)
+
+	var api geocoding.API = fake
+	req := &geocoding.ForwardRequest{Query: "Rome"}
+	tests := []struct {
+		want    *geocoding.Response
+		wantErr error
+	}{
+		{want: resp},
+		{wantErr: errFailed},
+		{want: resp},
+	}
+	for i, tt := range tests {
+		got, err := api.Forward(context.Background(), req)
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("call %d = %v, %v; want %v, %v", i, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	if n := fake.ForwardCallCount(); n != 3 {
+		t.Errorf("ForwardCallCount() = %d, want 3", n)
+	}
+	if _, got := fake.ForwardArgsForCall(2); got != req {
+		t.Errorf("ForwardArgsForCall(2) = %v, want %v", got, req)
+	}
+	if n := fake.ReverseCallCount(); n != 0 {
+		t.Errorf("ReverseCallCount() = %d, want 0", n)
+	}
+}
+
+func TestFakeAPI_Stub(t *testing.T) {
+	fake := new(FakeAPI)
+	fake.ReverseCalls(func(_ context.Context, req *geocoding.ReverseRequest) (*geocoding.Response, error) {
+		if req.Longitude > 180 {
+			return nil, errors.New("invalid longitude")
+		}
+		return &geocoding.Response{Type: "FeatureCollection"}, nil
+	})
+
+	if _, err := fake.Reverse(context.Background(), &geocoding.ReverseRequest{Longitude: 12.5, Latitude: 41.9}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := fake.Reverse(context.Background(), &geocoding.ReverseRequest{Longitude: 200}); err == nil {
+		t.Error("expected error from stub")
+	}
+
+	resp, err := fake.Batch(context.Background(), &geocoding.BatchRequest{})
+	if resp != nil || err != nil {
+		t.Errorf("unconfigured Batch() = %v, %v; want zero values", resp, err)
+	}
+
+	invocations := fake.Invocations()
+	if len(invocations["Reverse"]) != 2 || len(invocations["Batch"]) != 1 {
+		t.Errorf("unexpected invocations: %v", invocations)
+	}
+}
+
+func TestFakeAPI_Concurrent(t *testing.T) {
+	fake := new(FakeAPI)
+	fake.ForwardStructuredReturns(&geocoding.Response{}, nil)
+
+	done := make(chan struct{})
+	for range 10 {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			fake.ForwardStructured(context.Background(), &geocoding.StructuredForwardRequest{Place: "Rome"})
+		}()
+	}
+	for range 10 {
+		<-done
+	}
+	if n := fake.ForwardStructuredCallCount(); n != 10 {
+		t.Errorf("ForwardStructuredCallCount() = %d, want 10", n)
+	}
+}
@@ -0,0 +1,97 @@
+package geocoding
+
+import (
+	"encoding/json"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// ToGeoJSON converts the feature to an RFC 7946 feature with flattened
+// properties. A nil opts keeps every property.
+func (f Feature) ToGeoJSON(opts *geojson.ExportOptions) (*geojson.Feature, error) {
+	location := f.Geometry.Coordinates
+	if location == (geojson.LngLat{}) {
+		location = f.Properties.Coordinates.LngLat()
+	}
+
+	properties, err := geojson.FlattenProperties(f.Properties, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	out := geojson.NewFeature(location.Point())
+	out.Properties = properties
+	out.ID = f.ID
+	if f.ID == "" {
+		out.ID = f.Properties.MapboxID
+	}
+	if opts != nil && opts.BBox && f.Properties.BBox != nil {
+		out.BBox = f.Properties.BBox.Slice()
+	}
+	return out, nil
+}
+
+// ToGeoJSON converts the response to an RFC 7946 feature collection with
+// flattened properties. A nil opts keeps every property.
+func (r *Response) ToGeoJSON(opts *geojson.ExportOptions) (*geojson.FeatureCollection, error) {
+	fc := geojson.NewFeatureCollection()
+	for _, f := range r.Features {
+		feature, err := f.ToGeoJSON(opts)
+		if err != nil {
+			return nil, err
+		}
+		fc.Features = append(fc.Features, feature)
+	}
+
+	if opts != nil && opts.BBox {
+		fc.ComputeBBox()
+	}
+	return fc, nil
+}
+
+// MarshalFeatureCollection encodes the response as RFC 7946 GeoJSON.
+func (r *Response) MarshalFeatureCollection(opts *geojson.ExportOptions) ([]byte, error) {
+	fc, err := r.ToGeoJSON(opts)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fc)
+}
+
+// ToGeoJSON converts the features of every successful batch result into a
+// single RFC 7946 feature collection. Each feature carries a batch_index
+// property, the position of its query, and batch_id when the query had an
+// ID. A nil opts keeps every property.
+func (r *BatchResponse) ToGeoJSON(opts *geojson.ExportOptions) (*geojson.FeatureCollection, error) {
+	fc := geojson.NewFeatureCollection()
+	for i, result := range r.Results {
+		if result.Response == nil {
+			continue
+		}
+		for _, f := range result.Response.Features {
+			feature, err := f.ToGeoJSON(opts)
+			if err != nil {
+				return nil, err
+			}
+			feature.Properties["batch_index"] = i
+			if result.ID != "" {
+				feature.Properties["batch_id"] = result.ID
+			}
+			fc.Features = append(fc.Features, feature)
+		}
+	}
+
+	if opts != nil && opts.BBox {
+		fc.ComputeBBox()
+	}
+	return fc, nil
+}
+
+// MarshalFeatureCollection encodes the batch response as RFC 7946 GeoJSON.
+func (r *BatchResponse) MarshalFeatureCollection(opts *geojson.ExportOptions) ([]byte, error) {
+	fc, err := r.ToGeoJSON(opts)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fc)
+}
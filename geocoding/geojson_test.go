@@ -0,0 +1,169 @@
+package geocoding
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
+)
+
+func TestResponse_ToGeoJSON(t *testing.T) {
+	var resp Response
+	if err := json.Unmarshal([]byte(testutil.ForwardGeocodingResponse), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		opts     *geojson.ExportOptions
+		validate func(*testing.T, *geojson.FeatureCollection)
+	}{
+		{
+			name: "all properties",
+			opts: nil,
+			validate: func(t *testing.T, fc *geojson.FeatureCollection) {
+				f := fc.Features[0]
+				if f.ID != "address.123456" {
+					t.Errorf("expected feature ID, got %v", f.ID)
+				}
+				point, ok := f.Geometry.(*geojson.Point)
+				if !ok || point.Longitude() != -77.036543 || point.Latitude() != 38.897676 {
+					t.Errorf("unexpected geometry %+v", f.Geometry)
+				}
+				if f.Properties["context.region.region_code"] != "DC" {
+					t.Errorf("expected flattened region code, got %v", f.Properties["context.region.region_code"])
+				}
+				if fc.BBox != nil || f.BBox != nil {
+					t.Error("expected no bbox without BBox option")
+				}
+			},
+		},
+		{
+			name: "selected properties with bbox",
+			opts: &geojson.ExportOptions{
+				Include: []string{"name", "context.country.country_code"},
+				Rename:  map[string]string{"context.country.country_code": "country"},
+				BBox:    true,
+			},
+			validate: func(t *testing.T, fc *geojson.FeatureCollection) {
+				want := map[string]any{"name": "1600 Pennsylvania Avenue NW", "country": "US"}
+				if !reflect.DeepEqual(fc.Features[0].Properties, want) {
+					t.Errorf("Properties = %v, want %v", fc.Features[0].Properties, want)
+				}
+				wantBBox := []float64{-77.036543, 38.897676, -77.036543, 38.897676}
+				if !reflect.DeepEqual(fc.BBox, wantBBox) {
+					t.Errorf("BBox = %v, want %v", fc.BBox, wantBBox)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc, err := resp.ToGeoJSON(tt.opts)
+			if err != nil {
+				t.Fatalf("ToGeoJSON() error = %v", err)
+			}
+			if fc.Type != "FeatureCollection" || len(fc.Features) != 1 {
+				t.Fatalf("unexpected collection %+v", fc)
+			}
+			tt.validate(t, fc)
+		})
+	}
+}
+
+func TestFeature_ToGeoJSON(t *testing.T) {
+	f := Feature{
+		Properties: Properties{
+			MapboxID:    "place.1",
+			Name:        "Fiji",
+			Coordinates: Coordinates{Longitude: 178, Latitude: -17.8},
+			BBox:        geojson.NewBBox(177, -19, -178, -16),
+		},
+	}
+
+	out, err := f.ToGeoJSON(&geojson.ExportOptions{BBox: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out.ID != "place.1" {
+		t.Errorf("expected mapbox_id as ID, got %v", out.ID)
+	}
+	if p := out.Geometry.(*geojson.Point); p.Longitude() != 178 {
+		t.Errorf("expected geometry from properties coordinates, got %v", p.Coordinates)
+	}
+	if !reflect.DeepEqual(out.BBox, []float64{177, -19, -178, -16}) {
+		t.Errorf("expected the feature bbox, got %v", out.BBox)
+	}
+}
+
+func TestResponse_MarshalFeatureCollection(t *testing.T) {
+	var resp Response
+	if err := json.Unmarshal([]byte(testutil.ReverseGeocodingResponse), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := resp.MarshalFeatureCollection(&geojson.ExportOptions{Include: []string{"name"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fc geojson.FeatureCollection
+	if err := json.Unmarshal(data, &fc); err != nil {
+		t.Fatalf("output is not valid GeoJSON: %v", err)
+	}
+	if len(fc.Features) != 1 || fc.Features[0].Properties["name"] != "San Francisco" {
+		t.Errorf("unexpected output %s", data)
+	}
+}
+
+func TestBatchResponse_ToGeoJSON(t *testing.T) {
+	var resp BatchResponse
+	if err := json.Unmarshal([]byte(testutil.BatchGeocodingResponse), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	fc, err := resp.ToGeoJSON(&geojson.ExportOptions{Include: []string{"name"}, BBox: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fc.Features) != 2 {
+		t.Fatalf("expected 2 features from successful results, got %d", len(fc.Features))
+	}
+	second := fc.Features[1].Properties
+	if second["name"] != "Los Angeles" || second["batch_index"] != 1 || second["batch_id"] != "query2" {
+		t.Errorf("unexpected properties %v", second)
+	}
+	wantBBox := []float64{-118.243683, 34.052235, -74.005974, 40.712776}
+	if !reflect.DeepEqual(fc.BBox, wantBBox) {
+		t.Errorf("BBox = %v, want %v", fc.BBox, wantBBox)
+	}
+
+	if _, err := resp.MarshalFeatureCollection(nil); err != nil {
+		t.Errorf("MarshalFeatureCollection() error = %v", err)
+	}
+}
+
+func TestGeometry_JSON(t *testing.T) {
+	data := `{"type":"Point","coordinates":[-77.036543,38.897676]}`
+
+	var g Geometry
+	if err := json.Unmarshal([]byte(data), &g); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if want := (geojson.LngLat{Longitude: -77.036543, Latitude: 38.897676}); g.Type != "Point" || g.Coordinates != want {
+		t.Errorf("unexpected geometry %+v", g)
+	}
+
+	out, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(out) != data {
+		t.Errorf("Marshal() = %s, want %s", out, data)
+	}
+}
@@ -6,11 +6,17 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
 )
 
 // Reverse performs reverse geocoding.
 // It converts geographic coordinates into a human-readable address.
 func (s *Service) Reverse(ctx context.Context, req *ReverseRequest) (*Response, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.GeocodingReverse, req)
+
 	// Validate coordinates
 	if err := validateCoordinates(req.Longitude, req.Latitude); err != nil {
 		return nil, err
@@ -53,16 +59,14 @@ func (s *Service) buildReverseQuery(req *ReverseRequest) url.Values {
 		q.Set("worldview", req.Worldview)
 	}
 
+	if req.Permanent {
+		q.Set("permanent", "true")
+	}
+
 	return q
 }
 
 // validateCoordinates validates longitude and latitude values.
 func validateCoordinates(longitude, latitude float64) error {
-	if longitude < -180 || longitude > 180 {
-		return fmt.Errorf("longitude must be between -180 and 180, got %f", longitude)
-	}
-	if latitude < -90 || latitude > 90 {
-		return fmt.Errorf("latitude must be between -90 and 90, got %f", latitude)
-	}
-	return nil
+	return geojson.LngLat{Longitude: longitude, Latitude: latitude}.Validate()
 }
@@ -129,6 +129,7 @@ func TestBuildReverseQuery(t *testing.T) {
 		Limit:     intPtr(5),
 		Types:     []string{"place", "region"},
 		Worldview: "US",
+		Permanent: true,
 	}
 
 	query := service.buildReverseQuery(req)
@@ -145,6 +146,7 @@ func TestBuildReverseQuery(t *testing.T) {
 		{"limit", "5"},
 		{"types", "place,region"},
 		{"worldview", "US"},
+		{"permanent", "true"},
 	}
 
 	for _, tt := range tests {
@@ -1,6 +1,12 @@
 // Package geocoding provides access to the Mapbox Geocoding API.
 package geocoding
 
+import (
+	"encoding/json"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
 // ForwardRequest represents a forward geocoding request using text-based search.
 type ForwardRequest struct {
 	// Query is the search text (required).
@@ -9,8 +15,8 @@ type ForwardRequest struct {
 	// Autocomplete specifies whether to return autocomplete results (default: true).
 	Autocomplete *bool `json:"autocomplete,omitempty"`
 
-	// BBox limits results to a bounding box [min_lon, min_lat, max_lon, max_lat].
-	BBox []float64 `json:"bbox,omitempty"`
+	// BBox limits results to a bounding box.
+	BBox *geojson.BBox `json:"bbox,omitempty"`
 
 	// Country limits results to one or more countries (ISO 3166 alpha-2 codes).
 	Country []string `json:"country,omitempty"`
@@ -21,14 +27,17 @@ type ForwardRequest struct {
 	// Limit sets the maximum number of results (1-10, default: 5).
 	Limit *int `json:"limit,omitempty"`
 
-	// Proximity biases results toward a location [lon, lat].
-	Proximity []float64 `json:"proximity,omitempty"`
+	// Proximity biases results toward a location.
+	Proximity *geojson.LngLat `json:"proximity,omitempty"`
 
 	// Types filters results by feature types.
 	Types []string `json:"types,omitempty"`
 
 	// Worldview returns features for a specific worldview (country code).
 	Worldview string `json:"worldview,omitempty"`
+	// Permanent requests results that may be stored indefinitely. Permanent
+	// requests are billed at a different rate (default: false).
+	Permanent bool `json:"permanent,omitempty"`
 }
 
 // StructuredForwardRequest represents a forward geocoding request using structured address components.
@@ -57,8 +66,8 @@ type StructuredForwardRequest struct {
 	// Autocomplete specifies whether to return autocomplete results (default: true).
 	Autocomplete *bool `json:"autocomplete,omitempty"`
 
-	// BBox limits results to a bounding box [min_lon, min_lat, max_lon, max_lat].
-	BBox []float64 `json:"bbox,omitempty"`
+	// BBox limits results to a bounding box.
+	BBox *geojson.BBox `json:"bbox,omitempty"`
 
 	// Language sets the language for results (IETF language tags).
 	Language string `json:"language,omitempty"`
@@ -66,11 +75,14 @@ type StructuredForwardRequest struct {
 	// Limit sets the maximum number of results (1-10, default: 5).
 	Limit *int `json:"limit,omitempty"`
 
-	// Proximity biases results toward a location [lon, lat].
-	Proximity []float64 `json:"proximity,omitempty"`
+	// Proximity biases results toward a location.
+	Proximity *geojson.LngLat `json:"proximity,omitempty"`
 
 	// Worldview returns features for a specific worldview (country code).
 	Worldview string `json:"worldview,omitempty"`
+	// Permanent requests results that may be stored indefinitely. Permanent
+	// requests are billed at a different rate (default: false).
+	Permanent bool `json:"permanent,omitempty"`
 }
 
 // ReverseRequest represents a reverse geocoding request.
@@ -95,12 +107,19 @@ type ReverseRequest struct {
 
 	// Worldview returns features for a specific worldview (country code).
 	Worldview string `json:"worldview,omitempty"`
+	// Permanent requests results that may be stored indefinitely. Permanent
+	// requests are billed at a different rate (default: false).
+	Permanent bool `json:"permanent,omitempty"`
 }
 
 // BatchRequest represents a batch geocoding request.
 type BatchRequest struct {
 	// Queries is the list of forward or reverse queries (max 1000).
 	Queries []BatchQuery `json:"queries"`
+
+	// Permanent requests results that may be stored indefinitely. Each
+	// query is billed at the permanent rate (default: false).
+	Permanent bool `json:"-"`
 }
 
 // BatchQuery represents a single query in a batch request.
@@ -157,15 +176,42 @@ type Feature struct {
 	Properties Properties `json:"properties"`
 }
 
-// Geometry represents the geographic coordinates of a feature.
+// Geometry represents the geographic coordinates of a feature. It marshals
+// to and from the GeoJSON form {"type": ..., "coordinates": [lon, lat]}.
 type Geometry struct {
 	// Type is the GeoJSON geometry type (should be "Point").
-	Type string `json:"type"`
+	Type string
+
+	// Coordinates is the point position.
+	Coordinates geojson.LngLat
+}
+
+// LngLat returns the point coordinates.
+func (g Geometry) LngLat() geojson.LngLat {
+	return g.Coordinates
+}
 
-	// Coordinates contains [longitude, latitude].
+// geometryJSON is the wire form of Geometry.
+type geometryJSON struct {
+	Type        string    `json:"type"`
 	Coordinates []float64 `json:"coordinates"`
 }
 
+// MarshalJSON encodes the geometry with [longitude, latitude] coordinates.
+func (g Geometry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(geometryJSON{Type: g.Type, Coordinates: g.Coordinates.Position()})
+}
+
+// UnmarshalJSON decodes a geometry with [longitude, latitude] coordinates.
+func (g *Geometry) UnmarshalJSON(data []byte) error {
+	var raw geometryJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*g = Geometry{Type: raw.Type, Coordinates: (&geojson.Point{Coordinates: raw.Coordinates}).LngLat()}
+	return nil
+}
+
 // Properties contains metadata about a geocoding result.
 type Properties struct {
 	// MapboxID is a unique Mapbox identifier.
@@ -186,14 +232,14 @@ type Properties struct {
 	// PlaceNamePreferred is the preferred full place name.
 	PlaceNamePreferred string `json:"place_name_preferred,omitempty"`
 
-	// Context contains contextual information (region, country, etc.).
-	Context map[string]Context `json:"context,omitempty"`
+	// Context contains the hierarchy of places the feature belongs to.
+	Context *Context `json:"context,omitempty"`
 
 	// Coordinates contains the feature's coordinates.
 	Coordinates Coordinates `json:"coordinates"`
 
-	// BBox is the bounding box [min_lon, min_lat, max_lon, max_lat].
-	BBox []float64 `json:"bbox,omitempty"`
+	// BBox is the bounding box of the feature.
+	BBox *geojson.BBox `json:"bbox,omitempty"`
 
 	// MatchCode indicates the quality of the match.
 	MatchCode *MatchCode `json:"match_code,omitempty"`
@@ -211,19 +257,76 @@ type Properties struct {
 	Postcode string `json:"postcode,omitempty"`
 }
 
-// Context represents contextual information about a feature's location.
+// Context represents the hierarchy of places a feature belongs to.
 type Context struct {
+	Country      *ContextElement `json:"country,omitempty"`
+	Region       *ContextElement `json:"region,omitempty"`
+	Postcode     *ContextElement `json:"postcode,omitempty"`
+	District     *ContextElement `json:"district,omitempty"`
+	Place        *ContextElement `json:"place,omitempty"`
+	Locality     *ContextElement `json:"locality,omitempty"`
+	Neighborhood *ContextElement `json:"neighborhood,omitempty"`
+	Street       *ContextElement `json:"street,omitempty"`
+	Address      *ContextElement `json:"address,omitempty"`
+
+	// Extra holds context entries of types not listed above, keyed by type,
+	// as returned by the API.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// ContextElement represents a single element in the context hierarchy.
+type ContextElement struct {
 	// MapboxID is the unique identifier for this context.
 	MapboxID string `json:"mapbox_id"`
 
 	// Name is the name of this context (e.g., region or country name).
 	Name string `json:"name"`
 
-	// Wikidata is the Wikidata identifier.
+	// WikidataID is the Wikidata identifier.
+	WikidataID string `json:"wikidata_id,omitempty"`
+
+	// Wikidata is the Wikidata identifier, as returned by earlier versions
+	// of the API. Context fills it in from WikidataID and the other way round.
+	//
+	// Deprecated: Use WikidataID. Wikidata will be removed in the next major
+	// release.
 	Wikidata string `json:"wikidata,omitempty"`
 
-	// ShortCode is a short code for this context (e.g., "US-CA").
+	// ShortCode is a short code for this context (e.g., "US-CA"), as returned
+	// by earlier versions of the API.
 	ShortCode string `json:"short_code,omitempty"`
+
+	// CountryCode is the ISO 3166-1 alpha-2 country code (country only).
+	CountryCode string `json:"country_code,omitempty"`
+
+	// CountryCodeAlpha3 is the ISO 3166-1 alpha-3 country code (country only).
+	CountryCodeAlpha3 string `json:"country_code_alpha_3,omitempty"`
+
+	// RegionCode is the region code without the country prefix, e.g. "CA"
+	// (region only).
+	RegionCode string `json:"region_code,omitempty"`
+
+	// RegionCodeFull is the ISO 3166-2 region code, e.g. "US-CA" (region only).
+	RegionCodeFull string `json:"region_code_full,omitempty"`
+
+	// AddressNumber is the house number (address only).
+	AddressNumber string `json:"address_number,omitempty"`
+
+	// StreetName is the street name (address only).
+	StreetName string `json:"street_name,omitempty"`
+
+	// Translations holds the name in other languages, keyed by language
+	// code, when several languages are requested.
+	Translations map[string]Translation `json:"translations,omitempty"`
+}
+
+// Translation is the name of a context element in another language.
+type Translation struct {
+	// Language is the IETF language tag.
+	Language string `json:"language"`
+
+	// Name is the translated name.
+	Name string `json:"name"`
 }
 
 // Coordinates represents longitude and latitude coordinates.
@@ -235,6 +338,12 @@ type Coordinates struct {
 	Latitude float64 `json:"latitude"`
 }
 
+// LngLat returns the coordinates as a geojson.LngLat for use with the geo and
+// tilemath packages.
+func (c Coordinates) LngLat() geojson.LngLat {
+	return geojson.LngLat{Longitude: c.Longitude, Latitude: c.Latitude}
+}
+
 // MatchCode indicates the quality of the geocoding match.
 type MatchCode struct {
 	// Confidence indicates the confidence level (exact, high, medium, low).
@@ -242,8 +351,80 @@ type MatchCode struct {
 
 	// Accuracy indicates the precision of the match.
 	Accuracy string `json:"accuracy,omitempty"`
+
+	// AddressNumber is the match status of the address number.
+	AddressNumber MatchStatus `json:"address_number,omitempty"`
+
+	// Street is the match status of the street.
+	Street MatchStatus `json:"street,omitempty"`
+
+	// Postcode is the match status of the postcode.
+	Postcode MatchStatus `json:"postcode,omitempty"`
+
+	// Place is the match status of the place (city, town).
+	Place MatchStatus `json:"place,omitempty"`
+
+	// Region is the match status of the region (state, province).
+	Region MatchStatus `json:"region,omitempty"`
+
+	// Locality is the match status of the locality.
+	Locality MatchStatus `json:"locality,omitempty"`
+
+	// Country is the match status of the country.
+	Country MatchStatus `json:"country,omitempty"`
+}
+
+// Components returns the match status of each address component present in
+// the match code, keyed by API field name.
+func (m MatchCode) Components() map[string]MatchStatus {
+	components := make(map[string]MatchStatus)
+	for name, status := range map[string]MatchStatus{
+		"address_number": m.AddressNumber,
+		"street":         m.Street,
+		"postcode":       m.Postcode,
+		"place":          m.Place,
+		"region":         m.Region,
+		"locality":       m.Locality,
+		"country":        m.Country,
+	} {
+		if status != "" {
+			components[name] = status
+		}
+	}
+	return components
 }
 
+// MatchStatus is the match status of a single address component.
+type MatchStatus string
+
+// Match statuses returned in MatchCode components.
+const (
+	MatchMatched       MatchStatus = "matched"
+	MatchUnmatched     MatchStatus = "unmatched"
+	MatchPlausible     MatchStatus = "plausible"
+	MatchInferred      MatchStatus = "inferred"
+	MatchNotApplicable MatchStatus = "not_applicable"
+)
+
+// Confidence levels returned in MatchCode.Confidence.
+const (
+	ConfidenceExact  = "exact"
+	ConfidenceHigh   = "high"
+	ConfidenceMedium = "medium"
+	ConfidenceLow    = "low"
+)
+
+// Accuracy values returned in Properties.Accuracy for address features.
+const (
+	AccuracyRooftop      = "rooftop"
+	AccuracyParcel       = "parcel"
+	AccuracyPoint        = "point"
+	AccuracyInterpolated = "interpolated"
+	AccuracyIntersection = "intersection"
+	AccuracyApproximate  = "approximate"
+	AccuracyStreet       = "street"
+)
+
 // BatchResponse represents a batch geocoding API response.
 type BatchResponse struct {
 	// Results contains the response for each query in the batch.
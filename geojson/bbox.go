@@ -0,0 +1,142 @@
+package geojson
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// BBox is a bounding box in WGS84 degrees. A box whose West edge is greater
+// than its East edge crosses the antimeridian.
+//
+// BBox marshals to and from the GeoJSON form [west, south, east, north].
+type BBox struct {
+	West  float64
+	South float64
+	East  float64
+	North float64
+}
+
+// NewBBox creates a bounding box from its edges.
+func NewBBox(west, south, east, north float64) *BBox {
+	return &BBox{West: west, South: south, East: east, North: north}
+}
+
+// BBoxFromSlice creates a bounding box from [min_lon, min_lat, max_lon, max_lat]
+// and validates it.
+func BBoxFromSlice(values []float64) (*BBox, error) {
+	if len(values) != 4 {
+		return nil, fmt.Errorf("bbox must have 4 values [min_lon, min_lat, max_lon, max_lat], got %d", len(values))
+	}
+
+	b := NewBBox(values[0], values[1], values[2], values[3])
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Validate checks that the edges are within range, that South is less than
+// North and that the box has a non-zero width.
+func (b BBox) Validate() error {
+	for _, edge := range []struct {
+		name  string
+		value float64
+	}{{"west", b.West}, {"east", b.East}} {
+		if edge.value < -180 || edge.value > 180 {
+			return fmt.Errorf("bbox %s must be between -180 and 180, got %f", edge.name, edge.value)
+		}
+	}
+
+	for _, edge := range []struct {
+		name  string
+		value float64
+	}{{"south", b.South}, {"north", b.North}} {
+		if edge.value < -90 || edge.value > 90 {
+			return fmt.Errorf("bbox %s must be between -90 and 90, got %f", edge.name, edge.value)
+		}
+	}
+
+	if b.South >= b.North {
+		return fmt.Errorf("bbox south (%f) must be less than north (%f)", b.South, b.North)
+	}
+
+	if b.West == b.East {
+		return fmt.Errorf("bbox west and east must differ, got %f", b.West)
+	}
+
+	return nil
+}
+
+// CrossesAntimeridian reports whether the box wraps across 180° longitude.
+func (b BBox) CrossesAntimeridian() bool {
+	return b.West > b.East
+}
+
+// Split returns the box as boxes that do not cross the antimeridian: the box
+// itself, or its eastern and western halves.
+func (b BBox) Split() []BBox {
+	if !b.CrossesAntimeridian() {
+		return []BBox{b}
+	}
+
+	return []BBox{
+		{West: b.West, South: b.South, East: 180, North: b.North},
+		{West: -180, South: b.South, East: b.East, North: b.North},
+	}
+}
+
+// Contains reports whether p lies inside the box, edges included.
+func (b BBox) Contains(p LngLat) bool {
+	if p.Latitude < b.South || p.Latitude > b.North {
+		return false
+	}
+
+	if b.CrossesAntimeridian() {
+		return p.Longitude >= b.West || p.Longitude <= b.East
+	}
+	return p.Longitude >= b.West && p.Longitude <= b.East
+}
+
+// Slice returns the box as [west, south, east, north].
+func (b BBox) Slice() []float64 {
+	return []float64{b.West, b.South, b.East, b.North}
+}
+
+// String returns the box as "west,south,east,north", the form used in query
+// parameters.
+func (b BBox) String() string {
+	return formatFloats(b.West, b.South, b.East, b.North)
+}
+
+// MarshalJSON encodes the box as [west, south, east, north].
+func (b BBox) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.Slice())
+}
+
+// UnmarshalJSON decodes a [west, south, east, north] array.
+func (b *BBox) UnmarshalJSON(data []byte) error {
+	var values []float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	if len(values) != 4 {
+		return fmt.Errorf("bbox must have 4 values, got %d", len(values))
+	}
+
+	*b = BBox{West: values[0], South: values[1], East: values[2], North: values[3]}
+	return nil
+}
+
+// formatFloats joins values with commas using the shortest representation.
+func formatFloats(values ...float64) string {
+	buf := make([]byte, 0, 16*len(values))
+	for i, v := range values {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendFloat(buf, v, 'f', -1, 64)
+	}
+	return string(buf)
+}
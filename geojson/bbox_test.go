@@ -0,0 +1,122 @@
+package geojson
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBBox_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		bbox    BBox
+		wantErr bool
+	}{
+		{name: "valid", bbox: BBox{West: -122.5, South: 37.7, East: -122.3, North: 37.8}},
+		{name: "crossing the antimeridian", bbox: BBox{West: 170, South: -20, East: -170, North: -10}},
+		{name: "west out of range", bbox: BBox{West: -181, South: 0, East: 10, North: 10}, wantErr: true},
+		{name: "north out of range", bbox: BBox{West: 0, South: 0, East: 10, North: 91}, wantErr: true},
+		{name: "south above north", bbox: BBox{West: 0, South: 10, East: 1, North: 5}, wantErr: true},
+		{name: "zero height", bbox: BBox{West: 0, South: 5, East: 1, North: 5}, wantErr: true},
+		{name: "zero width", bbox: BBox{West: 1, South: 0, East: 1, North: 5}, wantErr: true},
+		{name: "latitude and longitude swapped", bbox: BBox{West: 37.7, South: -122.5, East: 37.8, North: -122.3}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.bbox.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBBoxFromSlice(t *testing.T) {
+	b, err := BBoxFromSlice([]float64{-122.5, 37.7, -122.3, 37.8})
+	if err != nil {
+		t.Fatalf("BBoxFromSlice() error = %v", err)
+	}
+	if *b != (BBox{West: -122.5, South: 37.7, East: -122.3, North: 37.8}) {
+		t.Errorf("BBoxFromSlice() = %v", b)
+	}
+
+	if _, err := BBoxFromSlice([]float64{1, 2, 3}); err == nil {
+		t.Error("expected error for wrong length")
+	}
+	if _, err := BBoxFromSlice([]float64{0, 10, 1, 5}); err == nil {
+		t.Error("expected error for invalid bbox")
+	}
+}
+
+func TestBBox_Antimeridian(t *testing.T) {
+	b := BBox{West: 170, South: -20, East: -170, North: -10}
+
+	if !b.CrossesAntimeridian() {
+		t.Fatal("expected bbox to cross the antimeridian")
+	}
+
+	parts := b.Split()
+	if len(parts) != 2 {
+		t.Fatalf("expected 2 parts, got %d", len(parts))
+	}
+	if parts[0] != (BBox{West: 170, South: -20, East: 180, North: -10}) {
+		t.Errorf("unexpected eastern part %v", parts[0])
+	}
+	if parts[1] != (BBox{West: -180, South: -20, East: -170, North: -10}) {
+		t.Errorf("unexpected western part %v", parts[1])
+	}
+
+	if !b.Contains(LngLat{Longitude: 179, Latitude: -15}) || !b.Contains(LngLat{Longitude: -175, Latitude: -15}) {
+		t.Error("expected points on both sides of the antimeridian to be contained")
+	}
+	if b.Contains(LngLat{Longitude: 0, Latitude: -15}) {
+		t.Error("expected point at the prime meridian to be outside")
+	}
+
+	plain := BBox{West: 0, South: 0, East: 10, North: 10}
+	if len(plain.Split()) != 1 || !plain.Contains(LngLat{Longitude: 5, Latitude: 5}) {
+		t.Error("expected non-crossing bbox to be returned as is and contain its center")
+	}
+}
+
+func TestBBox_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		bbox     BBox
+		expected string
+	}{
+		{name: "decimals", bbox: BBox{West: -122.5, South: 37.7, East: -122.3, North: 37.8}, expected: "-122.5,37.7,-122.3,37.8"},
+		{name: "integers", bbox: BBox{West: -77, South: 38, East: -76, North: 39}, expected: "-77,38,-76,39"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.bbox.String(); got != tt.expected {
+				t.Errorf("String() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBBox_JSON(t *testing.T) {
+	b := BBox{West: -122.5, South: 37.7, East: -122.3, North: 37.8}
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != "[-122.5,37.7,-122.3,37.8]" {
+		t.Errorf("Marshal() = %s", data)
+	}
+
+	var decoded BBox
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded != b {
+		t.Errorf("Unmarshal() = %v, want %v", decoded, b)
+	}
+
+	if err := json.Unmarshal([]byte("[1,2,3]"), &decoded); err == nil {
+		t.Error("expected error for wrong length")
+	}
+}
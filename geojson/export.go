@@ -0,0 +1,182 @@
+package geojson
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+)
+
+// ExportOptions configures the conversion of API responses to GeoJSON.
+// Property keys are the flattened keys, e.g. "context.region.name".
+type ExportOptions struct {
+	// Include lists the properties to keep. A key also keeps the keys nested
+	// under it, so "context" keeps "context.region.name". Empty keeps all.
+	Include []string
+
+	// Omit lists the properties to drop, with the same nesting rule.
+	Omit []string
+
+	// Rename maps flattened keys to the names used in the output.
+	Rename map[string]string
+
+	// Separator joins the keys of nested objects. Defaults to ".".
+	Separator string
+
+	// BBox adds a bbox member to every feature and to the collection.
+	BBox bool
+}
+
+// FlattenProperties converts v, usually a response properties struct, into a
+// flat GeoJSON property map. Nested objects are flattened into joined keys;
+// arrays are kept as they are. The result is then filtered and renamed as
+// set in opts; a nil opts keeps every property.
+func FlattenProperties(v any, opts *ExportOptions) (map[string]any, error) {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+	sep := opts.Separator
+	if sep == "" {
+		sep = "."
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var nested map[string]any
+	if err := json.Unmarshal(data, &nested); err != nil {
+		return nil, err
+	}
+
+	flat := map[string]any{}
+	flatten(flat, "", nested, sep)
+
+	out := make(map[string]any, len(flat))
+	for key, value := range flat {
+		if len(opts.Include) > 0 && !matchesKey(key, opts.Include, sep) {
+			continue
+		}
+		if matchesKey(key, opts.Omit, sep) {
+			continue
+		}
+		if renamed, ok := opts.Rename[key]; ok {
+			key = renamed
+		}
+		out[key] = value
+	}
+	return out, nil
+}
+
+// flatten copies the leaves of m into out under prefixed keys.
+func flatten(out map[string]any, prefix string, m map[string]any, sep string) {
+	for key, value := range m {
+		if prefix != "" {
+			key = prefix + sep + key
+		}
+		if child, ok := value.(map[string]any); ok && len(child) > 0 {
+			flatten(out, key, child, sep)
+			continue
+		}
+		out[key] = value
+	}
+}
+
+// matchesKey reports whether key equals one of keys or is nested under one.
+func matchesKey(key string, keys []string, sep string) bool {
+	for _, k := range keys {
+		if key == k || strings.HasPrefix(key, k+sep) {
+			return true
+		}
+	}
+	return false
+}
+
+// Bounds returns the bounding box of a geometry. It reports false for a nil
+// or empty geometry.
+func Bounds(g Geometry) (BBox, bool) {
+	b := BBox{West: math.Inf(1), South: math.Inf(1), East: math.Inf(-1), North: math.Inf(-1)}
+	extend := func(pos []float64) {
+		if len(pos) < 2 {
+			return
+		}
+		b.West, b.East = math.Min(b.West, pos[0]), math.Max(b.East, pos[0])
+		b.South, b.North = math.Min(b.South, pos[1]), math.Max(b.North, pos[1])
+	}
+
+	var walk func(Geometry)
+	walk = func(g Geometry) {
+		switch g := g.(type) {
+		case *Point:
+			extend(g.Coordinates)
+		case *MultiPoint:
+			for _, p := range g.Coordinates {
+				extend(p)
+			}
+		case *LineString:
+			for _, p := range g.Coordinates {
+				extend(p)
+			}
+		case *MultiLineString:
+			for _, line := range g.Coordinates {
+				for _, p := range line {
+					extend(p)
+				}
+			}
+		case *Polygon:
+			for _, ring := range g.Coordinates {
+				for _, p := range ring {
+					extend(p)
+				}
+			}
+		case *MultiPolygon:
+			for _, polygon := range g.Coordinates {
+				for _, ring := range polygon {
+					for _, p := range ring {
+						extend(p)
+					}
+				}
+			}
+		case *GeometryCollection:
+			for _, member := range g.Geometries {
+				walk(member)
+			}
+		}
+	}
+	walk(g)
+
+	if b.West > b.East {
+		return BBox{}, false
+	}
+	return b, true
+}
+
+// ComputeBBox sets the bbox of every feature that has none from its geometry,
+// and the bbox of the collection from those of its features. A feature box
+// crossing the antimeridian widens the collection to all longitudes.
+func (fc *FeatureCollection) ComputeBBox() {
+	var total *BBox
+	for _, f := range fc.Features {
+		if len(f.BBox) != 4 {
+			b, ok := Bounds(f.Geometry)
+			if !ok {
+				continue
+			}
+			f.BBox = b.Slice()
+		}
+
+		b := BBox{West: f.BBox[0], South: f.BBox[1], East: f.BBox[2], North: f.BBox[3]}
+		if b.CrossesAntimeridian() {
+			b.West, b.East = -180, 180
+		}
+		if total == nil {
+			total = &b
+			continue
+		}
+		total.West, total.East = math.Min(total.West, b.West), math.Max(total.East, b.East)
+		total.South, total.North = math.Min(total.South, b.South), math.Max(total.North, b.North)
+	}
+
+	if total != nil {
+		fc.BBox = total.Slice()
+	}
+}
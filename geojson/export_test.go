@@ -0,0 +1,161 @@
+package geojson
+
+import (
+	"reflect"
+	"testing"
+)
+
+type exportProps struct {
+	Name    string         `json:"name"`
+	Tags    []string       `json:"tags,omitempty"`
+	Context map[string]any `json:"context,omitempty"`
+}
+
+func TestFlattenProperties(t *testing.T) {
+	props := exportProps{
+		Name: "Cafe",
+		Tags: []string{"coffee"},
+		Context: map[string]any{
+			"region":  map[string]any{"name": "California", "region_code": "CA"},
+			"country": map[string]any{"name": "United States"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts *ExportOptions
+		want map[string]any
+	}{
+		{
+			name: "all properties",
+			opts: nil,
+			want: map[string]any{
+				"name":                       "Cafe",
+				"tags":                       []any{"coffee"},
+				"context.region.name":        "California",
+				"context.region.region_code": "CA",
+				"context.country.name":       "United States",
+			},
+		},
+		{
+			name: "include prefix",
+			opts: &ExportOptions{Include: []string{"name", "context.region"}},
+			want: map[string]any{
+				"name":                       "Cafe",
+				"context.region.name":        "California",
+				"context.region.region_code": "CA",
+			},
+		},
+		{
+			name: "omit and rename",
+			opts: &ExportOptions{
+				Omit:   []string{"context.country", "tags"},
+				Rename: map[string]string{"context.region.region_code": "state"},
+			},
+			want: map[string]any{
+				"name":                "Cafe",
+				"context.region.name": "California",
+				"state":               "CA",
+			},
+		},
+		{
+			name: "separator",
+			opts: &ExportOptions{Separator: "_", Include: []string{"context_country"}},
+			want: map[string]any{"context_country_name": "United States"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FlattenProperties(props, tt.opts)
+			if err != nil {
+				t.Fatalf("FlattenProperties() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FlattenProperties() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlattenProperties_NotObject(t *testing.T) {
+	if _, err := FlattenProperties([]string{"a"}, nil); err == nil {
+		t.Error("expected error for non-object properties")
+	}
+}
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry Geometry
+		want     BBox
+		wantOK   bool
+	}{
+		{name: "point", geometry: NewPoint(12.5, 41.9), want: BBox{12.5, 41.9, 12.5, 41.9}, wantOK: true},
+		{
+			name:     "line string",
+			geometry: NewLineString([][]float64{{0, 1}, {3, -2}, {1, 5}}),
+			want:     BBox{0, -2, 3, 5},
+			wantOK:   true,
+		},
+		{
+			name:     "multi polygon",
+			geometry: NewMultiPolygon([][][][]float64{{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}, {{{5, 5}, {6, 5}, {6, 7}, {5, 5}}}}),
+			want:     BBox{0, 0, 6, 7},
+			wantOK:   true,
+		},
+		{
+			name:     "geometry collection",
+			geometry: NewGeometryCollection(NewPoint(-1, -1), NewPoint(2, 3)),
+			want:     BBox{-1, -1, 2, 3},
+			wantOK:   true,
+		},
+		{name: "nil", geometry: nil, wantOK: false},
+		{name: "empty", geometry: NewLineString(nil), wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Bounds(tt.geometry)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("Bounds() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFeatureCollection_ComputeBBox(t *testing.T) {
+	withBBox := NewFeature(NewPoint(10, 10))
+	withBBox.BBox = []float64{9, 9, 11, 12}
+
+	fc := NewFeatureCollection(
+		NewFeature(NewPoint(1, 2)),
+		withBBox,
+		NewFeature(nil),
+	)
+	fc.ComputeBBox()
+
+	if !reflect.DeepEqual(fc.Features[0].BBox, []float64{1, 2, 1, 2}) {
+		t.Errorf("unexpected feature bbox %v", fc.Features[0].BBox)
+	}
+	if fc.Features[2].BBox != nil {
+		t.Errorf("expected no bbox for empty geometry, got %v", fc.Features[2].BBox)
+	}
+	if !reflect.DeepEqual(fc.BBox, []float64{1, 2, 11, 12}) {
+		t.Errorf("unexpected collection bbox %v", fc.BBox)
+	}
+
+	crossing := NewFeature(NewPoint(179, 0))
+	crossing.BBox = []float64{170, -5, -170, 5}
+	fc = NewFeatureCollection(crossing, NewFeature(NewPoint(0, 10)))
+	fc.ComputeBBox()
+	if !reflect.DeepEqual(fc.BBox, []float64{-180, -5, 180, 10}) {
+		t.Errorf("unexpected collection bbox across the antimeridian %v", fc.BBox)
+	}
+
+	empty := NewFeatureCollection()
+	empty.ComputeBBox()
+	if empty.BBox != nil {
+		t.Errorf("expected no bbox for empty collection, got %v", empty.BBox)
+	}
+}
@@ -0,0 +1,119 @@
+package geojson
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Feature represents a GeoJSON Feature with an arbitrary geometry.
+type Feature struct {
+	Type       string         `json:"type"`
+	ID         any            `json:"id,omitempty"`
+	BBox       []float64      `json:"bbox,omitempty"`
+	Geometry   Geometry       `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// NewFeature creates a new Feature with the given geometry and an empty property map.
+func NewFeature(geometry Geometry) *Feature {
+	return &Feature{
+		Type:       "Feature",
+		Geometry:   geometry,
+		Properties: map[string]any{},
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the geometry into its concrete type.
+func (f *Feature) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type       string          `json:"type"`
+		ID         any             `json:"id,omitempty"`
+		BBox       []float64       `json:"bbox,omitempty"`
+		Geometry   json.RawMessage `json:"geometry"`
+		Properties map[string]any  `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	geometry, err := UnmarshalGeometry(raw.Geometry)
+	if err != nil {
+		return err
+	}
+
+	f.Type = raw.Type
+	f.ID = raw.ID
+	f.BBox = raw.BBox
+	f.Geometry = geometry
+	f.Properties = raw.Properties
+
+	return nil
+}
+
+// FeatureCollection represents a GeoJSON FeatureCollection.
+type FeatureCollection struct {
+	Type     string     `json:"type"`
+	BBox     []float64  `json:"bbox,omitempty"`
+	Features []*Feature `json:"features"`
+}
+
+// NewFeatureCollection creates a new FeatureCollection with the given features.
+func NewFeatureCollection(features ...*Feature) *FeatureCollection {
+	if features == nil {
+		features = []*Feature{}
+	}
+	return &FeatureCollection{
+		Type:     "FeatureCollection",
+		Features: features,
+	}
+}
+
+// UnmarshalGeometry decodes a GeoJSON geometry object into its concrete type.
+// A null or empty input yields a nil Geometry.
+func UnmarshalGeometry(data []byte) (Geometry, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var head struct {
+		Type       string            `json:"type"`
+		Geometries []json.RawMessage `json:"geometries"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+
+	var g Geometry
+	switch head.Type {
+	case TypePoint:
+		g = &Point{}
+	case TypeMultiPoint:
+		g = &MultiPoint{}
+	case TypeLineString:
+		g = &LineString{}
+	case TypeMultiLineString:
+		g = &MultiLineString{}
+	case TypePolygon:
+		g = &Polygon{}
+	case TypeMultiPolygon:
+		g = &MultiPolygon{}
+	case TypeGeometryCollection:
+		collection := &GeometryCollection{Type: TypeGeometryCollection}
+		for _, raw := range head.Geometries {
+			member, err := UnmarshalGeometry(raw)
+			if err != nil {
+				return nil, err
+			}
+			collection.Geometries = append(collection.Geometries, member)
+		}
+		return collection, nil
+	default:
+		return nil, fmt.Errorf("unsupported geometry type %q", head.Type)
+	}
+
+	if err := json.Unmarshal(data, g); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
@@ -0,0 +1,112 @@
+package geojson
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFeature_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry Geometry
+	}{
+		{name: "point", geometry: NewPoint(12.49, 41.89)},
+		{name: "multi point", geometry: NewMultiPoint([][]float64{{0, 0}, {1, 1}})},
+		{name: "line string", geometry: NewLineString([][]float64{{0, 0}, {1, 1}})},
+		{name: "multi line string", geometry: NewMultiLineString([][][]float64{{{0, 0}, {1, 1}}})},
+		{name: "polygon", geometry: NewPolygon([][][]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}})},
+		{name: "multi polygon", geometry: NewMultiPolygon([][][][]float64{{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}})},
+		{name: "geometry collection", geometry: NewGeometryCollection(NewPoint(1, 2), NewLineString([][]float64{{0, 0}, {1, 1}}))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFeature(tt.geometry)
+			f.ID = "feature.1"
+			f.Properties["name"] = "test"
+
+			data, err := json.Marshal(f)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+
+			var decoded Feature
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			if decoded.Geometry == nil {
+				t.Fatal("expected geometry")
+			}
+			if decoded.Geometry.GeometryType() != tt.geometry.GeometryType() {
+				t.Errorf("expected %s, got %s", tt.geometry.GeometryType(), decoded.Geometry.GeometryType())
+			}
+			if decoded.ID != "feature.1" {
+				t.Errorf("expected ID feature.1, got %v", decoded.ID)
+			}
+			if decoded.Properties["name"] != "test" {
+				t.Errorf("expected name test, got %v", decoded.Properties["name"])
+			}
+		})
+	}
+}
+
+func TestFeatureCollection_Unmarshal(t *testing.T) {
+	data := []byte(`{
+		"type": "FeatureCollection",
+		"features": [
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [1, 2]}, "properties": {}},
+			{"type": "Feature", "geometry": null, "properties": {"empty": true}}
+		]
+	}`)
+
+	var fc FeatureCollection
+	if err := json.Unmarshal(data, &fc); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if len(fc.Features) != 2 {
+		t.Fatalf("expected 2 features, got %d", len(fc.Features))
+	}
+
+	point, ok := fc.Features[0].Geometry.(*Point)
+	if !ok {
+		t.Fatalf("expected *Point, got %T", fc.Features[0].Geometry)
+	}
+	if point.Longitude() != 1 || point.Latitude() != 2 {
+		t.Errorf("unexpected coordinates %v", point.Coordinates)
+	}
+
+	if fc.Features[1].Geometry != nil {
+		t.Errorf("expected nil geometry, got %v", fc.Features[1].Geometry)
+	}
+}
+
+func TestUnmarshalGeometry_Unsupported(t *testing.T) {
+	if _, err := UnmarshalGeometry([]byte(`{"type": "Circle"}`)); err == nil {
+		t.Error("expected error for unsupported geometry type")
+	}
+}
+
+func TestNewFeatureCollection_Empty(t *testing.T) {
+	data, err := json.Marshal(NewFeatureCollection())
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if string(data) != `{"type":"FeatureCollection","features":[]}` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+}
+
+func TestPoint_Accessors(t *testing.T) {
+	p := NewPoint(-122.4194, 37.7749)
+	if p.Longitude() != -122.4194 || p.Latitude() != 37.7749 {
+		t.Errorf("unexpected coordinates %v", p.Coordinates)
+	}
+
+	empty := &Point{}
+	if empty.Longitude() != 0 || empty.Latitude() != 0 {
+		t.Error("expected zero coordinates for empty point")
+	}
+}
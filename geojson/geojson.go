@@ -0,0 +1,137 @@
+// Package geojson provides GeoJSON (RFC 7946) types shared across the SDK.
+package geojson
+
+// Geometry type names.
+const (
+	TypePoint              = "Point"
+	TypeMultiPoint         = "MultiPoint"
+	TypeLineString         = "LineString"
+	TypeMultiLineString    = "MultiLineString"
+	TypePolygon            = "Polygon"
+	TypeMultiPolygon       = "MultiPolygon"
+	TypeGeometryCollection = "GeometryCollection"
+)
+
+// Geometry is implemented by all GeoJSON geometry types.
+type Geometry interface {
+	// GeometryType returns the GeoJSON type name, e.g. "Point".
+	GeometryType() string
+}
+
+// Point represents a GeoJSON Point geometry with coordinates [longitude, latitude].
+type Point struct {
+	Type        string    `json:"type"`
+	Coordinates []float64 `json:"coordinates"`
+}
+
+// NewPoint creates a new Point with the given longitude and latitude.
+func NewPoint(longitude, latitude float64) *Point {
+	return &Point{
+		Type:        TypePoint,
+		Coordinates: []float64{longitude, latitude},
+	}
+}
+
+// GeometryType implements Geometry.
+func (p *Point) GeometryType() string { return TypePoint }
+
+// Longitude returns the longitude coordinate.
+func (p *Point) Longitude() float64 {
+	if len(p.Coordinates) >= 1 {
+		return p.Coordinates[0]
+	}
+	return 0
+}
+
+// Latitude returns the latitude coordinate.
+func (p *Point) Latitude() float64 {
+	if len(p.Coordinates) >= 2 {
+		return p.Coordinates[1]
+	}
+	return 0
+}
+
+// MultiPoint represents a GeoJSON MultiPoint geometry.
+type MultiPoint struct {
+	Type        string      `json:"type"`
+	Coordinates [][]float64 `json:"coordinates"`
+}
+
+// NewMultiPoint creates a new MultiPoint from a list of [lon, lat] positions.
+func NewMultiPoint(coordinates [][]float64) *MultiPoint {
+	return &MultiPoint{Type: TypeMultiPoint, Coordinates: coordinates}
+}
+
+// GeometryType implements Geometry.
+func (m *MultiPoint) GeometryType() string { return TypeMultiPoint }
+
+// LineString represents a GeoJSON LineString geometry.
+type LineString struct {
+	Type        string      `json:"type"`
+	Coordinates [][]float64 `json:"coordinates"`
+}
+
+// NewLineString creates a new LineString from a list of [lon, lat] positions.
+func NewLineString(coordinates [][]float64) *LineString {
+	return &LineString{Type: TypeLineString, Coordinates: coordinates}
+}
+
+// GeometryType implements Geometry.
+func (l *LineString) GeometryType() string { return TypeLineString }
+
+// MultiLineString represents a GeoJSON MultiLineString geometry.
+type MultiLineString struct {
+	Type        string        `json:"type"`
+	Coordinates [][][]float64 `json:"coordinates"`
+}
+
+// NewMultiLineString creates a new MultiLineString from a list of lines.
+func NewMultiLineString(coordinates [][][]float64) *MultiLineString {
+	return &MultiLineString{Type: TypeMultiLineString, Coordinates: coordinates}
+}
+
+// GeometryType implements Geometry.
+func (m *MultiLineString) GeometryType() string { return TypeMultiLineString }
+
+// Polygon represents a GeoJSON Polygon geometry. The first ring is the
+// exterior ring; any further rings are holes.
+type Polygon struct {
+	Type        string        `json:"type"`
+	Coordinates [][][]float64 `json:"coordinates"`
+}
+
+// NewPolygon creates a new Polygon from a list of linear rings.
+func NewPolygon(coordinates [][][]float64) *Polygon {
+	return &Polygon{Type: TypePolygon, Coordinates: coordinates}
+}
+
+// GeometryType implements Geometry.
+func (p *Polygon) GeometryType() string { return TypePolygon }
+
+// MultiPolygon represents a GeoJSON MultiPolygon geometry.
+type MultiPolygon struct {
+	Type        string          `json:"type"`
+	Coordinates [][][][]float64 `json:"coordinates"`
+}
+
+// NewMultiPolygon creates a new MultiPolygon from a list of polygons.
+func NewMultiPolygon(coordinates [][][][]float64) *MultiPolygon {
+	return &MultiPolygon{Type: TypeMultiPolygon, Coordinates: coordinates}
+}
+
+// GeometryType implements Geometry.
+func (m *MultiPolygon) GeometryType() string { return TypeMultiPolygon }
+
+// GeometryCollection represents a GeoJSON GeometryCollection.
+type GeometryCollection struct {
+	Type       string     `json:"type"`
+	Geometries []Geometry `json:"geometries"`
+}
+
+// NewGeometryCollection creates a new GeometryCollection.
+func NewGeometryCollection(geometries ...Geometry) *GeometryCollection {
+	return &GeometryCollection{Type: TypeGeometryCollection, Geometries: geometries}
+}
+
+// GeometryType implements Geometry.
+func (g *GeometryCollection) GeometryType() string { return TypeGeometryCollection }
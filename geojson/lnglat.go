@@ -0,0 +1,46 @@
+package geojson
+
+import "fmt"
+
+// LngLat is a geographic position in WGS84 degrees. It is the common
+// coordinate type accepted by the geo and tilemath helpers; the coordinate
+// types of the service packages convert to it with their LngLat method.
+type LngLat struct {
+	// Longitude is the longitude in degrees, between -180 and 180.
+	Longitude float64 `json:"longitude"`
+
+	// Latitude is the latitude in degrees, between -90 and 90.
+	Latitude float64 `json:"latitude"`
+}
+
+// Validate checks that the longitude and latitude are within range.
+func (l LngLat) Validate() error {
+	if l.Longitude < -180 || l.Longitude > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180, got %f", l.Longitude)
+	}
+	if l.Latitude < -90 || l.Latitude > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90, got %f", l.Latitude)
+	}
+	return nil
+}
+
+// String returns the position as "longitude,latitude", the form used in query
+// parameters.
+func (l LngLat) String() string {
+	return formatFloats(l.Longitude, l.Latitude)
+}
+
+// Position returns the position as a GeoJSON [longitude, latitude] pair.
+func (l LngLat) Position() []float64 {
+	return []float64{l.Longitude, l.Latitude}
+}
+
+// Point returns the position as a GeoJSON Point.
+func (l LngLat) Point() *Point {
+	return NewPoint(l.Longitude, l.Latitude)
+}
+
+// LngLat returns the point's coordinates as a LngLat.
+func (p *Point) LngLat() LngLat {
+	return LngLat{Longitude: p.Longitude(), Latitude: p.Latitude()}
+}
@@ -0,0 +1,45 @@
+package geojson
+
+import "testing"
+
+func TestLngLat(t *testing.T) {
+	l := LngLat{Longitude: 12.4964, Latitude: 41.9028}
+
+	pos := l.Position()
+	if len(pos) != 2 || pos[0] != 12.4964 || pos[1] != 41.9028 {
+		t.Errorf("Position() = %v, want [12.4964 41.9028]", pos)
+	}
+
+	p := l.Point()
+	if p.Type != TypePoint {
+		t.Errorf("expected type %q, got %q", TypePoint, p.Type)
+	}
+	if p.LngLat() != l {
+		t.Errorf("Point().LngLat() = %v, want %v", p.LngLat(), l)
+	}
+}
+
+func TestLngLat_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		lngLat  LngLat
+		wantErr bool
+	}{
+		{name: "valid", lngLat: LngLat{Longitude: -122.4194, Latitude: 37.7749}},
+		{name: "swapped", lngLat: LngLat{Longitude: 37.7749, Latitude: -122.4194}, wantErr: true},
+		{name: "longitude too high", lngLat: LngLat{Longitude: 181}, wantErr: true},
+		{name: "latitude too low", lngLat: LngLat{Latitude: -91}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.lngLat.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if got := (LngLat{Longitude: -122.4194, Latitude: 37.7749}).String(); got != "-122.4194,37.7749" {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -0,0 +1,58 @@
+// Package antimeridian splits searches whose bounding box crosses the
+// antimeridian into one request per side and merges their results.
+package antimeridian
+
+import "github.com/pettinz/mapbox-go-sdk/geojson"
+
+// Split returns the bounding boxes to issue requests for. The Mapbox APIs
+// reject boxes crossing the antimeridian, so those are split into their
+// eastern and western halves; any other bbox, including nil, is returned as
+// is.
+func Split(bbox *geojson.BBox) []*geojson.BBox {
+	if bbox == nil || !bbox.CrossesAntimeridian() {
+		return []*geojson.BBox{bbox}
+	}
+
+	halves := bbox.Split()
+	return []*geojson.BBox{&halves[0], &halves[1]}
+}
+
+// Interleave merges the results of split requests. It takes items from each
+// list in turn, so each half contributes its best matches first, skipping
+// items whose non-empty key has already been seen, until the lists are
+// exhausted or limit items are taken. A nil limit stands for defaultLimit,
+// the limit the API applies to each request, so that the merged results are
+// no more than a single request returns.
+func Interleave[T any](lists [][]T, key func(T) string, limit *int, defaultLimit int) []T {
+	n := defaultLimit
+	if limit != nil {
+		n = *limit
+	}
+
+	seen := make(map[string]bool)
+	out := make([]T, 0)
+
+	for i := 0; ; i++ {
+		added := false
+		for _, list := range lists {
+			if i >= len(list) {
+				continue
+			}
+			added = true
+
+			k := key(list[i])
+			if k != "" && seen[k] {
+				continue
+			}
+			seen[k] = true
+
+			out = append(out, list[i])
+			if len(out) >= n {
+				return out
+			}
+		}
+		if !added {
+			return out
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package antimeridian
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+func TestSplit(t *testing.T) {
+	if got := Split(nil); len(got) != 1 || got[0] != nil {
+		t.Errorf("Split(nil) = %v, want [nil]", got)
+	}
+
+	plain := geojson.NewBBox(-122.5, 37.7, -122.3, 37.8)
+	if got := Split(plain); len(got) != 1 || got[0] != plain {
+		t.Errorf("Split() = %v, want the bbox itself", got)
+	}
+
+	got := Split(geojson.NewBBox(170, -20, -170, -10))
+	if len(got) != 2 {
+		t.Fatalf("expected 2 halves, got %d", len(got))
+	}
+	if *got[0] != (geojson.BBox{West: 170, South: -20, East: 180, North: -10}) || *got[1] != (geojson.BBox{West: -180, South: -20, East: -170, North: -10}) {
+		t.Errorf("unexpected halves %v, %v", got[0], got[1])
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	identity := func(s string) string { return s }
+	limit := func(n int) *int { return &n }
+
+	tests := []struct {
+		name     string
+		lists    [][]string
+		limit    *int
+		expected []string
+	}{
+		{name: "uneven lists", lists: [][]string{{"a", "b", "c"}, {"x"}}, limit: limit(10), expected: []string{"a", "x", "b", "c"}},
+		{name: "duplicates", lists: [][]string{{"a", "b"}, {"b", "a"}}, limit: limit(10), expected: []string{"a", "b"}},
+		{name: "empty keys kept", lists: [][]string{{""}, {""}}, limit: limit(10), expected: []string{"", ""}},
+		{name: "limit", lists: [][]string{{"a", "b"}, {"x", "y"}}, limit: limit(3), expected: []string{"a", "x", "b"}},
+		{name: "default limit", lists: [][]string{{"a", "b", "c"}, {"x", "y", "z"}}, expected: []string{"a", "x", "b", "y"}},
+		{name: "empty", lists: [][]string{{}, {}}, expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Interleave(tt.lists, identity, tt.limit, 4)
+			if fmt.Sprint(got) != fmt.Sprint(tt.expected) || len(got) != len(tt.expected) {
+				t.Errorf("Interleave() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
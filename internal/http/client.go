@@ -9,42 +9,105 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+
+	"github.com/pettinz/mapbox-go-sdk/internal/redact"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
 )
 
 // Client is an HTTP client wrapper for making API requests.
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	roundTrip  middleware.RoundTrip
 }
 
 // New creates a new HTTP client with the given base URL and HTTP client.
-func New(baseURL string, httpClient *http.Client) *Client {
+// Every request passes through the middleware, the first being the
+// outermost.
+func New(baseURL string, httpClient *http.Client, mw ...middleware.Middleware) *Client {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
-	return &Client{
+	c := &Client{
 		baseURL:    baseURL,
 		httpClient: httpClient,
 	}
+	c.roundTrip = middleware.Chain(c.send, mw...)
+	return c
+}
+
+type endpointKey struct{}
+
+type endpoint struct {
+	name   string
+	params any
+}
+
+// WithEndpoint returns a context naming the API call made with it and
+// carrying its typed request, for the middleware chain.
+func WithEndpoint(ctx context.Context, name string, params any) context.Context {
+	return context.WithValue(ctx, endpointKey{}, endpoint{name: name, params: params})
 }
 
-// Do executes an HTTP request and returns the response.
-func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body any) (*http.Response, error) {
+type tokenKey struct{}
+
+// Do executes an HTTP request and returns the response. The access token is
+// taken out of the query while the request passes through the middleware
+// and restored when it is sent.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body any) (*middleware.Response, error) {
+	req := &middleware.Request{
+		Method: method,
+		Path:   path,
+		Query:  url.Values{},
+		Header: http.Header{},
+		Body:   body,
+	}
+	if e, ok := ctx.Value(endpointKey{}).(endpoint); ok {
+		req.Endpoint = e.name
+		req.Params = e.params
+	}
+	for key, values := range query {
+		req.Query[key] = append([]string(nil), values...)
+	}
+	if token := req.Query.Get("access_token"); token != "" {
+		req.Query.Del("access_token")
+		ctx = context.WithValue(ctx, tokenKey{}, token)
+	}
+
+	// Set headers
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "github.com/pettinz/mapbox-go-sdk-go")
+
+	return c.roundTrip(ctx, req)
+}
+
+// send is the innermost round trip, sending the request over HTTP.
+func (c *Client) send(ctx context.Context, r *middleware.Request) (*middleware.Response, error) {
 	// Build the full URL
-	u, err := url.Parse(c.baseURL + path)
+	u, err := url.Parse(c.baseURL + r.Path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse URL: %w", err)
 	}
 
 	// Add query parameters
-	if query != nil {
+	query := url.Values{}
+	for key, values := range r.Query {
+		query[key] = values
+	}
+	if token, ok := ctx.Value(tokenKey{}).(string); ok {
+		query.Set("access_token", token)
+	}
+	if len(query) > 0 {
 		u.RawQuery = query.Encode()
 	}
 
 	// Create request body
 	var bodyReader io.Reader
-	if body != nil {
-		data, err := json.Marshal(body)
+	if r.Body != nil {
+		data, err := json.Marshal(r.Body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
@@ -52,25 +115,24 @@ func (c *Client) Do(ctx context.Context, method, path string, query url.Values,
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), bodyReader)
+	req, err := http.NewRequestWithContext(ctx, r.Method, u.String(), bodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", redact.Error(err))
 	}
+	req.Header = r.Header.Clone()
 
-	// Set headers
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "github.com/pettinz/mapbox-go-sdk-go")
-
-	// Execute request
+	// Execute request. Transport errors include the URL, and so the token.
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, fmt.Errorf("failed to execute request: %w", redact.Error(err))
 	}
 
-	return resp, nil
+	return &middleware.Response{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Header:     resp.Header,
+		Body:       resp.Body,
+	}, nil
 }
 
 // Get executes a GET request and unmarshals the response into result.
@@ -85,8 +147,19 @@ func (c *Client) Get(ctx context.Context, path string, query url.Values, result
 }
 
 // Post executes a POST request and unmarshals the response into result.
-func (c *Client) Post(ctx context.Context, path string, body any, result any) error {
-	resp, err := c.Do(ctx, http.MethodPost, path, nil, body)
+func (c *Client) Post(ctx context.Context, path string, query url.Values, body any, result any) error {
+	resp, err := c.Do(ctx, http.MethodPost, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return c.handleResponse(resp, result)
+}
+
+// Patch executes a PATCH request and unmarshals the response into result.
+func (c *Client) Patch(ctx context.Context, path string, query url.Values, body any, result any) error {
+	resp, err := c.Do(ctx, http.MethodPatch, path, query, body)
 	if err != nil {
 		return err
 	}
@@ -95,12 +168,57 @@ func (c *Client) Post(ctx context.Context, path string, body any, result any) er
 	return c.handleResponse(resp, result)
 }
 
+// Delete executes a DELETE request. Any response body is discarded.
+func (c *Client) Delete(ctx context.Context, path string, query url.Values) error {
+	resp, err := c.Do(ctx, http.MethodDelete, path, query, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return c.handleResponse(resp, nil)
+}
+
+// GetRaw executes a GET request and returns the raw response body and its content type.
+// It is used for binary payloads such as map tiles.
+func (c *Client) GetRaw(ctx context.Context, path string, query url.Values) ([]byte, string, error) {
+	resp, err := c.Do(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := c.readResponse(resp)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return body, resp.Header.Get("Content-Type"), nil
+}
+
 // handleResponse processes the HTTP response and handles errors.
-func (c *Client) handleResponse(resp *http.Response, result any) error {
+func (c *Client) handleResponse(resp *middleware.Response, result any) error {
+	body, err := c.readResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	// Unmarshal successful response
+	if result != nil {
+		if err := json.Unmarshal(body, result); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// readResponse reads the HTTP response body, converting non-2xx responses into errors.
+func (c *Client) readResponse(resp *middleware.Response) ([]byte, error) {
 	// Read response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	// Check for HTTP errors
@@ -111,7 +229,7 @@ func (c *Client) handleResponse(resp *http.Response, result any) error {
 			Code    string `json:"code"`
 		}
 		if err := json.Unmarshal(body, &errResp); err == nil && errResp.Message != "" {
-			return &ErrorResponse{
+			return nil, &ErrorResponse{
 				StatusCode: resp.StatusCode,
 				Message:    errResp.Message,
 				Code:       errResp.Code,
@@ -119,20 +237,13 @@ func (c *Client) handleResponse(resp *http.Response, result any) error {
 		}
 
 		// Fallback to status text
-		return &ErrorResponse{
+		return nil, &ErrorResponse{
 			StatusCode: resp.StatusCode,
 			Message:    resp.Status,
 		}
 	}
 
-	// Unmarshal successful response
-	if result != nil {
-		if err := json.Unmarshal(body, result); err != nil {
-			return fmt.Errorf("failed to unmarshal response: %w", err)
-		}
-	}
-
-	return nil
+	return body, nil
 }
 
 // ErrorResponse represents an error response from the API.
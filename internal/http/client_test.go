@@ -2,10 +2,15 @@ package http
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/middleware"
 )
 
 func TestClient_Get(t *testing.T) {
@@ -106,7 +111,7 @@ func TestClient_Post(t *testing.T) {
 			client := New(server.URL, nil)
 			var result map[string]any
 
-			err := client.Post(context.Background(), "/test", tt.requestBody, &result)
+			err := client.Post(context.Background(), "/test", nil, tt.requestBody, &result)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Post() error = %v, wantErr %v", err, tt.wantErr)
@@ -183,3 +188,233 @@ func TestErrorResponse_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Post_Query(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") != "test-token" {
+			t.Errorf("expected access_token query param, got %q", r.URL.Query().Get("access_token"))
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"result": "ok"}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, nil)
+	query := url.Values{"access_token": []string{"test-token"}}
+
+	if err := client.Post(context.Background(), "/test", query, map[string]any{}, nil); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+}
+
+func TestClient_Patch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH request, got %s", r.Method)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("expected Content-Type application/json, got %s", r.Header.Get("Content-Type"))
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"note": "updated"}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, nil)
+	var result map[string]any
+
+	err := client.Patch(context.Background(), "/test", nil, map[string]any{"note": "updated"}, &result)
+	if err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+
+	if result["note"] != "updated" {
+		t.Errorf("Patch() result = %v, want note=updated", result)
+	}
+}
+
+func TestClient_Delete(t *testing.T) {
+	tests := []struct {
+		name         string
+		serverStatus int
+		wantErr      bool
+	}{
+		{
+			name:         "no content",
+			serverStatus: http.StatusNoContent,
+			wantErr:      false,
+		},
+		{
+			name:         "not found",
+			serverStatus: http.StatusNotFound,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete {
+					t.Errorf("expected DELETE request, got %s", r.Method)
+				}
+				w.WriteHeader(tt.serverStatus)
+			}))
+			defer server.Close()
+
+			client := New(server.URL, nil)
+
+			err := client.Delete(context.Background(), "/test", nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_GetRaw(t *testing.T) {
+	tests := []struct {
+		name         string
+		serverStatus int
+		serverBody   []byte
+		contentType  string
+		wantErr      bool
+	}{
+		{
+			name:         "binary body",
+			serverStatus: http.StatusOK,
+			serverBody:   []byte{0x1a, 0x00, 0xff},
+			contentType:  "application/vnd.mapbox-vector-tile",
+			wantErr:      false,
+		},
+		{
+			name:         "error response",
+			serverStatus: http.StatusNotFound,
+			serverBody:   []byte(`{"message": "Tile not found"}`),
+			contentType:  "application/json",
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.serverStatus)
+				w.Write(tt.serverBody)
+			}))
+			defer server.Close()
+
+			client := New(server.URL, nil)
+
+			body, contentType, err := client.GetRaw(context.Background(), "/tile", nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRaw() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if string(body) != string(tt.serverBody) {
+				t.Errorf("GetRaw() body = %v, want %v", body, tt.serverBody)
+			}
+			if contentType != tt.contentType {
+				t.Errorf("GetRaw() content type = %q, want %q", contentType, tt.contentType)
+			}
+		})
+	}
+}
+
+func TestClient_Middleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") != "pk.test" {
+			t.Errorf("expected access token in the sent request, got %q", r.URL.RawQuery)
+		}
+		if r.Header.Get("X-Custom") != "value" {
+			t.Errorf("expected header set by middleware, got %q", r.Header.Get("X-Custom"))
+		}
+		w.Header().Set("X-Rate-Limit-Remaining", "99")
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	params := &struct{ Query string }{Query: "Rome"}
+	var seen *middleware.Request
+	var status int
+	var remaining string
+	mw := func(next middleware.RoundTrip) middleware.RoundTrip {
+		return func(ctx context.Context, req *middleware.Request) (*middleware.Response, error) {
+			seen = req
+			req.Header.Set("X-Custom", "value")
+			resp, err := next(ctx, req)
+			if err == nil {
+				status = resp.StatusCode
+				remaining = resp.Header.Get("X-Rate-Limit-Remaining")
+			}
+			return resp, err
+		}
+	}
+
+	client := New(server.URL, nil, mw)
+	ctx := WithEndpoint(context.Background(), middleware.GeocodingForward, params)
+	query := url.Values{"q": {"Rome"}, "access_token": {"pk.test"}}
+
+	var result map[string]any
+	if err := client.Get(ctx, "/search/geocode/v6/forward", query, &result); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if seen.Endpoint != middleware.GeocodingForward || seen.Params != params {
+		t.Errorf("middleware saw endpoint %q with params %v", seen.Endpoint, seen.Params)
+	}
+	if seen.Query.Has("access_token") || seen.Query.Get("q") != "Rome" {
+		t.Errorf("middleware saw query %v, want q without the access token", seen.Query)
+	}
+	if !query.Has("access_token") {
+		t.Error("the caller's query was modified")
+	}
+	if status != http.StatusOK || remaining != "99" {
+		t.Errorf("middleware saw status %d and remaining %q", status, remaining)
+	}
+	if result["status"] != "ok" {
+		t.Errorf("unexpected result %v", result)
+	}
+}
+
+func TestClient_MiddlewareShortCircuit(t *testing.T) {
+	client := New("http://127.0.0.1:0", nil, func(next middleware.RoundTrip) middleware.RoundTrip {
+		return func(ctx context.Context, req *middleware.Request) (*middleware.Response, error) {
+			return &middleware.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Status:     "503 Service Unavailable",
+				Body:       io.NopCloser(strings.NewReader(`{"message": "try later"}`)),
+			}, nil
+		}
+	})
+
+	err := client.Get(context.Background(), "/test", nil, nil)
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.StatusCode != http.StatusServiceUnavailable || errResp.Message != "try later" {
+		t.Errorf("Get() error = %v, want the response returned by the middleware", err)
+	}
+}
+
+func TestClient_TransportErrorRedacted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := New(server.URL, nil)
+	query := url.Values{"access_token": {"pk.secret"}, "session_token": {"session-secret"}}
+	err := client.Get(context.Background(), "/test", query, nil)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if strings.Contains(err.Error(), "pk.secret") || strings.Contains(err.Error(), "session-secret") {
+		t.Errorf("error contains a token: %v", err)
+	}
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) || strings.Contains(urlErr.URL, "secret") {
+		t.Errorf("expected a redacted *url.Error, got %v", err)
+	}
+}
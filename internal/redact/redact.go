@@ -0,0 +1,84 @@
+// Package redact removes access and session tokens from everything the SDK
+// reports: queries, URLs, error messages and errors.
+package redact
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+)
+
+// Placeholder replaces redacted values.
+const Placeholder = "REDACTED"
+
+// Params are the secret query parameters.
+var Params = []string{"access_token", "session_token"}
+
+// secretParam matches the value of a secret query parameter, raw or URL
+// encoded.
+var secretParam = regexp.MustCompile(`((?:access_token|session_token)(?:=|%3D|%3d))[^&\s"'#]+`)
+
+// String redacts the secret parameter values in s, e.g. in a URL or an
+// error message containing one.
+func String(s string) string {
+	return secretParam.ReplaceAllString(s, "${1}"+Placeholder)
+}
+
+// Query returns a copy of query with the secret parameter values redacted.
+func Query(query url.Values) url.Values {
+	redacted := make(url.Values, len(query))
+	for key, values := range query {
+		redacted[key] = append([]string(nil), values...)
+	}
+	for _, key := range Params {
+		if values, ok := redacted[key]; ok {
+			for i := range values {
+				values[i] = Placeholder
+			}
+		}
+	}
+	return redacted
+}
+
+// URL returns u with the secret parameter values redacted.
+func URL(u *url.URL) string {
+	redacted := *u
+	redacted.RawQuery = Query(u.Query()).Encode()
+	return redacted.String()
+}
+
+// Error returns err with the secrets redacted from its message. A
+// *url.Error, as returned by http.Client.Do, is copied with its URL
+// redacted so that errors.As and errors.Is still see it and its cause.
+// Other errors are wrapped only when their message contains a secret.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr == err {
+		return &url.Error{Op: urlErr.Op, URL: String(urlErr.URL), Err: Error(urlErr.Err)}
+	}
+
+	message := err.Error()
+	if redacted := String(message); redacted != message {
+		return &redactedError{message: redacted, err: err}
+	}
+	return err
+}
+
+// redactedError is an error whose message has been redacted.
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+// Unwrap returns the original error, whose message is not redacted.
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,103 @@
+package redact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "url",
+			in:   `Get "https://api.mapbox.com/search/geocode/v6/forward?access_token=pk.abc&q=Rome": EOF`,
+			want: `Get "https://api.mapbox.com/search/geocode/v6/forward?access_token=REDACTED&q=Rome": EOF`,
+		},
+		{
+			name: "both tokens",
+			in:   "q=coffee&session_token=4b7c-11&access_token=sk.xyz",
+			want: "q=coffee&session_token=REDACTED&access_token=REDACTED",
+		},
+		{
+			name: "encoded",
+			in:   "next=%2Fpath%3Faccess_token%3Dpk.abc",
+			want: "next=%2Fpath%3Faccess_token%3DREDACTED",
+		},
+		{
+			name: "no secrets",
+			in:   "q=Rome&limit=5",
+			want: "q=Rome&limit=5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := String(tt.in); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	query := url.Values{"q": {"Rome"}, "access_token": {"pk.abc"}, "session_token": {"4b7c"}}
+	got := Query(query)
+
+	if got.Get("access_token") != Placeholder || got.Get("session_token") != Placeholder || got.Get("q") != "Rome" {
+		t.Errorf("Query() = %v", got)
+	}
+	if query.Get("access_token") != "pk.abc" {
+		t.Error("Query() modified its argument")
+	}
+}
+
+func TestURL(t *testing.T) {
+	u, _ := url.Parse("https://api.mapbox.com/tokens/v2?access_token=sk.abc")
+	if got, want := URL(u), "https://api.mapbox.com/tokens/v2?access_token=REDACTED"; got != want {
+		t.Errorf("URL() = %q, want %q", got, want)
+	}
+}
+
+func TestError(t *testing.T) {
+	urlErr := &url.Error{Op: "Get", URL: "https://api.mapbox.com/x?access_token=pk.abc", Err: context.DeadlineExceeded}
+
+	t.Run("url error", func(t *testing.T) {
+		err := Error(urlErr)
+		if got, want := err.Error(), `Get "https://api.mapbox.com/x?access_token=REDACTED": context deadline exceeded`; got != want {
+			t.Errorf("Error() = %q, want %q", got, want)
+		}
+		var target *url.Error
+		if !errors.As(err, &target) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Error("expected the url.Error and its cause in the chain")
+		}
+		if urlErr.URL != "https://api.mapbox.com/x?access_token=pk.abc" {
+			t.Error("Error() modified its argument")
+		}
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		wrapped := fmt.Errorf("forward failed: %w", urlErr)
+		err := Error(wrapped)
+		if got := err.Error(); got != `forward failed: Get "https://api.mapbox.com/x?access_token=REDACTED": context deadline exceeded` {
+			t.Errorf("Error() = %q", got)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Error("expected the cause in the chain")
+		}
+	})
+
+	t.Run("no secrets", func(t *testing.T) {
+		plain := errors.New("boom")
+		if Error(plain) != plain {
+			t.Error("expected the error unchanged")
+		}
+		if Error(nil) != nil {
+			t.Error("expected nil")
+		}
+	})
+}
@@ -399,3 +399,75 @@ const SearchBoxReverseResponse = `{
   ],
   "attribution": "© 2024 Mapbox"
 }`
+
+// TokensListResponse is a sample list tokens response.
+const TokensListResponse = `[
+  {
+    "client": "api",
+    "note": "Default public token",
+    "usage": "pk",
+    "id": "cijucimbe000brbkt48d0dhcx",
+    "default": true,
+    "scopes": ["styles:tiles", "styles:read", "fonts:read", "datasets:read"],
+    "created": "2016-01-25T19:07:07.621Z",
+    "modified": "2016-01-25T19:07:07.621Z",
+    "token": "pk.eyJ1IjoidGVzdHVzZXIiLCJhIjoiY2lqdWNpbWJlMDAwYnJia3Q0OGQwZGhjeCJ9.sig"
+  },
+  {
+    "client": "api",
+    "note": "Store locator",
+    "usage": "pk",
+    "id": "cijucimbe000brbkt48d0dhcy",
+    "default": false,
+    "scopes": ["styles:tiles", "styles:read"],
+    "allowedUrls": ["https://example.com"],
+    "created": "2023-03-02T10:00:00.000Z",
+    "modified": "2023-03-05T12:30:00.000Z",
+    "token": "pk.eyJ1IjoidGVzdHVzZXIiLCJhIjoiY2lqdWNpbWJlMDAwYnJia3Q0OGQwZGhjeSJ9.sig"
+  }
+]`
+
+// TokensTokenResponse is a sample create or update token response.
+const TokensTokenResponse = `{
+  "client": "api",
+  "note": "Store locator",
+  "usage": "pk",
+  "id": "cijucimbe000brbkt48d0dhcy",
+  "default": false,
+  "scopes": ["styles:tiles", "styles:read"],
+  "allowedUrls": ["https://example.com"],
+  "created": "2023-03-02T10:00:00.000Z",
+  "modified": "2023-03-02T10:00:00.000Z",
+  "token": "pk.eyJ1IjoidGVzdHVzZXIiLCJhIjoiY2lqdWNpbWJlMDAwYnJia3Q0OGQwZGhjeSJ9.sig"
+}`
+
+// TokensTemporaryResponse is a sample create temporary token response.
+const TokensTemporaryResponse = `{
+  "token": "tk.eyJ1IjoidGVzdHVzZXIiLCJleHAiOjE3MDAwMDAwMDB9.sig"
+}`
+
+// TokensRetrieveResponse is a sample retrieve token response.
+const TokensRetrieveResponse = `{
+  "code": "TokenValid",
+  "token": {
+    "usage": "pk",
+    "user": "testuser",
+    "authorization": "cijucimbe000brbkt48d0dhcx",
+    "client": "api",
+    "scopes": ["styles:tiles", "styles:read", "fonts:read"],
+    "created": "2016-01-25T19:07:07.621Z"
+  }
+}`
+
+// TokensScopesResponse is a sample list scopes response.
+const TokensScopesResponse = `[
+  {
+    "id": "styles:read",
+    "description": "Read styles.",
+    "public": true
+  },
+  {
+    "id": "tokens:write",
+    "description": "Create, update, and revoke tokens."
+  }
+]`
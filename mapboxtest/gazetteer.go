@@ -0,0 +1,451 @@
+package mapboxtest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/pettinz/mapbox-go-sdk/geo"
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// Place is an entry of the gazetteer the server searches.
+type Place struct {
+	// MapboxID identifies the place. Gazetteer.Add assigns one when empty.
+	MapboxID string
+
+	// FeatureType is the Mapbox feature type, e.g. "poi", "address" or
+	// "place". When empty it is "poi" for places with categories, "address"
+	// for places with an address number and "place" otherwise.
+	FeatureType string
+
+	// Name is the display name. For addresses it defaults to the street
+	// address.
+	Name string
+
+	AddressNumber string
+	Street        string
+	Postcode      string
+
+	// City is the place (city, town or village) the entry belongs to.
+	City string
+
+	Region string
+
+	// RegionCode is the region code without the country prefix, e.g. "CA".
+	RegionCode string
+
+	Country string
+
+	// CountryCode is the ISO 3166-1 alpha-2 country code.
+	CountryCode string
+
+	Location geojson.LngLat
+
+	// Categories are the canonical POI category IDs, e.g. "coffee".
+	Categories []string
+
+	Brand string
+}
+
+// address returns the street address.
+func (p *Place) address() string {
+	return strings.TrimSpace(p.AddressNumber + " " + p.Street)
+}
+
+// placeFormatted returns the administrative part of the address, e.g.
+// "San Francisco, California 94103, United States".
+func (p *Place) placeFormatted() string {
+	region := strings.TrimSpace(p.Region + " " + p.Postcode)
+	return joinNonEmpty(p.City, region, p.Country)
+}
+
+// fullAddress returns the street address followed by the administrative part.
+func (p *Place) fullAddress() string {
+	return joinNonEmpty(p.address(), p.placeFormatted())
+}
+
+// placeName returns the full name used by the geocoding API.
+func (p *Place) placeName() string {
+	address := p.address()
+	if address == p.Name {
+		address = ""
+	}
+	return joinNonEmpty(p.Name, address, p.placeFormatted())
+}
+
+// words returns the searchable words of the place.
+func (p *Place) words() []string {
+	var out []string
+	for _, s := range []string{p.Name, p.address(), p.City, p.Region, p.RegionCode, p.Postcode, p.Country, p.CountryCode, p.Brand} {
+		out = append(out, words(s)...)
+	}
+	for _, c := range p.Categories {
+		out = append(out, words(c)...)
+	}
+	return out
+}
+
+// Gazetteer is an in-memory set of places. It is safe for concurrent use.
+type Gazetteer struct {
+	mu     sync.RWMutex
+	places []Place
+}
+
+// NewGazetteer creates a gazetteer holding the given places.
+func NewGazetteer(places ...Place) *Gazetteer {
+	g := &Gazetteer{}
+	g.Add(places...)
+	return g
+}
+
+// Add adds places to the gazetteer, filling in missing IDs, feature types
+// and names.
+func (g *Gazetteer) Add(places ...Place) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, p := range places {
+		if p.MapboxID == "" {
+			p.MapboxID = fmt.Sprintf("mapboxtest.%d", len(g.places)+1)
+		}
+		if p.FeatureType == "" {
+			switch {
+			case len(p.Categories) > 0:
+				p.FeatureType = "poi"
+			case p.AddressNumber != "":
+				p.FeatureType = "address"
+			default:
+				p.FeatureType = "place"
+			}
+		}
+		if p.Name == "" {
+			p.Name = p.address()
+		}
+		p.Categories = slices.Clone(p.Categories)
+		g.places = append(g.places, p)
+	}
+}
+
+// Places returns a copy of the places in the gazetteer.
+func (g *Gazetteer) Places() []Place {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return slices.Clone(g.places)
+}
+
+// Lookup returns the place with the given Mapbox ID.
+func (g *Gazetteer) Lookup(mapboxID string) (Place, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for _, p := range g.places {
+		if p.MapboxID == mapboxID {
+			return p, true
+		}
+	}
+	return Place{}, false
+}
+
+// Categories returns the POI category IDs used by the places, sorted.
+func (g *Gazetteer) Categories() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var out []string
+	for _, p := range g.places {
+		for _, c := range p.Categories {
+			if !slices.Contains(out, c) {
+				out = append(out, c)
+			}
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// DefaultGazetteer returns a gazetteer seeded with a few well-known places,
+// addresses and points of interest in the United States and Europe.
+func DefaultGazetteer() *Gazetteer {
+	us := func(p Place) Place {
+		p.Country, p.CountryCode = "United States", "US"
+		return p
+	}
+	return NewGazetteer(
+		us(Place{Name: "Washington", City: "Washington", Region: "District of Columbia", RegionCode: "DC", Location: geojson.LngLat{Longitude: -77.036871, Latitude: 38.907192}}),
+		us(Place{AddressNumber: "1600", Street: "Pennsylvania Avenue NW", Postcode: "20500", City: "Washington", Region: "District of Columbia", RegionCode: "DC", Location: geojson.LngLat{Longitude: -77.036543, Latitude: 38.897676}}),
+		us(Place{Name: "San Francisco", City: "San Francisco", Region: "California", RegionCode: "CA", Location: geojson.LngLat{Longitude: -122.419415, Latitude: 37.774929}}),
+		us(Place{Name: "Blue Bottle Coffee", AddressNumber: "66", Street: "Mint St", Postcode: "94103", City: "San Francisco", Region: "California", RegionCode: "CA", Location: geojson.LngLat{Longitude: -122.406588, Latitude: 37.782483}, Categories: []string{"coffee", "cafe"}, Brand: "Blue Bottle Coffee"}),
+		us(Place{Name: "Philz Coffee", AddressNumber: "201", Street: "Berry St", Postcode: "94158", City: "San Francisco", Region: "California", RegionCode: "CA", Location: geojson.LngLat{Longitude: -122.392171, Latitude: 37.775909}, Categories: []string{"coffee", "cafe"}, Brand: "Philz Coffee"}),
+		us(Place{Name: "Ferry Building Marketplace", AddressNumber: "1", Street: "Ferry Building", Postcode: "94111", City: "San Francisco", Region: "California", RegionCode: "CA", Location: geojson.LngLat{Longitude: -122.393439, Latitude: 37.795528}, Categories: []string{"shopping", "food_and_drink"}}),
+		us(Place{Name: "New York", City: "New York", Region: "New York", RegionCode: "NY", Location: geojson.LngLat{Longitude: -74.005974, Latitude: 40.712776}}),
+		us(Place{Name: "Empire State Building", AddressNumber: "350", Street: "5th Avenue", Postcode: "10118", City: "New York", Region: "New York", RegionCode: "NY", Location: geojson.LngLat{Longitude: -73.985656, Latitude: 40.748433}, Categories: []string{"tourist_attraction", "landmark"}}),
+		us(Place{Name: "Los Angeles", City: "Los Angeles", Region: "California", RegionCode: "CA", Location: geojson.LngLat{Longitude: -118.243683, Latitude: 34.052235}}),
+		Place{Name: "Rome", City: "Rome", Region: "Lazio", RegionCode: "62", Country: "Italy", CountryCode: "IT", Location: geojson.LngLat{Longitude: 12.496366, Latitude: 41.902782}},
+		Place{Name: "Colosseum", Street: "Piazza del Colosseo", Postcode: "00184", City: "Rome", Region: "Lazio", RegionCode: "62", Country: "Italy", CountryCode: "IT", Location: geojson.LngLat{Longitude: 12.492231, Latitude: 41.890210}, Categories: []string{"historic_site", "tourist_attraction"}},
+		Place{Name: "Paris", City: "Paris", Region: "Île-de-France", RegionCode: "IDF", Country: "France", CountryCode: "FR", Location: geojson.LngLat{Longitude: 2.352222, Latitude: 48.856613}},
+		Place{Name: "Eiffel Tower", AddressNumber: "5", Street: "Avenue Anatole France", Postcode: "75007", City: "Paris", Region: "Île-de-France", RegionCode: "IDF", Country: "France", CountryCode: "FR", Location: geojson.LngLat{Longitude: 2.294481, Latitude: 48.858370}, Categories: []string{"tourist_attraction", "landmark"}},
+		Place{Name: "Berlin", City: "Berlin", Region: "Berlin", RegionCode: "BE", Country: "Germany", CountryCode: "DE", Location: geojson.LngLat{Longitude: 13.404954, Latitude: 52.520008}},
+	)
+}
+
+// randomNames, randomStreets and randomCategories are the vocabularies of
+// RandomPlaces.
+var (
+	randomNames      = []string{"Golden", "Corner", "Harbor", "Maple", "Silver", "Union", "Garden", "Summit", "River", "Market"}
+	randomStreets    = []string{"Main St", "Oak Ave", "Elm St", "Park Rd", "Lake Dr", "Hill St", "Church Rd", "Mill Ln"}
+	randomCategories = []string{"cafe", "restaurant", "bar", "hotel", "pharmacy", "supermarket", "bank", "gas_station"}
+)
+
+// RandomPlaces generates n synthetic points of interest inside area. The
+// same seed always yields the same places. City, region and country fields
+// are copied from template.
+func RandomPlaces(seed uint64, n int, area geojson.BBox, template Place) []Place {
+	rng := rand.New(rand.NewPCG(seed, seed))
+
+	width := area.East - area.West
+	if area.CrossesAntimeridian() {
+		width += 360
+	}
+
+	places := make([]Place, 0, n)
+	for range n {
+		category := randomCategories[rng.IntN(len(randomCategories))]
+		lng := area.West + rng.Float64()*width
+		if lng > 180 {
+			lng -= 360
+		}
+
+		p := template
+		p.MapboxID = ""
+		p.FeatureType = ""
+		p.Name = randomNames[rng.IntN(len(randomNames))] + " " + categoryName(category)
+		p.AddressNumber = fmt.Sprint(1 + rng.IntN(999))
+		p.Street = randomStreets[rng.IntN(len(randomStreets))]
+		p.Categories = []string{category}
+		p.Location = geojson.LngLat{Longitude: lng, Latitude: area.South + rng.Float64()*(area.North-area.South)}
+		places = append(places, p)
+	}
+	return places
+}
+
+// query holds the filters and ranking inputs shared by the search endpoints.
+type query struct {
+	text         string
+	autocomplete bool
+	countries    []string
+	types        []string
+	categories   []string
+	bbox         *geojson.BBox
+	proximity    *geojson.LngLat
+	limit        int
+}
+
+// match is a place found by a search, with its distance in meters from the
+// proximity point or the reverse query point, when there is one.
+type match struct {
+	place    Place
+	score    float64
+	distance *float64
+}
+
+// filter reports whether p passes the country, type, category and bbox
+// filters of q.
+func (q *query) filter(p *Place) bool {
+	if len(q.countries) > 0 && !slices.ContainsFunc(q.countries, func(c string) bool { return strings.EqualFold(c, p.CountryCode) }) {
+		return false
+	}
+	if len(q.types) > 0 && !slices.Contains(q.types, p.FeatureType) {
+		return false
+	}
+	if len(q.categories) > 0 && !slices.ContainsFunc(q.categories, func(c string) bool { return slices.Contains(p.Categories, c) }) {
+		return false
+	}
+	return q.bbox == nil || q.bbox.Contains(p.Location)
+}
+
+// search returns the places matching every word of the query text. With
+// autocomplete the last word may be a prefix. Results are ranked by how
+// much of the name the query covers, then by distance from the proximity
+// point.
+func (g *Gazetteer) search(q query) []match {
+	terms := words(q.text)
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var matches []match
+	for _, p := range g.places {
+		if !q.filter(&p) {
+			continue
+		}
+
+		placeWords := p.words()
+		matched := len(terms) > 0
+		for i, term := range terms {
+			prefix := q.autocomplete && i == len(terms)-1
+			if !slices.ContainsFunc(placeWords, func(w string) bool { return w == term || prefix && strings.HasPrefix(w, term) }) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		var score float64
+		nameWords := words(p.Name)
+		for _, w := range nameWords {
+			if slices.Contains(terms, w) {
+				score++
+			}
+		}
+		if len(nameWords) > 0 {
+			score /= float64(len(nameWords))
+		}
+		matches = append(matches, newMatch(p, score, q.proximity))
+	}
+
+	rank(matches)
+	return truncate(matches, q.limit)
+}
+
+// structured returns the places matching every given address component.
+func (g *Gazetteer) structured(components map[string]string, q query) []match {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var matches []match
+	for _, p := range g.places {
+		if !q.filter(&p) {
+			continue
+		}
+
+		ok := true
+		for key, value := range components {
+			switch key {
+			case "address_number":
+				ok = strings.EqualFold(value, p.AddressNumber)
+			case "street":
+				streetWords := words(p.Street)
+				ok = len(streetWords) > 0
+				for _, w := range words(value) {
+					ok = ok && slices.Contains(streetWords, w)
+				}
+			case "place":
+				ok = strings.EqualFold(value, p.City)
+			case "region":
+				ok = strings.EqualFold(value, p.Region) || strings.EqualFold(value, p.RegionCode)
+			case "postcode":
+				ok = normalizePostcode(value) == normalizePostcode(p.Postcode)
+			case "country":
+				ok = strings.EqualFold(value, p.CountryCode) || strings.EqualFold(value, p.Country)
+			}
+			if !ok {
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		// Prefer the most specific feature: an address over its city.
+		score := 0.0
+		if p.AddressNumber != "" {
+			score++
+		}
+		matches = append(matches, newMatch(p, score, q.proximity))
+	}
+
+	rank(matches)
+	return truncate(matches, q.limit)
+}
+
+// nearest returns the places closest to from. A nil from keeps gazetteer
+// order.
+func (g *Gazetteer) nearest(from *geojson.LngLat, q query) []match {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var matches []match
+	for _, p := range g.places {
+		if q.filter(&p) {
+			matches = append(matches, newMatch(p, 0, from))
+		}
+	}
+
+	rank(matches)
+	return truncate(matches, q.limit)
+}
+
+func newMatch(p Place, score float64, from *geojson.LngLat) match {
+	m := match{place: p, score: score}
+	if from != nil {
+		d := geo.Distance(*from, p.Location)
+		m.distance = &d
+	}
+	return m
+}
+
+// rank sorts matches by score, then by distance. The sort is stable so ties
+// keep gazetteer order.
+func rank(matches []match) {
+	slices.SortStableFunc(matches, func(a, b match) int {
+		if a.score != b.score {
+			if a.score > b.score {
+				return -1
+			}
+			return 1
+		}
+		if a.distance != nil && b.distance != nil && *a.distance != *b.distance {
+			if *a.distance < *b.distance {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	})
+}
+
+func truncate(matches []match, limit int) []match {
+	if limit > 0 && len(matches) > limit {
+		return matches[:limit]
+	}
+	return matches
+}
+
+// words splits s into lower-case words of letters and digits.
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func normalizePostcode(s string) string {
+	return strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+}
+
+// categoryName turns a category ID such as "gas_station" into a display
+// name such as "Gas Station".
+func categoryName(id string) string {
+	parts := strings.Split(id, "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func joinNonEmpty(parts ...string) string {
+	var out []string
+	for _, part := range parts {
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+	return strings.Join(out, ", ")
+}
@@ -0,0 +1,98 @@
+package mapboxtest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+func names(matches []match) []string {
+	var out []string
+	for _, m := range matches {
+		out = append(out, m.place.Name)
+	}
+	return out
+}
+
+func TestGazetteer_Search(t *testing.T) {
+	g := DefaultGazetteer()
+	sf := geojson.LngLat{Longitude: -122.3925, Latitude: 37.7760}
+
+	tests := []struct {
+		name string
+		q    query
+		want []string
+	}{
+		{name: "exact words", q: query{text: "empire state building"}, want: []string{"Empire State Building"}},
+		{name: "autocomplete prefix", q: query{text: "eiffel tow", autocomplete: true}, want: []string{"Eiffel Tower"}},
+		{name: "prefix needs autocomplete", q: query{text: "eiffel tow"}, want: nil},
+		{name: "address", q: query{text: "1600 Pennsylvania Avenue NW Washington DC"}, want: []string{"1600 Pennsylvania Avenue NW"}},
+		{name: "name ranks first", q: query{text: "rome"}, want: []string{"Rome", "Colosseum"}},
+		{name: "proximity breaks ties", q: query{text: "coffee", proximity: &sf}, want: []string{"Philz Coffee", "Blue Bottle Coffee"}},
+		{name: "country filter", q: query{text: "tourist attraction", countries: []string{"fr"}}, want: []string{"Eiffel Tower"}},
+		{name: "types filter", q: query{text: "new york", types: []string{"place"}}, want: []string{"New York"}},
+		{name: "bbox filter", q: query{text: "california", bbox: geojson.NewBBox(-119, 33, -117, 35)}, want: []string{"Los Angeles"}},
+		{name: "limit", q: query{text: "san francisco", limit: 2}, want: []string{"San Francisco", "Blue Bottle Coffee"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := names(g.search(tt.q)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("search() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGazetteer_Structured(t *testing.T) {
+	g := DefaultGazetteer()
+
+	got := names(g.structured(map[string]string{"address_number": "1600", "street": "pennsylvania avenue", "region": "DC"}, query{}))
+	if !reflect.DeepEqual(got, []string{"1600 Pennsylvania Avenue NW"}) {
+		t.Errorf("structured() = %v", got)
+	}
+
+	got = names(g.structured(map[string]string{"place": "paris", "country": "France"}, query{}))
+	if !reflect.DeepEqual(got, []string{"Eiffel Tower", "Paris"}) {
+		t.Errorf("expected address before city, got %v", got)
+	}
+}
+
+func TestGazetteer_Add(t *testing.T) {
+	g := NewGazetteer(Place{AddressNumber: "10", Street: "Downing Street"}, Place{MapboxID: "custom", Name: "Cafe", Categories: []string{"cafe"}})
+
+	places := g.Places()
+	if places[0].MapboxID != "mapboxtest.1" || places[0].FeatureType != "address" || places[0].Name != "10 Downing Street" {
+		t.Errorf("unexpected defaults %+v", places[0])
+	}
+	if p, ok := g.Lookup("custom"); !ok || p.FeatureType != "poi" {
+		t.Errorf("Lookup() = %+v, %v", p, ok)
+	}
+	if !reflect.DeepEqual(g.Categories(), []string{"cafe"}) {
+		t.Errorf("Categories() = %v", g.Categories())
+	}
+}
+
+func TestRandomPlaces(t *testing.T) {
+	area := geojson.BBox{West: 170, South: -10, East: -170, North: 10}
+	template := Place{City: "Suva", Country: "Fiji", CountryCode: "FJ"}
+
+	a := RandomPlaces(42, 20, area, template)
+	b := RandomPlaces(42, 20, area, template)
+	if !reflect.DeepEqual(a, b) {
+		t.Error("expected the same places for the same seed")
+	}
+	if reflect.DeepEqual(a, RandomPlaces(7, 20, area, template)) {
+		t.Error("expected different places for another seed")
+	}
+
+	for _, p := range a {
+		if !area.Contains(p.Location) {
+			t.Errorf("place %v outside area", p.Location)
+		}
+		if p.CountryCode != "FJ" || len(p.Categories) != 1 || p.Name == "" {
+			t.Errorf("unexpected place %+v", p)
+		}
+	}
+}
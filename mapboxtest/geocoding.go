@@ -0,0 +1,238 @@
+package mapboxtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// structuredParams are the address components of a structured forward query.
+var structuredParams = []string{"address_number", "street", "block", "place", "region", "postcode", "country"}
+
+func (s *Server) geocodingForward(r *http.Request) (any, error) {
+	params := r.URL.Query()
+	q, err := parseQuery(params, 5, 10)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Has("q") {
+		q.text = params.Get("q")
+		if strings.TrimSpace(q.text) == "" {
+			return nil, invalidParam("q must not be empty")
+		}
+		return geocodingResponse(s.gazetteer.search(q)), nil
+	}
+
+	components := map[string]string{}
+	for _, key := range structuredParams {
+		if value := params.Get(key); value != "" {
+			components[key] = value
+		}
+	}
+	if len(components) == 0 {
+		return nil, invalidParam("q or at least one address component is required")
+	}
+	// In a structured query country names the country to match, not a
+	// filter list.
+	q.countries = nil
+	return geocodingResponse(s.gazetteer.structured(components, q)), nil
+}
+
+func (s *Server) geocodingReverse(r *http.Request) (any, error) {
+	params := r.URL.Query()
+	q, err := parseQuery(params, 1, 5)
+	if err != nil {
+		return nil, err
+	}
+
+	point, err := parseLngLat(params.Get("longitude") + "," + params.Get("latitude"))
+	if err != nil {
+		return nil, invalidParam("longitude and latitude are required: %v", err)
+	}
+	return geocodingResponse(s.gazetteer.nearest(&point, q)), nil
+}
+
+func (s *Server) geocodingBatch(r *http.Request) (any, error) {
+	var body struct {
+		Queries []geocoding.BatchQuery `json:"queries"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, &apiError{status: http.StatusBadRequest, message: "Invalid request body"}
+	}
+	if len(body.Queries) == 0 || len(body.Queries) > 1000 {
+		return nil, invalidParam("batch must contain between 1 and 1000 queries")
+	}
+
+	resp := geocoding.BatchResponse{Results: make([]geocoding.BatchResult, len(body.Queries))}
+	for i, bq := range body.Queries {
+		result := &resp.Results[i]
+		result.ID = bq.ID
+
+		q := query{countries: bq.Country, types: bq.Types, autocomplete: true, limit: 5}
+		if bq.Limit != nil {
+			q.limit = *bq.Limit
+		}
+
+		switch {
+		case bq.Query != "":
+			q.text = bq.Query
+			result.Response = geocodingResponse(s.gazetteer.search(q))
+		case bq.Longitude != nil && bq.Latitude != nil:
+			point := geojson.LngLat{Longitude: *bq.Longitude, Latitude: *bq.Latitude}
+			if err := point.Validate(); err != nil {
+				result.Error = &geocoding.BatchError{Message: err.Error(), Code: "InvalidInput"}
+				continue
+			}
+			if bq.Limit == nil {
+				q.limit = 1
+			}
+			result.Response = geocodingResponse(s.gazetteer.nearest(&point, q))
+		default:
+			result.Error = &geocoding.BatchError{Message: "q or longitude and latitude are required", Code: "InvalidInput"}
+		}
+	}
+	return resp, nil
+}
+
+// geocodingResponse converts matches to a geocoding API response.
+func geocodingResponse(matches []match) *geocoding.Response {
+	resp := &geocoding.Response{Type: "FeatureCollection", Features: []geocoding.Feature{}, Attribution: attribution}
+	for _, m := range matches {
+		resp.Features = append(resp.Features, geocodingFeature(&m.place))
+	}
+	return resp
+}
+
+func geocodingFeature(p *Place) geocoding.Feature {
+	return geocoding.Feature{
+		Type: "Feature",
+		ID:   p.MapboxID,
+		Geometry: geocoding.Geometry{
+			Type:        geojson.TypePoint,
+			Coordinates: p.Location,
+		},
+		Properties: geocoding.Properties{
+			MapboxID:           p.MapboxID,
+			FeatureType:        p.FeatureType,
+			Name:               p.Name,
+			NamePreferred:      p.Name,
+			PlaceName:          p.placeName(),
+			PlaceNamePreferred: p.placeName(),
+			Context:            geocodingContext(p),
+			Coordinates:        geocoding.Coordinates{Longitude: p.Location.Longitude, Latitude: p.Location.Latitude},
+			AddressNumber:      p.AddressNumber,
+			Street:             p.Street,
+			Postcode:           p.Postcode,
+		},
+	}
+}
+
+func geocodingContext(p *Place) *geocoding.Context {
+	c := &geocoding.Context{}
+	if p.CountryCode != "" {
+		c.Country = &geocoding.ContextElement{MapboxID: contextID("country", p.CountryCode), Name: p.Country, CountryCode: p.CountryCode}
+	}
+	if p.Region != "" {
+		c.Region = &geocoding.ContextElement{MapboxID: contextID("region", p.CountryCode, p.RegionCode), Name: p.Region, RegionCode: p.RegionCode}
+		if p.RegionCode != "" && p.CountryCode != "" {
+			c.Region.RegionCodeFull = p.CountryCode + "-" + p.RegionCode
+		}
+	}
+	if p.Postcode != "" {
+		c.Postcode = &geocoding.ContextElement{MapboxID: contextID("postcode", p.CountryCode, p.Postcode), Name: p.Postcode}
+	}
+	if p.City != "" && p.FeatureType != "place" {
+		c.Place = &geocoding.ContextElement{MapboxID: contextID("place", p.CountryCode, p.City), Name: p.City}
+	}
+	if p.Street != "" {
+		c.Street = &geocoding.ContextElement{MapboxID: contextID("street", p.CountryCode, p.City, p.Street), Name: p.Street}
+	}
+	return c
+}
+
+// contextID builds a stable identifier for a context element.
+func contextID(kind string, parts ...string) string {
+	return "mapboxtest." + kind + "." + url.PathEscape(strings.ToLower(strings.Join(parts, ".")))
+}
+
+// parseQuery reads the filters shared by the search endpoints: country,
+// types, poi_category, bbox, proximity, autocomplete and limit.
+func parseQuery(params url.Values, defaultLimit, maxLimit int) (query, error) {
+	q := query{
+		autocomplete: params.Get("autocomplete") != "false",
+		countries:    splitList(params.Get("country")),
+		types:        splitList(params.Get("types")),
+		categories:   splitList(params.Get("poi_category")),
+		limit:        defaultLimit,
+	}
+
+	if v := params.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxLimit {
+			return query{}, invalidParam("limit must be an integer between 1 and %d", maxLimit)
+		}
+		q.limit = limit
+	}
+
+	if v := params.Get("bbox"); v != "" {
+		values, err := parseFloats(v, 4)
+		if err != nil {
+			return query{}, invalidParam("invalid bbox: %v", err)
+		}
+		bbox, err := geojson.BBoxFromSlice(values)
+		if err != nil {
+			return query{}, invalidParam("invalid bbox: %v", err)
+		}
+		q.bbox = bbox
+	}
+
+	if v := params.Get("proximity"); v != "" && v != "ip" {
+		point, err := parseLngLat(v)
+		if err != nil {
+			return query{}, invalidParam("invalid proximity: %v", err)
+		}
+		q.proximity = &point
+	}
+
+	return q, nil
+}
+
+// parseLngLat parses a "longitude,latitude" pair.
+func parseLngLat(s string) (geojson.LngLat, error) {
+	values, err := parseFloats(s, 2)
+	if err != nil {
+		return geojson.LngLat{}, err
+	}
+	point := geojson.LngLat{Longitude: values[0], Latitude: values[1]}
+	return point, point.Validate()
+}
+
+// parseFloats parses n comma-separated numbers.
+func parseFloats(s string, n int) ([]float64, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != n {
+		return nil, strconv.ErrSyntax
+	}
+	values := make([]float64, n)
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,143 @@
+package mapboxtest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+func TestGeocoding_Forward(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	svc := newGeocoding(srv, DefaultToken)
+
+	resp, err := svc.Forward(context.Background(), &geocoding.ForwardRequest{Query: "1600 Pennsylvania Avenue NW, Washington"})
+	if err != nil {
+		t.Fatalf("Forward() error = %v", err)
+	}
+	if len(resp.Features) != 1 {
+		t.Fatalf("expected 1 feature, got %d", len(resp.Features))
+	}
+
+	props := resp.Features[0].Properties
+	if props.FeatureType != "address" || props.AddressNumber != "1600" || props.Postcode != "20500" {
+		t.Errorf("unexpected properties %+v", props)
+	}
+	if props.PlaceName != "1600 Pennsylvania Avenue NW, Washington, District of Columbia 20500, United States" {
+		t.Errorf("PlaceName = %q", props.PlaceName)
+	}
+	if props.Context.Region.RegionCodeFull != "US-DC" || props.Context.Country.CountryCode != "US" {
+		t.Errorf("unexpected context %+v", props.Context)
+	}
+	if ll := resp.Features[0].Geometry.LngLat(); ll.Longitude != -77.036543 || ll.Latitude != 38.897676 {
+		t.Errorf("unexpected geometry %v", ll)
+	}
+}
+
+func TestGeocoding_ForwardStructured(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	limit := 1
+	resp, err := newGeocoding(srv, DefaultToken).ForwardStructured(context.Background(), &geocoding.StructuredForwardRequest{
+		AddressNumber: "350",
+		Street:        "5th Avenue",
+		Place:         "New York",
+		Country:       "us",
+		Limit:         &limit,
+	})
+	if err != nil {
+		t.Fatalf("ForwardStructured() error = %v", err)
+	}
+	if len(resp.Features) != 1 || resp.Features[0].Properties.Name != "Empire State Building" {
+		t.Errorf("unexpected features %+v", resp.Features)
+	}
+}
+
+func TestGeocoding_ForwardAntimeridian(t *testing.T) {
+	srv := NewServer(WithGazetteer(NewGazetteer(
+		Place{Name: "Taveuni", Location: geojson.LngLat{Longitude: 179.9, Latitude: -16.8}},
+		Place{Name: "Taveuni Ridge", Location: geojson.LngLat{Longitude: -179.9, Latitude: -16.9}},
+		Place{Name: "Taveuni Far", Location: geojson.LngLat{Longitude: 100, Latitude: -16.9}},
+	)))
+	defer srv.Close()
+
+	resp, err := newGeocoding(srv, DefaultToken).Forward(context.Background(), &geocoding.ForwardRequest{
+		Query: "taveuni",
+		BBox:  geojson.NewBBox(179, -20, -179, -15),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Features) != 2 {
+		t.Errorf("expected both sides of the antimeridian, got %d features", len(resp.Features))
+	}
+}
+
+func TestGeocoding_Reverse(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	svc := newGeocoding(srv, DefaultToken)
+
+	resp, err := svc.Reverse(context.Background(), &geocoding.ReverseRequest{Longitude: 12.4922, Latitude: 41.8902})
+	if err != nil {
+		t.Fatalf("Reverse() error = %v", err)
+	}
+	if len(resp.Features) != 1 || resp.Features[0].Properties.Name != "Colosseum" {
+		t.Errorf("unexpected features %+v", resp.Features)
+	}
+
+	resp, err = svc.Reverse(context.Background(), &geocoding.ReverseRequest{Longitude: 12.4922, Latitude: 41.8902, Types: []string{"place"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Features) != 1 || resp.Features[0].Properties.Name != "Rome" {
+		t.Errorf("expected the city with a types filter, got %+v", resp.Features)
+	}
+}
+
+func TestGeocoding_Batch(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	lng, lat := -118.25, 34.05
+	resp, err := newGeocoding(srv, DefaultToken).Batch(context.Background(), &geocoding.BatchRequest{
+		Queries: []geocoding.BatchQuery{
+			{ID: "forward", Query: "Eiffel Tower"},
+			{ID: "reverse", Longitude: &lng, Latitude: &lat},
+			{ID: "empty", Query: "nowhere at all"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+	if len(resp.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(resp.Results))
+	}
+	if r := resp.Results[0]; r.ID != "forward" || r.Response.Features[0].Properties.Name != "Eiffel Tower" {
+		t.Errorf("unexpected forward result %+v", r)
+	}
+	if r := resp.Results[1]; r.Response.Features[0].Properties.Name != "Los Angeles" {
+		t.Errorf("unexpected reverse result %+v", r)
+	}
+	if r := resp.Results[2]; r.Response == nil || len(r.Response.Features) != 0 {
+		t.Errorf("expected an empty response, got %+v", r)
+	}
+}
+
+func TestGeocoding_InvalidParams(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL + "/search/geocode/v6/forward?limit=50&q=rome&access_token=" + DefaultToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want 422", resp.StatusCode)
+	}
+}
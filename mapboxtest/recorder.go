@@ -0,0 +1,392 @@
+package mapboxtest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pettinz/mapbox-go-sdk/internal/redact"
+)
+
+// ErrNoInteraction is returned by a replaying Recorder when no recorded
+// interaction matches a request.
+var ErrNoInteraction = errors.New("mapboxtest: no recorded interaction matches the request")
+
+// Mode selects whether a Recorder replays or records interactions.
+type Mode int
+
+const (
+	// ModeReplay serves requests from the cassette only. Requests without a
+	// recorded interaction fail with ErrNoInteraction.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends every request to the real API and rewrites the
+	// cassette with the interactions.
+	ModeRecord
+
+	// ModeReplayOrRecord replays recorded interactions and records the
+	// requests that have none.
+	ModeReplayOrRecord
+)
+
+// Cassette is the file format of recorded interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request with its secrets redacted.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   Body   `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response with its secrets redacted.
+type RecordedResponse struct {
+	Status  int         `json:"status"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    Body        `json:"body,omitempty"`
+}
+
+// Body is a recorded payload. It is stored as text when it is valid UTF-8
+// and as base64 otherwise, e.g. for map tiles.
+type Body []byte
+
+// MarshalJSON implements json.Marshaler.
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*b = Body(text)
+		return nil
+	}
+
+	var encoded struct {
+		Base64 string `json:"base64"`
+	}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded.Base64)
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// Matcher reports whether a recorded request matches an outgoing one. The
+// outgoing request body has already been read into body.
+type Matcher func(r *http.Request, body []byte, recorded *RecordedRequest) bool
+
+// MatchMethod matches the HTTP method.
+func MatchMethod(r *http.Request, _ []byte, recorded *RecordedRequest) bool {
+	return r.Method == recorded.Method
+}
+
+// MatchPath matches the URL path.
+func MatchPath(r *http.Request, _ []byte, recorded *RecordedRequest) bool {
+	u, err := url.Parse(recorded.URL)
+	return err == nil && u.Path == r.URL.Path
+}
+
+// MatchQuery matches the query parameters regardless of their order,
+// ignoring the access and session tokens and the given parameters.
+func MatchQuery(ignore ...string) Matcher {
+	return func(r *http.Request, _ []byte, recorded *RecordedRequest) bool {
+		u, err := url.Parse(recorded.URL)
+		return err == nil && normalizeQuery(r.URL.Query(), ignore) == normalizeQuery(u.Query(), ignore)
+	}
+}
+
+// MatchBody matches the request body, comparing JSON bodies by value.
+func MatchBody(_ *http.Request, body []byte, recorded *RecordedRequest) bool {
+	return bytes.Equal(normalizeBody(body), normalizeBody(recorded.Body))
+}
+
+// DefaultMatchers match the method, path and query, which identify every
+// request the SDK sends except batch geocoding, whose queries are in the
+// body.
+var DefaultMatchers = []Matcher{MatchMethod, MatchPath, MatchQuery()}
+
+// Recorder is an http.RoundTripper that records interactions with the API
+// to a cassette file and replays them, for deterministic tests without
+// network access:
+//
+//	rec, err := mapboxtest.NewRecorder("testdata/geocode.json", mapboxtest.WithMode(mode))
+//	client := mapbox.NewClient(token, mapbox.WithHTTPClient(rec.Client()))
+//
+// Access and session tokens are redacted from everything written to the
+// cassette.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	matchers  []Matcher
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// RecorderOption configures a Recorder.
+type RecorderOption func(*Recorder)
+
+// WithMode sets the recording mode. The default is ModeReplay.
+func WithMode(mode Mode) RecorderOption {
+	return func(r *Recorder) {
+		r.mode = mode
+	}
+}
+
+// WithTransport sets the transport used to reach the real API when
+// recording. The default is http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithMatchers sets how requests are matched to recorded interactions. A
+// recorded interaction matches when every matcher accepts it. The default
+// is DefaultMatchers.
+func WithMatchers(matchers ...Matcher) RecorderOption {
+	return func(r *Recorder) {
+		r.matchers = matchers
+	}
+}
+
+// NewRecorder creates a recorder for the cassette at path. The cassette is
+// loaded unless the mode is ModeRecord; a missing cassette is an error only
+// in ModeReplay.
+func NewRecorder(path string, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		transport: http.DefaultTransport,
+		matchers:  DefaultMatchers,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && r.mode == ModeReplayOrRecord {
+			return r, nil
+		}
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Client returns an HTTP client using the recorder, for mapbox.WithHTTPClient.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions returns the interactions of the cassette.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.mode != ModeRecord {
+		if recorded, ok := r.find(req, body); ok {
+			return replay(req, recorded), nil
+		}
+		if r.mode == ModeReplay {
+			return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, redact.URL(req.URL))
+		}
+	}
+
+	outgoing := req.Clone(req.Context())
+	outgoing.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := r.transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	if err := r.record(req, body, resp, respBody); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// find returns the first unused interaction matching the request, or the
+// last used one so that repeated requests replay indefinitely.
+func (r *Recorder) find(req *http.Request, body []byte) (*RecordedResponse, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fallback := -1
+	for i := range r.cassette.Interactions {
+		interaction := &r.cassette.Interactions[i]
+		if !r.matches(req, body, &interaction.Request) {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return &interaction.Response, true
+		}
+		fallback = i
+	}
+	if fallback >= 0 {
+		return &r.cassette.Interactions[fallback].Response, true
+	}
+	return nil, false
+}
+
+func (r *Recorder) matches(req *http.Request, body []byte, recorded *RecordedRequest) bool {
+	for _, match := range r.matchers {
+		if !match(req, body, recorded) {
+			return false
+		}
+	}
+	return true
+}
+
+// record appends a redacted interaction and saves the cassette.
+func (r *Recorder) record(req *http.Request, body []byte, resp *http.Response, respBody []byte) error {
+	scrub := newScrubber(req.URL.Query())
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    scrub(redact.URL(req.URL)),
+			Body:   Body(scrub(string(body))),
+		},
+		Response: RecordedResponse{
+			Status:  resp.StatusCode,
+			Headers: resp.Header.Clone(),
+			Body:    Body(scrub(string(respBody))),
+		},
+	}
+	for _, values := range interaction.Response.Headers {
+		for i, v := range values {
+			values[i] = scrub(v)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.used = append(r.used, true)
+	return r.save()
+}
+
+// save writes the cassette file. The caller must hold r.mu.
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// replay builds a response from a recorded one.
+func replay(req *http.Request, recorded *RecordedResponse) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Headers.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
+
+// newScrubber returns a function replacing the secret values of query
+// wherever they appear, e.g. in an error message echoing the request URL.
+func newScrubber(query url.Values) func(string) string {
+	var pairs []string
+	for _, key := range redact.Params {
+		if v := query.Get(key); v != "" {
+			pairs = append(pairs, v, redact.Placeholder)
+		}
+	}
+	return strings.NewReplacer(pairs...).Replace
+}
+
+// normalizeQuery encodes the query in sorted order without the secret and
+// ignored parameters.
+func normalizeQuery(query url.Values, ignore []string) string {
+	for _, key := range redact.Params {
+		query.Del(key)
+	}
+	for _, key := range ignore {
+		query.Del(key)
+	}
+	return query.Encode()
+}
+
+// normalizeBody re-encodes JSON bodies so that formatting and key order do
+// not affect matching.
+func normalizeBody(body []byte) []byte {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	normalized, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return normalized
+}
@@ -0,0 +1,198 @@
+package mapboxtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/searchbox"
+)
+
+// record runs fn against a fake server through a recording Recorder and
+// returns the cassette path. The server is closed before returning, so
+// replays cannot reach it.
+func record(t *testing.T, fn func(baseURL string, client *http.Client)) string {
+	t.Helper()
+
+	srv := NewServer()
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+	rec, err := NewRecorder(path, WithMode(ModeRecord), WithTransport(srv.Client().Transport))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn(srv.URL, rec.Client())
+	return path
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	var recorded *geocoding.Response
+	var baseURL string
+	path := record(t, func(url string, client *http.Client) {
+		baseURL = url
+		svc := geocoding.New(DefaultToken, internalhttp.New(url, client))
+		var err error
+		recorded, err = svc.Forward(context.Background(), &geocoding.ForwardRequest{Query: "Eiffel Tower", Country: []string{"FR"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), DefaultToken) {
+		t.Errorf("cassette contains the access token:\n%s", data)
+	}
+	if !strings.Contains(string(data), "access_token=REDACTED") {
+		t.Errorf("expected redacted token in cassette:\n%s", data)
+	}
+
+	rec, err := NewRecorder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Replays ignore the token and the order of query parameters.
+	svc := geocoding.New("pk.another-token", internalhttp.New(baseURL, rec.Client()))
+	for range 2 {
+		resp, err := svc.Forward(context.Background(), &geocoding.ForwardRequest{Query: "Eiffel Tower", Country: []string{"FR"}})
+		if err != nil {
+			t.Fatalf("replay error = %v", err)
+		}
+		if resp.Features[0].Properties.Name != recorded.Features[0].Properties.Name {
+			t.Errorf("replayed %+v, recorded %+v", resp.Features[0], recorded.Features[0])
+		}
+	}
+
+	_, err = svc.Forward(context.Background(), &geocoding.ForwardRequest{Query: "Colosseum"})
+	if !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("expected ErrNoInteraction, got %v", err)
+	}
+}
+
+func TestRecorder_SessionTokens(t *testing.T) {
+	var baseURL, id string
+	path := record(t, func(url string, client *http.Client) {
+		baseURL = url
+		svc := searchbox.New(DefaultToken, internalhttp.New(url, client))
+		token := searchbox.NewSessionToken()
+		resp, err := svc.Suggest(context.Background(), &searchbox.SuggestRequest{Query: "philz", SessionToken: token})
+		if err != nil {
+			t.Fatal(err)
+		}
+		id = resp.Suggestions[0].MapboxID
+		if _, err := svc.Retrieve(context.Background(), &searchbox.RetrieveRequest{MapboxID: id, SessionToken: token}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	rec, err := NewRecorder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range rec.Interactions() {
+		if !strings.Contains(i.Request.URL, "session_token=REDACTED") {
+			t.Errorf("expected redacted session token in %s", i.Request.URL)
+		}
+	}
+
+	// A new session token per test run still matches.
+	svc := searchbox.New(DefaultToken, internalhttp.New(baseURL, rec.Client()))
+	token := searchbox.NewSessionToken()
+	if _, err := svc.Suggest(context.Background(), &searchbox.SuggestRequest{Query: "philz", SessionToken: token}); err != nil {
+		t.Errorf("suggest replay error = %v", err)
+	}
+	resp, err := svc.Retrieve(context.Background(), &searchbox.RetrieveRequest{MapboxID: id, SessionToken: token})
+	if err != nil || resp.Features[0].Properties.Name != "Philz Coffee" {
+		t.Errorf("retrieve replay = %+v, %v", resp, err)
+	}
+}
+
+func TestRecorder_MatchBody(t *testing.T) {
+	lng, lat := 2.29, 48.85
+	batch := func(client *http.Client, baseURL, query string) (*geocoding.BatchResponse, error) {
+		svc := geocoding.New(DefaultToken, internalhttp.New(baseURL, client))
+		return svc.Batch(context.Background(), &geocoding.BatchRequest{Queries: []geocoding.BatchQuery{
+			{Query: query},
+			{Longitude: &lng, Latitude: &lat},
+		}})
+	}
+
+	var baseURL string
+	path := record(t, func(url string, client *http.Client) {
+		baseURL = url
+		for _, q := range []string{"Rome", "Paris"} {
+			if _, err := batch(client, url, q); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	rec, err := NewRecorder(path, WithMatchers(append(DefaultMatchers, MatchBody)...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := batch(rec.Client(), baseURL, "Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := resp.Results[0].Response.Features[0].Properties.Name; name != "Paris" {
+		t.Errorf("expected the interaction matching the body, got %s", name)
+	}
+	if _, err := batch(rec.Client(), baseURL, "Berlin"); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("expected ErrNoInteraction for an unrecorded body, got %v", err)
+	}
+}
+
+func TestRecorder_ReplayOrRecord(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if _, err := NewRecorder(path); err == nil {
+		t.Error("expected error for a missing cassette in replay mode")
+	}
+
+	rec, err := NewRecorder(path, WithMode(ModeReplayOrRecord), WithTransport(srv.Client().Transport))
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := geocoding.New(DefaultToken, internalhttp.New(srv.URL, rec.Client()))
+	req := &geocoding.ReverseRequest{Longitude: 13.4, Latitude: 52.5}
+	for range 3 {
+		if _, err := svc.Reverse(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("expected one request to reach the server, got %d", n)
+	}
+	if n := len(rec.Interactions()); n != 1 {
+		t.Errorf("expected one recorded interaction, got %d", n)
+	}
+}
+
+func TestBody_JSON(t *testing.T) {
+	binary := Body{0x1f, 0x8b, 0xff, 0x00}
+	data, err := binary.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "base64") {
+		t.Errorf("expected base64 for binary bodies, got %s", data)
+	}
+
+	var decoded Body
+	if err := decoded.UnmarshalJSON(data); err != nil || string(decoded) != string(binary) {
+		t.Errorf("round trip = %v, %v", decoded, err)
+	}
+}
@@ -0,0 +1,197 @@
+package mapboxtest
+
+import (
+	"net/http"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/searchbox"
+)
+
+func badRequest(message string) *apiError {
+	return &apiError{status: http.StatusBadRequest, message: message}
+}
+
+func (s *Server) searchBoxSuggest(r *http.Request) (any, error) {
+	params := r.URL.Query()
+	token := params.Get("session_token")
+	if params.Get("q") == "" || token == "" {
+		return nil, badRequest("q and session_token are required")
+	}
+
+	q, err := parseQuery(params, 5, 10)
+	if err != nil {
+		return nil, err
+	}
+	q.text = params.Get("q")
+	s.session(token, false)
+
+	resp := &searchbox.SuggestResponse{Suggestions: []searchbox.Suggestion{}, Attribution: attribution, ResponseID: s.nextResponseID()}
+	for _, m := range s.gazetteer.search(q) {
+		p := &m.place
+		resp.Suggestions = append(resp.Suggestions, searchbox.Suggestion{
+			MapboxID:       p.MapboxID,
+			FeatureType:    p.FeatureType,
+			Name:           p.Name,
+			NamePreferred:  p.Name,
+			PlaceFormatted: p.placeFormatted(),
+			Address:        p.address(),
+			FullAddress:    p.fullAddress(),
+			Context:        searchBoxContext(p),
+			POICategory:    categoryNames(p.Categories),
+			POICategoryIDs: p.Categories,
+			Brand:          brand(p),
+			Distance:       m.distance,
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) searchBoxRetrieve(r *http.Request) (any, error) {
+	token := r.URL.Query().Get("session_token")
+	if token == "" {
+		return nil, badRequest("session_token is required")
+	}
+	s.session(token, true)
+
+	p, ok := s.gazetteer.Lookup(r.PathValue("id"))
+	if !ok {
+		return nil, &apiError{status: http.StatusNotFound, message: "Not Found"}
+	}
+	return &searchbox.RetrieveResponse{
+		Type:        "FeatureCollection",
+		Features:    []searchbox.Feature{searchBoxFeature(&p, nil)},
+		Attribution: attribution,
+		ResponseID:  s.nextResponseID(),
+	}, nil
+}
+
+func (s *Server) searchBoxForward(r *http.Request) (any, error) {
+	params := r.URL.Query()
+	if params.Get("q") == "" {
+		return nil, badRequest("q is required")
+	}
+
+	q, err := parseQuery(params, 5, 10)
+	if err != nil {
+		return nil, err
+	}
+	q.text = params.Get("q")
+
+	resp := &searchbox.ForwardResponse{Type: "FeatureCollection", Attribution: attribution, ResponseID: s.nextResponseID()}
+	resp.Features = searchBoxFeatures(s.gazetteer.search(q))
+	return resp, nil
+}
+
+func (s *Server) searchBoxCategory(r *http.Request) (any, error) {
+	params := r.URL.Query()
+	q, err := parseQuery(params, 10, 25)
+	if err != nil {
+		return nil, err
+	}
+	if q.proximity == nil && q.bbox == nil && params.Get("proximity") != "ip" && params.Get("route") == "" {
+		return nil, badRequest("proximity, bbox or route is required")
+	}
+	q.categories = []string{r.PathValue("id")}
+
+	matches := s.gazetteer.nearest(q.proximity, q)
+	resp := &searchbox.CategorySearchResponse{Type: "FeatureCollection", Attribution: attribution, ResponseID: s.nextResponseID()}
+	resp.Features = searchBoxFeatures(matches)
+	return resp, nil
+}
+
+func (s *Server) searchBoxListCategories(r *http.Request) (any, error) {
+	resp := &searchbox.ListCategoriesResponse{Categories: []searchbox.Category{}}
+	for _, id := range s.gazetteer.Categories() {
+		resp.Categories = append(resp.Categories, searchbox.Category{CanonicalID: id, Name: categoryName(id)})
+	}
+	return resp, nil
+}
+
+func (s *Server) searchBoxReverse(r *http.Request) (any, error) {
+	params := r.URL.Query()
+	q, err := parseQuery(params, 1, 10)
+	if err != nil {
+		return nil, err
+	}
+
+	point, err := parseLngLat(params.Get("longitude") + "," + params.Get("latitude"))
+	if err != nil {
+		return nil, badRequest("longitude and latitude are required")
+	}
+
+	resp := &searchbox.ReverseResponse{Type: "FeatureCollection", Attribution: attribution, ResponseID: s.nextResponseID()}
+	resp.Features = searchBoxFeatures(s.gazetteer.nearest(&point, q))
+	return resp, nil
+}
+
+func searchBoxFeatures(matches []match) []searchbox.Feature {
+	features := []searchbox.Feature{}
+	for _, m := range matches {
+		features = append(features, searchBoxFeature(&m.place, m.distance))
+	}
+	return features
+}
+
+func searchBoxFeature(p *Place, distance *float64) searchbox.Feature {
+	return searchbox.Feature{
+		Type: "Feature",
+		ID:   p.MapboxID,
+		Geometry: searchbox.Geometry{
+			Type:        geojson.TypePoint,
+			Coordinates: p.Location,
+		},
+		Properties: searchbox.FeatureProperties{
+			MapboxID:       p.MapboxID,
+			FeatureType:    p.FeatureType,
+			Name:           p.Name,
+			NamePreferred:  p.Name,
+			PlaceFormatted: p.placeFormatted(),
+			FullAddress:    p.fullAddress(),
+			Address:        p.address(),
+			AddressNumber:  p.AddressNumber,
+			Street:         p.Street,
+			Postcode:       p.Postcode,
+			Context:        searchBoxContext(p),
+			Coordinates:    searchbox.Coordinates{Longitude: p.Location.Longitude, Latitude: p.Location.Latitude},
+			POICategory:    categoryNames(p.Categories),
+			POICategoryIDs: p.Categories,
+			Brand:          brand(p),
+			Distance:       distance,
+		},
+	}
+}
+
+func searchBoxContext(p *Place) *searchbox.Context {
+	c := &searchbox.Context{}
+	if p.CountryCode != "" {
+		c.Country = &searchbox.ContextElement{MapboxID: contextID("country", p.CountryCode), Name: p.Country, CountryCode: p.CountryCode}
+	}
+	if p.Region != "" {
+		c.Region = &searchbox.ContextElement{MapboxID: contextID("region", p.CountryCode, p.RegionCode), Name: p.Region, RegionCode: p.RegionCode}
+	}
+	if p.Postcode != "" {
+		c.Postcode = &searchbox.ContextElement{MapboxID: contextID("postcode", p.CountryCode, p.Postcode), Name: p.Postcode}
+	}
+	if p.City != "" && p.FeatureType != "place" {
+		c.Place = &searchbox.ContextElement{MapboxID: contextID("place", p.CountryCode, p.City), Name: p.City}
+	}
+	if p.Street != "" {
+		c.Street = &searchbox.ContextElement{MapboxID: contextID("street", p.CountryCode, p.City, p.Street), Name: p.Street}
+	}
+	return c
+}
+
+func categoryNames(ids []string) []string {
+	var names []string
+	for _, id := range ids {
+		names = append(names, categoryName(id))
+	}
+	return names
+}
+
+func brand(p *Place) []string {
+	if p.Brand == "" {
+		return nil
+	}
+	return []string{p.Brand}
+}
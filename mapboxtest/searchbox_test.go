@@ -0,0 +1,187 @@
+package mapboxtest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/searchbox"
+)
+
+func TestSearchBox_SuggestRetrieve(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	svc := newSearchBox(srv, DefaultToken)
+	token := searchbox.NewSessionToken()
+
+	suggestions, err := svc.Suggest(context.Background(), &searchbox.SuggestRequest{
+		Query:        "blue bot",
+		SessionToken: token,
+		Proximity:    &geojson.LngLat{Longitude: -122.4, Latitude: 37.78},
+	})
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if len(suggestions.Suggestions) != 1 {
+		t.Fatalf("expected 1 suggestion, got %d", len(suggestions.Suggestions))
+	}
+	s := suggestions.Suggestions[0]
+	if s.Name != "Blue Bottle Coffee" || s.Distance == nil || s.FullAddress != "66 Mint St, San Francisco, California 94103, United States" {
+		t.Errorf("unexpected suggestion %+v", s)
+	}
+
+	retrieved, err := svc.Retrieve(context.Background(), &searchbox.RetrieveRequest{MapboxID: s.MapboxID, SessionToken: token})
+	if err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+	f := retrieved.Features[0]
+	if f.Properties.Coordinates.Latitude != 37.782483 || f.Properties.POICategoryIDs[0] != "coffee" {
+		t.Errorf("unexpected feature %+v", f.Properties)
+	}
+
+	_, err = svc.Retrieve(context.Background(), &searchbox.RetrieveRequest{MapboxID: "missing", SessionToken: token})
+	if statusOf(err) != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown ID, got %v", err)
+	}
+}
+
+func TestSearchBox_Sessions(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv := NewServer(WithClock(func() time.Time { return now }))
+	defer srv.Close()
+	svc := newSearchBox(srv, DefaultToken)
+	ctx := context.Background()
+
+	suggest := func(token string) {
+		t.Helper()
+		if _, err := svc.Suggest(ctx, &searchbox.SuggestRequest{Query: "coffee", SessionToken: token}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	retrieve := func(token string) {
+		t.Helper()
+		if _, err := svc.Retrieve(ctx, &searchbox.RetrieveRequest{MapboxID: "mapboxtest.4", SessionToken: token}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// One session: three suggests and a retrieve.
+	suggest("a")
+	suggest("a")
+	suggest("a")
+	retrieve("a")
+	// Reusing the token after the retrieve starts a second session.
+	suggest("a")
+	// The third session starts once the second expires.
+	now = now.Add(61 * time.Minute)
+	suggest("a")
+	// Another token has its own session.
+	suggest("b")
+
+	sessions := srv.Sessions()
+	if len(sessions) != 4 {
+		t.Fatalf("expected 4 sessions, got %+v", sessions)
+	}
+	if s := sessions[0]; s.Token != "a" || s.Suggests != 3 || s.Retrieves != 1 {
+		t.Errorf("unexpected first session %+v", s)
+	}
+	if sessions[1].Suggests != 1 || sessions[2].Suggests != 1 || sessions[3].Token != "b" {
+		t.Errorf("unexpected sessions %+v", sessions)
+	}
+
+	for range sessionMaxSuggests {
+		suggest("c")
+	}
+	suggest("c")
+	var c []Session
+	for _, s := range srv.Sessions() {
+		if s.Token == "c" {
+			c = append(c, s)
+		}
+	}
+	if len(c) != 2 || c[0].Suggests != sessionMaxSuggests {
+		t.Errorf("expected a new session after %d suggests, got %+v", sessionMaxSuggests, c)
+	}
+}
+
+func TestSearchBox_Forward(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	resp, err := newSearchBox(srv, DefaultToken).Forward(context.Background(), &searchbox.ForwardRequest{Query: "landmark", Country: []string{"US"}})
+	if err != nil {
+		t.Fatalf("Forward() error = %v", err)
+	}
+	if len(resp.Features) != 1 || resp.Features[0].Properties.Name != "Empire State Building" {
+		t.Errorf("unexpected features %+v", resp.Features)
+	}
+	if resp.ResponseID == "" {
+		t.Error("expected a response ID")
+	}
+}
+
+func TestSearchBox_Category(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	svc := newSearchBox(srv, DefaultToken)
+
+	resp, err := svc.CategorySearch(context.Background(), &searchbox.CategorySearchRequest{
+		CategoryID: "tourist_attraction",
+		Proximity:  &geojson.LngLat{Longitude: 2.35, Latitude: 48.85},
+	})
+	if err != nil {
+		t.Fatalf("CategorySearch() error = %v", err)
+	}
+	var got []string
+	for _, f := range resp.Features {
+		got = append(got, f.Properties.Name)
+	}
+	if len(got) != 3 || got[0] != "Eiffel Tower" || got[1] != "Colosseum" {
+		t.Errorf("expected results by distance from Paris, got %v", got)
+	}
+
+	list, err := svc.ListCategories(context.Background(), &searchbox.ListCategoriesRequest{})
+	if err != nil {
+		t.Fatalf("ListCategories() error = %v", err)
+	}
+	if len(list.Categories) == 0 || list.Categories[0].CanonicalID != "cafe" || list.Categories[0].Name != "Cafe" {
+		t.Errorf("unexpected categories %+v", list.Categories)
+	}
+}
+
+func TestSearchBox_Reverse(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	limit := 2
+	resp, err := newSearchBox(srv, DefaultToken).Reverse(context.Background(), &searchbox.ReverseRequest{
+		Longitude: -73.9857,
+		Latitude:  40.7484,
+		Limit:     &limit,
+	})
+	if err != nil {
+		t.Fatalf("Reverse() error = %v", err)
+	}
+	if len(resp.Features) != 2 || resp.Features[0].Properties.Name != "Empire State Building" || resp.Features[1].Properties.Name != "New York" {
+		t.Errorf("unexpected features %+v", resp.Features)
+	}
+}
+
+func TestSearchBox_MissingSessionToken(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL + "/search/searchbox/v1/suggest?q=coffee&access_token=" + DefaultToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", resp.StatusCode)
+	}
+	if len(srv.Sessions()) != 0 {
+		t.Error("expected no session without a token")
+	}
+}
@@ -0,0 +1,427 @@
+// Package mapboxtest provides an in-process fake of the Mapbox Geocoding and
+// Search Box APIs for integration tests.
+//
+// The server answers forward, reverse and batch geocoding and the Search Box
+// suggest, retrieve, forward, category and reverse endpoints from an
+// in-memory gazetteer, using the same JSON shapes as the real API. It checks
+// access tokens, tracks search sessions, and can simulate rate limiting and
+// inject errors:
+//
+//	srv := mapboxtest.NewServer()
+//	defer srv.Close()
+//
+//	client := mapbox.NewClient(mapboxtest.DefaultToken, mapbox.WithBaseURL(srv.URL))
+package mapboxtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultToken is the access token accepted by a server created without
+// WithTokens.
+const DefaultToken = "pk.mapboxtest"
+
+// attribution is returned with every search response.
+const attribution = "© mapboxtest synthetic data"
+
+// Endpoint identifies an API endpoint served by the fake.
+type Endpoint string
+
+// Endpoints served by the fake.
+const (
+	GeocodingForward        Endpoint = "geocoding.forward"
+	GeocodingReverse        Endpoint = "geocoding.reverse"
+	GeocodingBatch          Endpoint = "geocoding.batch"
+	SearchBoxSuggest        Endpoint = "searchbox.suggest"
+	SearchBoxRetrieve       Endpoint = "searchbox.retrieve"
+	SearchBoxForward        Endpoint = "searchbox.forward"
+	SearchBoxCategory       Endpoint = "searchbox.category"
+	SearchBoxListCategories Endpoint = "searchbox.list_categories"
+	SearchBoxReverse        Endpoint = "searchbox.reverse"
+)
+
+// Search Box sessions end after a retrieve, after this many suggest calls or
+// after sessionDuration, following the Mapbox billing rules.
+const (
+	sessionMaxSuggests = 50
+	sessionDuration    = time.Hour
+)
+
+// Server is a fake Mapbox API server listening on a local address.
+type Server struct {
+	// URL is the base URL of the server, for use with mapbox.WithBaseURL.
+	URL string
+
+	server    *httptest.Server
+	mux       *http.ServeMux
+	gazetteer *Gazetteer
+	tokens    []string
+	now       func() time.Time
+
+	mu           sync.Mutex
+	requests     []Request
+	sessions     []Session
+	faults       []*Fault
+	rateLimit    int
+	rateInterval time.Duration
+	windowStart  time.Time
+	windowCount  int
+	responseID   int
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithGazetteer sets the places the server searches. The default is
+// DefaultGazetteer.
+func WithGazetteer(g *Gazetteer) Option {
+	return func(s *Server) {
+		s.gazetteer = g
+	}
+}
+
+// WithTokens sets the accepted access tokens, replacing DefaultToken.
+func WithTokens(tokens ...string) Option {
+	return func(s *Server) {
+		s.tokens = tokens
+	}
+}
+
+// WithRateLimit rejects requests beyond limit per interval with HTTP 429,
+// as the API does when an account exceeds its rate limit.
+func WithRateLimit(limit int, interval time.Duration) Option {
+	return func(s *Server) {
+		s.rateLimit = limit
+		s.rateInterval = interval
+	}
+}
+
+// WithClock sets the clock used for rate limiting and session expiry.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// NewServer starts a fake server. The caller must call Close when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		tokens: []string{DefaultToken},
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.gazetteer == nil {
+		s.gazetteer = DefaultGazetteer()
+	}
+
+	s.mux = http.NewServeMux()
+	s.route("GET /search/geocode/v6/forward", GeocodingForward, s.geocodingForward)
+	s.route("GET /search/geocode/v6/reverse", GeocodingReverse, s.geocodingReverse)
+	s.route("POST /search/geocode/v6/batch", GeocodingBatch, s.geocodingBatch)
+	s.route("GET /search/searchbox/v1/suggest", SearchBoxSuggest, s.searchBoxSuggest)
+	s.route("GET /search/searchbox/v1/retrieve/{id}", SearchBoxRetrieve, s.searchBoxRetrieve)
+	s.route("GET /search/searchbox/v1/forward", SearchBoxForward, s.searchBoxForward)
+	s.route("GET /search/searchbox/v1/category/{id}", SearchBoxCategory, s.searchBoxCategory)
+	s.route("GET /search/searchbox/v1/category", SearchBoxListCategories, s.searchBoxListCategories)
+	s.route("GET /search/searchbox/v1/reverse", SearchBoxReverse, s.searchBoxReverse)
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, &apiError{status: http.StatusNotFound, message: "Not Found"})
+	})
+
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the server and blocks until all requests have completed.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns an HTTP client configured for the server.
+func (s *Server) Client() *http.Client {
+	return s.server.Client()
+}
+
+// Gazetteer returns the places the server searches. Places added to it are
+// visible to subsequent requests.
+func (s *Server) Gazetteer() *Gazetteer {
+	return s.gazetteer
+}
+
+// ServeHTTP implements http.Handler, so the fake can also be mounted in
+// another server.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Endpoint Endpoint
+	Method   string
+	Path     string
+	Query    url.Values
+	Status   int
+}
+
+// Requests returns the requests received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// Session is a Search Box session, as counted for billing: the suggest and
+// retrieve calls sharing a session token until the session ends.
+type Session struct {
+	Token     string
+	Started   time.Time
+	Suggests  int
+	Retrieves int
+}
+
+// Sessions returns the Search Box sessions seen so far, in order. A token
+// reused after its session ended starts a new session.
+func (s *Server) Sessions() []Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.sessions)
+}
+
+// Fault is an error the server returns instead of handling a request.
+type Fault struct {
+	// Endpoint limits the fault to one endpoint. Empty matches all.
+	Endpoint Endpoint
+
+	// Status is the HTTP status to return. Zero only applies Delay.
+	Status int
+
+	// Message and Code form the JSON error body.
+	Message string
+	Code    string
+
+	// Delay is waited before responding, or until the request is canceled.
+	Delay time.Duration
+
+	// Count is the number of requests the fault applies to. Zero applies it
+	// until ClearFaults is called.
+	Count int
+}
+
+// InjectFault makes the server fail matching requests. Faults are matched
+// in the order they were injected.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Reset clears the recorded requests, sessions, faults and rate limit window.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests, s.sessions, s.faults = nil, nil, nil
+	s.windowStart, s.windowCount = time.Time{}, 0
+}
+
+// apiError is an error response in the API's JSON format.
+type apiError struct {
+	status  int
+	message string
+	code    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.status, e.message)
+}
+
+func invalidParam(format string, args ...any) *apiError {
+	return &apiError{status: http.StatusUnprocessableEntity, message: fmt.Sprintf(format, args...)}
+}
+
+// handler serves one endpoint, returning the value to encode as JSON.
+type handler func(r *http.Request) (any, error)
+
+// route registers an endpoint behind the fault, token and rate limit checks.
+func (s *Server) route(pattern string, endpoint Endpoint, h handler) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.requests = append(s.requests, Request{
+				Endpoint: endpoint,
+				Method:   r.Method,
+				Path:     r.URL.Path,
+				Query:    r.URL.Query(),
+				Status:   rec.status,
+			})
+		}()
+
+		if err := s.check(rec, r, endpoint); err != nil {
+			writeError(rec, err)
+			return
+		}
+
+		result, err := h(r)
+		if err != nil {
+			writeError(rec, err)
+			return
+		}
+		rec.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(rec).Encode(result)
+	})
+}
+
+// check applies injected faults, then token checking, then rate limiting.
+func (s *Server) check(w http.ResponseWriter, r *http.Request, endpoint Endpoint) error {
+	if fault := s.takeFault(endpoint); fault != nil {
+		if fault.Delay > 0 {
+			select {
+			case <-time.After(fault.Delay):
+			case <-r.Context().Done():
+				return &apiError{status: http.StatusServiceUnavailable, message: "Request canceled"}
+			}
+		}
+		if fault.Status != 0 {
+			return &apiError{status: fault.Status, message: fault.Message, code: fault.Code}
+		}
+	}
+
+	token := r.URL.Query().Get("access_token")
+	if token == "" {
+		return &apiError{status: http.StatusUnauthorized, message: "Not Authorized - No Token"}
+	}
+	if !slices.Contains(s.tokens, token) {
+		return &apiError{status: http.StatusUnauthorized, message: "Not Authorized - Invalid Token"}
+	}
+
+	return s.limit(w)
+}
+
+// takeFault returns the first fault matching endpoint, consuming one use.
+func (s *Server) takeFault(endpoint Endpoint) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, f := range s.faults {
+		if f.Endpoint != "" && f.Endpoint != endpoint {
+			continue
+		}
+		if f.Count > 0 {
+			f.Count--
+			if f.Count == 0 {
+				s.faults = slices.Delete(s.faults, i, i+1)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// limit counts the request against the rate limit window and sets the rate
+// limit headers the API returns.
+func (s *Server) limit(w http.ResponseWriter) error {
+	if s.rateLimit <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	now := s.now()
+	if s.windowStart.IsZero() || now.Sub(s.windowStart) >= s.rateInterval {
+		s.windowStart, s.windowCount = now, 0
+	}
+	s.windowCount++
+	count, reset := s.windowCount, s.windowStart.Add(s.rateInterval)
+	s.mu.Unlock()
+
+	w.Header().Set("X-Rate-Limit-Limit", strconv.Itoa(s.rateLimit))
+	w.Header().Set("X-Rate-Limit-Interval", strconv.Itoa(int(s.rateInterval.Seconds())))
+	w.Header().Set("X-Rate-Limit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	if count > s.rateLimit {
+		return &apiError{status: http.StatusTooManyRequests, message: "Too Many Requests"}
+	}
+	return nil
+}
+
+// session records a suggest or retrieve call against the open session of
+// token, starting a new session when there is none.
+func (s *Server) session(token string, retrieve bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var open *Session
+	for i := len(s.sessions) - 1; i >= 0; i-- {
+		sess := &s.sessions[i]
+		if sess.Token != token {
+			continue
+		}
+		if sess.Retrieves == 0 && sess.Suggests < sessionMaxSuggests && now.Sub(sess.Started) < sessionDuration {
+			open = sess
+		}
+		break
+	}
+	if open == nil {
+		s.sessions = append(s.sessions, Session{Token: token, Started: now})
+		open = &s.sessions[len(s.sessions)-1]
+	}
+
+	if retrieve {
+		open.Retrieves++
+	} else {
+		open.Suggests++
+	}
+}
+
+// nextResponseID returns a unique response_id for Search Box responses.
+func (s *Server) nextResponseID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responseID++
+	return fmt.Sprintf("mapboxtest-%d", s.responseID)
+}
+
+// writeError writes an error in the API's JSON format.
+func writeError(w http.ResponseWriter, err error) {
+	apiErr, ok := err.(*apiError)
+	if !ok {
+		apiErr = &apiError{status: http.StatusInternalServerError, message: err.Error()}
+	}
+
+	body := map[string]string{"message": apiErr.message}
+	if apiErr.code != "" {
+		body["code"] = apiErr.code
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiErr.status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// statusRecorder records the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,157 @@
+package mapboxtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/searchbox"
+)
+
+func newGeocoding(srv *Server, token string) *geocoding.Service {
+	return geocoding.New(token, internalhttp.New(srv.URL, srv.Client()))
+}
+
+func newSearchBox(srv *Server, token string) *searchbox.Service {
+	return searchbox.New(token, internalhttp.New(srv.URL, srv.Client()))
+}
+
+// statusOf returns the HTTP status of an SDK error.
+func statusOf(err error) int {
+	var apiErr *internalhttp.ErrorResponse
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+func TestServer_Tokens(t *testing.T) {
+	srv := NewServer(WithTokens("pk.one", "pk.two"))
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		token      string
+		wantStatus int
+	}{
+		{name: "valid token", token: "pk.two", wantStatus: 0},
+		{name: "invalid token", token: "pk.other", wantStatus: http.StatusUnauthorized},
+		{name: "default token not accepted", token: DefaultToken, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newGeocoding(srv, tt.token).Forward(context.Background(), &geocoding.ForwardRequest{Query: "Rome"})
+			if got := statusOf(err); got != tt.wantStatus {
+				t.Errorf("status = %d, want %d (err = %v)", got, tt.wantStatus, err)
+			}
+		})
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv := NewServer(WithRateLimit(2, time.Minute), WithClock(func() time.Time { return now }))
+	defer srv.Close()
+
+	svc := newGeocoding(srv, DefaultToken)
+	req := &geocoding.ReverseRequest{Longitude: 12.49, Latitude: 41.89}
+	for i := range 2 {
+		if _, err := svc.Reverse(context.Background(), req); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+	if _, err := svc.Reverse(context.Background(), req); statusOf(err) != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := svc.Reverse(context.Background(), req); err != nil {
+		t.Errorf("expected a new window to allow requests, got %v", err)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.InjectFault(Fault{Endpoint: GeocodingReverse, Status: http.StatusServiceUnavailable, Message: "Service Unavailable", Count: 1})
+	srv.InjectFault(Fault{Endpoint: SearchBoxForward, Status: http.StatusInternalServerError, Message: "boom", Code: "InternalError"})
+
+	geo := newGeocoding(srv, DefaultToken)
+	req := &geocoding.ReverseRequest{Longitude: -122.41, Latitude: 37.77}
+	if _, err := geo.Reverse(context.Background(), req); statusOf(err) != http.StatusServiceUnavailable {
+		t.Errorf("expected injected 503, got %v", err)
+	}
+	if _, err := geo.Reverse(context.Background(), req); err != nil {
+		t.Errorf("expected the one-shot fault to be consumed, got %v", err)
+	}
+
+	search := newSearchBox(srv, DefaultToken)
+	for range 2 {
+		_, err := search.Forward(context.Background(), &searchbox.ForwardRequest{Query: "coffee"})
+		var apiErr *internalhttp.ErrorResponse
+		if !errors.As(err, &apiErr) || apiErr.Code != "InternalError" {
+			t.Errorf("expected persistent fault, got %v", err)
+		}
+	}
+
+	srv.ClearFaults()
+	if _, err := search.Forward(context.Background(), &searchbox.ForwardRequest{Query: "coffee"}); err != nil {
+		t.Errorf("expected success after ClearFaults, got %v", err)
+	}
+}
+
+func TestServer_FaultDelay(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.InjectFault(Fault{Delay: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := newGeocoding(srv, DefaultToken).Forward(ctx, &geocoding.ForwardRequest{Query: "Rome"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestServer_Requests(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	newGeocoding(srv, DefaultToken).Forward(context.Background(), &geocoding.ForwardRequest{Query: "Paris"})
+	newGeocoding(srv, "pk.bad").Forward(context.Background(), &geocoding.ForwardRequest{Query: "Paris"})
+
+	requests := srv.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	if r := requests[0]; r.Endpoint != GeocodingForward || r.Status != http.StatusOK || r.Query.Get("q") != "Paris" {
+		t.Errorf("unexpected request %+v", r)
+	}
+	if requests[1].Status != http.StatusUnauthorized {
+		t.Errorf("expected recorded 401, got %d", requests[1].Status)
+	}
+
+	srv.Reset()
+	if len(srv.Requests()) != 0 {
+		t.Error("expected Reset to clear requests")
+	}
+}
+
+func TestServer_NotFound(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL + "/styles/v1/mapbox/streets-v12?access_token=" + DefaultToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", resp.StatusCode)
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Dedup returns a middleware collapsing concurrent identical lookups into a
+// single API call. While a GET request is in flight, requests with the same
+// path and query, in any parameter order, wait for it and receive a copy of
+// its response or error instead of calling the API again. Other methods are
+// passed through, as are requests made after the call has returned: Dedup
+// does not cache.
+//
+// The key covers every query parameter. Search Box suggest and retrieve
+// requests carry their session_token, so only lookups made within the same
+// session collapse.
+//
+// The shared call runs with the context values of the request that started
+// it, such as its usage tag, and is canceled only once every waiting caller
+// has given up. Install metering middleware after Dedup so that collapsed
+// requests are counted once, as they are billed. Each chain the middleware
+// wraps keeps its own calls, so clients with different tokens never share
+// responses.
+func Dedup() Middleware {
+	return func(next RoundTrip) RoundTrip {
+		g := &group{calls: map[string]*call{}}
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			if req.Method != http.MethodGet {
+				return next(ctx, req)
+			}
+			return g.do(ctx, req.Path+"?"+req.Query.Encode(), next, req)
+		}
+	}
+}
+
+// group tracks the calls in flight by key.
+type group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// call is a request in flight and, once done is closed, its outcome.
+type call struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+
+	resp *Response
+	body []byte
+	err  error
+}
+
+// do returns the outcome of the call in flight for key, sending req with
+// next if there is none.
+func (g *group) do(ctx context.Context, key string, next RoundTrip, req *Request) (*Response, error) {
+	g.mu.Lock()
+	c, ok := g.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &call{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = c
+		go g.run(callCtx, key, c, next, req)
+	}
+	c.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		if c.err != nil {
+			return nil, c.err
+		}
+		return &Response{
+			StatusCode: c.resp.StatusCode,
+			Status:     c.resp.Status,
+			Header:     c.resp.Header.Clone(),
+			Body:       io.NopCloser(bytes.NewReader(c.body)),
+		}, nil
+	case <-ctx.Done():
+		g.mu.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			// Nobody is left waiting: abandon the call, and let the next
+			// request start a new one.
+			c.cancel()
+			g.forget(key, c)
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// run makes the call and reads the response body to share it.
+func (g *group) run(ctx context.Context, key string, c *call, next RoundTrip, req *Request) {
+	defer c.cancel()
+
+	resp, err := next(ctx, req)
+	if err == nil {
+		c.body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		c.resp = resp
+	}
+	c.err = err
+
+	g.mu.Lock()
+	g.forget(key, c)
+	g.mu.Unlock()
+	close(c.done)
+}
+
+// forget removes c from the calls in flight unless it has been replaced.
+// The caller must hold g.mu.
+func (g *group) forget(key string, c *call) {
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+}
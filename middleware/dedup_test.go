@@ -0,0 +1,211 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blocking returns a terminal round trip counting its calls and answering
+// with the request query once release is closed, or failing when its
+// context is canceled.
+func blocking(calls *atomic.Int32, release <-chan struct{}) RoundTrip {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		calls.Add(1)
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		return &Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(req.Query.Encode())),
+		}, nil
+	}
+}
+
+func lookup(query url.Values) *Request {
+	req := newRequest()
+	req.Query = query
+	return req
+}
+
+// waitFor polls cond until it holds or the test times out.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDedup(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	rt := Chain(blocking(&calls, release), Dedup())
+
+	const n = 10
+	var wg sync.WaitGroup
+	bodies := make([]string, n)
+	errs := make([]error, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// The same parameters, in a different order every other call.
+			query := url.Values{"q": {"Rome"}, "limit": {"1"}}
+			if i%2 == 1 {
+				query = url.Values{"limit": {"1"}, "q": {"Rome"}}
+			}
+			resp, err := rt(context.Background(), lookup(query))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			bodies[i] = string(body)
+			resp.Header.Set("Content-Type", "modified")
+		}()
+	}
+
+	// A different query is not collapsed.
+	other := make(chan error, 1)
+	go func() {
+		_, err := rt(context.Background(), lookup(url.Values{"q": {"Paris"}}))
+		other <- err
+	}()
+
+	waitFor(t, func() bool { return calls.Load() == 2 })
+	time.Sleep(10 * time.Millisecond) // let late callers join
+	close(release)
+	wg.Wait()
+
+	if err := <-other; err != nil {
+		t.Fatal(err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+	for i := range n {
+		if errs[i] != nil || bodies[i] != "limit=1&q=Rome" {
+			t.Errorf("caller %d got %q, %v", i, bodies[i], errs[i])
+		}
+	}
+
+	// Calls made after the shared one has returned are sent again.
+	if _, err := rt(context.Background(), lookup(url.Values{"q": {"Rome"}, "limit": {"1"}})); err != nil {
+		t.Fatal(err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected a new call after the first returned, got %d calls", got)
+	}
+}
+
+func TestDedup_SharesErrors(t *testing.T) {
+	var calls atomic.Int32
+	failure := errors.New("connection reset")
+	release := make(chan struct{})
+	rt := Chain(func(ctx context.Context, req *Request) (*Response, error) {
+		calls.Add(1)
+		<-release
+		return nil, failure
+	}, Dedup())
+
+	errs := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := rt(context.Background(), lookup(url.Values{"q": {"Rome"}}))
+			errs <- err
+		}()
+	}
+	waitFor(t, func() bool { return calls.Load() == 1 })
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	for range 2 {
+		if err := <-errs; !errors.Is(err, failure) {
+			t.Errorf("expected the shared error, got %v", err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
+
+func TestDedup_OnlyGET(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	close(release)
+	rt := Chain(blocking(&calls, release), Dedup())
+
+	for range 2 {
+		req := lookup(url.Values{})
+		req.Method = http.MethodPost
+		if _, err := rt(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected POST requests to be sent, got %d calls", got)
+	}
+}
+
+func TestDedup_Cancel(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	defer close(release)
+	rt := Chain(blocking(&calls, release), Dedup())
+
+	// The caller that started the call gives up; the other keeps waiting.
+	first, cancelFirst := context.WithCancel(context.Background())
+	second, cancelSecond := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() {
+		_, err := rt(first, lookup(url.Values{"q": {"Rome"}}))
+		errs <- err
+	}()
+	waitFor(t, func() bool { return calls.Load() == 1 })
+
+	done := make(chan struct{})
+	go func() {
+		_, err := rt(second, lookup(url.Values{"q": {"Rome"}}))
+		errs <- err
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	cancelFirst()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	select {
+	case <-done:
+		t.Fatal("the remaining caller returned early")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// Once nobody waits, the call is canceled and a new request starts over.
+	cancelSecond()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	rt(ctx, lookup(url.Values{"q": {"Rome"}}))
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected a new call after the first was abandoned, got %d calls", got)
+	}
+}
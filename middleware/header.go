@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+)
+
+// Header returns a middleware setting the given headers on every request,
+// replacing any existing values.
+func Header(header http.Header) Middleware {
+	header = header.Clone()
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			for key, values := range header {
+				req.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+			}
+			return next(ctx, req)
+		}
+	}
+}
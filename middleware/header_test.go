@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Team", "routing")
+	header["user-agent"] = []string{"my-app/1.0"}
+
+	var got http.Header
+	rt := Chain(func(ctx context.Context, req *Request) (*Response, error) {
+		got = req.Header
+		return ok(ctx, req)
+	}, Header(header))
+
+	req := newRequest()
+	req.Header.Set("User-Agent", "github.com/pettinz/mapbox-go-sdk-go")
+	req.Header.Set("Accept", "application/json")
+	if _, err := rt(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"X-Team":     "routing",
+		"User-Agent": "my-app/1.0",
+		"Accept":     "application/json",
+	}
+	for key, want := range tests {
+		if v := got.Get(key); v != want {
+			t.Errorf("header %s = %q, want %q", key, v, want)
+		}
+	}
+
+	// Changing the original header does not affect the middleware.
+	header.Set("X-Team", "search")
+	if _, err := rt(context.Background(), newRequest()); err != nil {
+		t.Fatal(err)
+	}
+	if v := got.Get("X-Team"); v != "routing" {
+		t.Errorf("header X-Team = %q, want routing", v)
+	}
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pettinz/mapbox-go-sdk/internal/redact"
+)
+
+// Logging returns a middleware logging every call to logger. Requests and
+// responses are logged at Debug level, failed requests and error statuses at
+// Warn level. Records carry the endpoint, method, path and query, with the
+// tokens redacted, the status, the duration, the response size in bytes and,
+// when set, the request ID. The response is logged when its body is closed,
+// so the duration and size include reading it.
+func Logging(logger *slog.Logger) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			attrs := []slog.Attr{
+				slog.String("endpoint", req.Endpoint),
+				slog.String("method", req.Method),
+				slog.String("path", req.Path),
+				slog.String("query", redact.Query(req.Query).Encode()),
+			}
+			if id := req.Header.Get(RequestIDHeader); id != "" {
+				attrs = append(attrs, slog.String("request_id", id))
+			}
+			logger.LogAttrs(ctx, slog.LevelDebug, "mapbox request", attrs...)
+
+			start := time.Now()
+			resp, err := next(ctx, req)
+			if err != nil {
+				attrs = append(attrs,
+					slog.Duration("duration", time.Since(start)),
+					slog.String("error", redact.Error(err).Error()))
+				logger.LogAttrs(ctx, slog.LevelWarn, "mapbox request failed", attrs...)
+				return nil, err
+			}
+
+			resp.Body = &loggedBody{
+				ReadCloser: resp.Body,
+				log: func(n int64) {
+					level := slog.LevelDebug
+					if resp.StatusCode >= 400 {
+						level = slog.LevelWarn
+					}
+					logger.LogAttrs(ctx, level, "mapbox response", append(attrs,
+						slog.Int("status", resp.StatusCode),
+						slog.Duration("duration", time.Since(start)),
+						slog.Int64("bytes", n))...)
+				},
+			}
+			return resp, nil
+		}
+	}
+}
+
+// loggedBody counts the bytes read from a response body and logs them once
+// when it is closed.
+type loggedBody struct {
+	io.ReadCloser
+	n    int64
+	once sync.Once
+	log  func(n int64)
+}
+
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+func (b *loggedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.log(b.n) })
+	return err
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestLogging(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		err       error
+		wantLevel string
+		wantAttrs []string
+	}{
+		{
+			name:      "success",
+			status:    http.StatusOK,
+			wantLevel: "level=DEBUG",
+			wantAttrs: []string{`msg="mapbox response"`, "endpoint=geocoding.forward", "method=GET", "path=/search/geocode/v6/forward", "status=200", "bytes=17", "request_id=req-1"},
+		},
+		{
+			name:      "error status",
+			status:    http.StatusTooManyRequests,
+			wantLevel: "level=WARN",
+			wantAttrs: []string{`msg="mapbox response"`, "status=429", "bytes=17"},
+		},
+		{
+			name:      "transport error",
+			err:       &url.Error{Op: "Get", URL: "https://api.mapbox.com/?access_token=pk.secret", Err: errors.New("connection refused")},
+			wantLevel: "level=WARN",
+			wantAttrs: []string{`msg="mapbox request failed"`, "access_token=REDACTED", "connection refused"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			rt := Chain(func(ctx context.Context, req *Request) (*Response, error) {
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return &Response{StatusCode: tt.status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{"features": []}` + "\n"))}, nil
+			}, RequestID(), Logging(logger))
+
+			req := newRequest()
+			req.Query = url.Values{"q": {"Rome"}, "session_token": {"session-secret"}}
+			resp, err := rt(WithRequestID(context.Background(), "req-1"), req)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp != nil {
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+				resp.Body.Close()
+			}
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != 2 {
+				t.Fatalf("expected a request and a response record, got %q", lines)
+			}
+			if !strings.Contains(lines[0], "level=DEBUG") || !strings.Contains(lines[0], `msg="mapbox request"`) {
+				t.Errorf("unexpected request record %q", lines[0])
+			}
+			if !strings.Contains(lines[0], "query=\"q=Rome&session_token=REDACTED\"") {
+				t.Errorf("request record %q does not contain the redacted query", lines[0])
+			}
+			for _, want := range append(tt.wantAttrs, tt.wantLevel, "duration=") {
+				if !strings.Contains(lines[1], want) {
+					t.Errorf("record %q does not contain %q", lines[1], want)
+				}
+			}
+			if strings.Contains(buf.String(), "secret") {
+				t.Errorf("log contains a token: %s", buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Package middleware defines the chain wrapped around every Mapbox API call.
+//
+// A Middleware sees each call before it is sent, with the name of the
+// endpoint and the typed request passed to the service method, and the
+// response metadata after it returns. The access token is added after the
+// chain, so middleware never sees it. Install middleware with
+// mapbox.WithMiddleware.
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Endpoint names, one per service method.
+const (
+	GeocodingForward           = "geocoding.forward"
+	GeocodingForwardStructured = "geocoding.forward_structured"
+	GeocodingReverse           = "geocoding.reverse"
+	GeocodingBatch             = "geocoding.batch"
+
+	SearchBoxSuggest        = "searchbox.suggest"
+	SearchBoxRetrieve       = "searchbox.retrieve"
+	SearchBoxForward        = "searchbox.forward"
+	SearchBoxCategory       = "searchbox.category"
+	SearchBoxListCategories = "searchbox.list_categories"
+	SearchBoxReverse        = "searchbox.reverse"
+
+	TokensRetrieve        = "tokens.retrieve"
+	TokensList            = "tokens.list"
+	TokensCreate          = "tokens.create"
+	TokensUpdate          = "tokens.update"
+	TokensDelete          = "tokens.delete"
+	TokensListScopes      = "tokens.list_scopes"
+	TokensCreateTemporary = "tokens.create_temporary"
+
+	TilesVector = "tiles.vector"
+	TilesRaster = "tiles.raster"
+)
+
+// Request is an API call on its way to the server.
+type Request struct {
+	// Endpoint is the name of the API call, e.g. GeocodingForward. It is
+	// empty for requests not made by a service method.
+	Endpoint string
+
+	// Params is the typed request passed to the service method, e.g. a
+	// *geocoding.ForwardRequest, or nil for methods without one.
+	Params any
+
+	// Method, Path and Query describe the HTTP request. Query does not
+	// contain the access token.
+	Method string
+	Path   string
+	Query  url.Values
+
+	// Header holds the request headers. Middleware may add or change them.
+	Header http.Header
+
+	// Body is the value encoded as the JSON request body, or nil.
+	Body any
+}
+
+// Response is the response to an API call.
+type Response struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+
+	// Body is the response body. Middleware reading it must replace it
+	// with an equivalent reader.
+	Body io.ReadCloser
+}
+
+// RoundTrip sends a request and returns its response. A non-2xx status is
+// not an error at this level; it is reported by the service method.
+type RoundTrip func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps a RoundTrip with additional behavior.
+type Middleware func(next RoundTrip) RoundTrip
+
+// Chain wraps rt with the middleware. The first middleware is the outermost:
+// it sees the request first and the response last.
+func Chain(rt RoundTrip, middleware ...Middleware) RoundTrip {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		rt = middleware[i](rt)
+	}
+	return rt
+}
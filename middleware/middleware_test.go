@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ok is a terminal round trip returning an empty 200 response.
+func ok(context.Context, *Request) (*Response, error) {
+	return &Response{StatusCode: http.StatusOK, Status: "200 OK", Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+func newRequest() *Request {
+	return &Request{Endpoint: GeocodingForward, Method: http.MethodGet, Path: "/search/geocode/v6/forward", Header: http.Header{}}
+}
+
+func TestChain(t *testing.T) {
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next RoundTrip) RoundTrip {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				order = append(order, name+" request")
+				resp, err := next(ctx, req)
+				order = append(order, name+" response")
+				return resp, err
+			}
+		}
+	}
+
+	rt := Chain(ok, trace("outer"), trace("inner"))
+	if _, err := rt(context.Background(), newRequest()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"outer request", "inner request", "inner response", "outer response"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
+
+func TestChain_Empty(t *testing.T) {
+	resp, err := Chain(ok)(context.Background(), newRequest())
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("Chain() = %v, %v", resp, err)
+	}
+}
+
+func TestRetryFromContext(t *testing.T) {
+	if n := RetryFromContext(context.Background()); n != 0 {
+		t.Errorf("RetryFromContext() = %d, want 0", n)
+	}
+	if n := RetryFromContext(WithRetry(context.Background(), 2)); n != 2 {
+		t.Errorf("RetryFromContext() = %d, want 2", n)
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+// RequestIDHeader is the header RequestID sets.
+const RequestIDHeader = "X-Request-Id"
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying a request ID, typically the ID of
+// the incoming request being served, to propagate to API calls.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// RequestID returns a middleware setting the X-Request-Id header to the
+// request ID from the context, or to a new random ID when there is none. The
+// ID is also added to the context seen by the rest of the chain.
+func RequestID() Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			id, ok := RequestIDFromContext(ctx)
+			if !ok {
+				id = newRequestID()
+				ctx = WithRequestID(ctx, id)
+			}
+			req.Header.Set(RequestIDHeader, id)
+			return next(ctx, req)
+		}
+	}
+}
+
+// newRequestID returns a random 128-bit hex ID.
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%x", b)
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRequestID(t *testing.T) {
+	var header, fromContext string
+	rt := Chain(func(ctx context.Context, req *Request) (*Response, error) {
+		header = req.Header.Get(RequestIDHeader)
+		fromContext, _ = RequestIDFromContext(ctx)
+		return ok(ctx, req)
+	}, RequestID())
+
+	t.Run("propagated", func(t *testing.T) {
+		ctx := WithRequestID(context.Background(), "req-42")
+		if _, err := rt(ctx, newRequest()); err != nil {
+			t.Fatal(err)
+		}
+		if header != "req-42" || fromContext != "req-42" {
+			t.Errorf("header = %q, context = %q; want req-42", header, fromContext)
+		}
+	})
+
+	t.Run("generated", func(t *testing.T) {
+		if _, err := rt(context.Background(), newRequest()); err != nil {
+			t.Fatal(err)
+		}
+		if len(header) != 32 || fromContext != header {
+			t.Errorf("header = %q, context = %q; want the same 32-character ID", header, fromContext)
+		}
+
+		first := header
+		if _, err := rt(context.Background(), newRequest()); err != nil {
+			t.Fatal(err)
+		}
+		if header == first {
+			t.Errorf("expected a new ID per request, got %q twice", header)
+		}
+	})
+}
+
+func TestRequestIDFromContext_Empty(t *testing.T) {
+	if _, ok := RequestIDFromContext(WithRequestID(context.Background(), "")); ok {
+		t.Error("expected no request ID for an empty ID")
+	}
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import "context"
+
+type retryKey struct{}
+
+// WithRetry returns a context marking API calls made with it as the n-th
+// retry of a failed call. Code retrying calls sets it so that middleware,
+// such as instrumentation, can tell retries from first attempts.
+func WithRetry(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, retryKey{}, n)
+}
+
+// RetryFromContext returns the retry number set with WithRetry, or 0 for a
+// first attempt.
+func RetryFromContext(ctx context.Context) int {
+	n, _ := ctx.Value(retryKey{}).(int)
+	return n
+}
@@ -0,0 +1,61 @@
+package offline
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// DirWriter stores tiles as files in a {root}/{z}/{x}/{y}.{ext} directory tree.
+type DirWriter struct {
+	root string
+	ext  string
+}
+
+// NewDirWriter creates a DirWriter rooted at root. ext is the file extension
+// without the leading dot, e.g. "mvt" or "png".
+func NewDirWriter(root, ext string) *DirWriter {
+	return &DirWriter{root: root, ext: ext}
+}
+
+// Path returns the file path of a tile.
+func (w *DirWriter) Path(id TileID) string {
+	return filepath.Join(w.root, strconv.Itoa(id.Z), strconv.Itoa(id.X), strconv.Itoa(id.Y)+"."+w.ext)
+}
+
+// Has implements Writer.
+func (w *DirWriter) Has(id TileID) (bool, error) {
+	_, err := os.Stat(w.Path(id))
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return false, err
+}
+
+// Put implements Writer. Tiles are written to a temporary file and renamed
+// into place, so an interrupted download never leaves a partial tile behind.
+func (w *DirWriter) Put(id TileID, data []byte) error {
+	path := w.Path(id)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create tile directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write tile: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write tile: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+	"github.com/pettinz/mapbox-go-sdk/tiles"
+)
+
+const (
+	defaultConcurrency = 4
+	defaultRetries     = 2
+	retryBackoff       = 500 * time.Millisecond
+)
+
+// Downloader fetches the tiles of a Region concurrently and stores them in a Writer.
+type Downloader struct {
+	tiles       *tiles.Service
+	concurrency int
+	rate        float64
+	retries     int
+	maxTiles    int
+	progress    func(Progress)
+}
+
+// Option is a functional option for configuring the Downloader.
+type Option func(*Downloader)
+
+// WithConcurrency sets the number of tiles fetched in parallel (default: 4).
+func WithConcurrency(n int) Option {
+	return func(d *Downloader) {
+		if n > 0 {
+			d.concurrency = n
+		}
+	}
+}
+
+// WithRateLimit caps the number of tile requests per second. Zero disables the limit.
+func WithRateLimit(perSecond float64) Option {
+	return func(d *Downloader) {
+		d.rate = perSecond
+	}
+}
+
+// WithRetries sets how many times a failed tile is retried (default: 2).
+// Client errors other than rate limiting are never retried.
+func WithRetries(n int) Option {
+	return func(d *Downloader) {
+		if n >= 0 {
+			d.retries = n
+		}
+	}
+}
+
+// WithMaxTiles refuses to start downloads whose pyramid exceeds n tiles.
+func WithMaxTiles(n int) Option {
+	return func(d *Downloader) {
+		d.maxTiles = n
+	}
+}
+
+// WithProgress registers a callback invoked after every processed tile.
+// Calls are serialized.
+func WithProgress(fn func(Progress)) Option {
+	return func(d *Downloader) {
+		d.progress = fn
+	}
+}
+
+// Progress reports the state of a download.
+type Progress struct {
+	Total      int // tiles in the region
+	Downloaded int // tiles fetched and stored
+	Skipped    int // tiles already present in the writer
+	Failed     int // tiles that could not be fetched
+}
+
+// Result is the outcome of a download.
+type Result struct {
+	Progress
+
+	// Errors lists the tiles that could not be fetched.
+	Errors []*TileError
+}
+
+// TileError records a tile that could not be fetched.
+type TileError struct {
+	Tile TileID
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *TileError) Error() string {
+	return fmt.Sprintf("tile %s: %v", e.Tile, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *TileError) Unwrap() error {
+	return e.Err
+}
+
+// NewDownloader creates a Downloader that fetches tiles through the given service.
+func NewDownloader(service *tiles.Service, opts ...Option) *Downloader {
+	d := &Downloader{
+		tiles:       service,
+		concurrency: defaultConcurrency,
+		retries:     defaultRetries,
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Download fetches every tile of the region that is not already stored in w.
+// Tiles that fail after retries are reported in Result.Errors and do not stop
+// the download; errors from the writer or context cancellation do. Running
+// Download again with the same writer resumes an interrupted download.
+func (d *Downloader) Download(ctx context.Context, region *Region, w Writer) (*Result, error) {
+	total, err := region.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	if d.maxTiles > 0 && total > d.maxTiles {
+		return nil, fmt.Errorf("region contains %d tiles, maximum is %d", total, d.maxTiles)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		result   = &Result{Progress: Progress{Total: total}}
+		fatalErr error
+		limit    = newLimiter(d.rate)
+		jobs     = make(chan TileID)
+		wg       sync.WaitGroup
+	)
+
+	// report updates the result under the lock and notifies the progress callback.
+	report := func(update func()) {
+		mu.Lock()
+		defer mu.Unlock()
+		update()
+		if d.progress != nil {
+			d.progress(result.Progress)
+		}
+	}
+
+	// fail records a fatal error and stops the download.
+	fail := func(err error) {
+		mu.Lock()
+		if fatalErr == nil {
+			fatalErr = err
+		}
+		mu.Unlock()
+		cancel()
+	}
+
+	for i := 0; i < d.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				mu.Lock()
+				exists, err := w.Has(id)
+				mu.Unlock()
+				if err != nil {
+					fail(fmt.Errorf("failed to check tile %s: %w", id, err))
+					return
+				}
+
+				if exists {
+					report(func() { result.Skipped++ })
+					continue
+				}
+
+				data, err := d.fetch(ctx, limit, region, id)
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					report(func() {
+						result.Failed++
+						result.Errors = append(result.Errors, &TileError{Tile: id, Err: err})
+					})
+					continue
+				}
+
+				mu.Lock()
+				err = w.Put(id, data)
+				mu.Unlock()
+				if err != nil {
+					fail(fmt.Errorf("failed to store tile %s: %w", id, err))
+					return
+				}
+
+				report(func() { result.Downloaded++ })
+			}
+		}()
+	}
+
+enumerate:
+	for _, tr := range region.ranges() {
+		for x := tr.MinX; x <= tr.MaxX; x++ {
+			for y := tr.MinY; y <= tr.MaxY; y++ {
+				select {
+				case jobs <- TileID{Z: tr.Z, X: x, Y: y}:
+				case <-ctx.Done():
+					break enumerate
+				}
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if fatalErr != nil {
+		return result, fatalErr
+	}
+
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// fetch downloads a single tile, retrying transient failures.
+func (d *Downloader) fetch(ctx context.Context, limit *limiter, region *Region, id TileID) ([]byte, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= d.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Duration(attempt) * retryBackoff):
+			}
+		}
+
+		if err := limit.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		ctx := middleware.WithRetry(ctx, attempt)
+		var (
+			tile *tiles.Tile
+			err  error
+		)
+		if region.isVector() {
+			tile, err = d.tiles.Vector(ctx, &tiles.VectorRequest{
+				Tileset: region.Tileset,
+				Z:       id.Z,
+				X:       id.X,
+				Y:       id.Y,
+			})
+		} else {
+			tile, err = d.tiles.Raster(ctx, &tiles.RasterRequest{
+				Tileset: region.Tileset,
+				Z:       id.Z,
+				X:       id.X,
+				Y:       id.Y,
+				Format:  region.Format,
+				HighDPI: region.HighDPI,
+			})
+		}
+		if err == nil {
+			return tile.Data, nil
+		}
+
+		lastErr = err
+		if !retryable(err) {
+			break
+		}
+	}
+
+	return nil, lastErr
+}
+
+// retryable reports whether a failed tile request is worth retrying.
+func retryable(err error) bool {
+	var apiErr *internalhttp.ErrorResponse
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return true
+}
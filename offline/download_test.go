@@ -0,0 +1,291 @@
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+	"github.com/pettinz/mapbox-go-sdk/tiles"
+)
+
+// memoryWriter is an in-memory Writer for tests.
+type memoryWriter struct {
+	tiles  map[TileID][]byte
+	puts   map[TileID]int
+	putErr error
+}
+
+func newMemoryWriter() *memoryWriter {
+	return &memoryWriter{tiles: map[TileID][]byte{}, puts: map[TileID]int{}}
+}
+
+func (w *memoryWriter) Has(id TileID) (bool, error) {
+	_, ok := w.tiles[id]
+	return ok, nil
+}
+
+func (w *memoryWriter) Put(id TileID, data []byte) error {
+	if w.putErr != nil {
+		return w.putErr
+	}
+	w.tiles[id] = data
+	w.puts[id]++
+	return nil
+}
+
+func newTestService(t *testing.T, handler http.HandlerFunc) *tiles.Service {
+	t.Helper()
+	server := testutil.MockServer(t, handler)
+	t.Cleanup(server.Close)
+	return tiles.New("test-token", internalhttp.New(server.URL, nil))
+}
+
+func TestDownloader_Download(t *testing.T) {
+	var requests int32
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(r.URL.Path))
+	})
+
+	region := &Region{Tileset: "mapbox.mapbox-streets-v8", BBox: geojson.BBox{West: -180, South: -90, East: 180, North: 90}, MinZoom: 0, MaxZoom: 2}
+	writer := newMemoryWriter()
+
+	var (
+		mu       sync.Mutex
+		progress []Progress
+	)
+	d := NewDownloader(service, WithConcurrency(3), WithProgress(func(p Progress) {
+		mu.Lock()
+		progress = append(progress, p)
+		mu.Unlock()
+	}))
+
+	result, err := d.Download(context.Background(), region, writer)
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+
+	if result.Total != 21 || result.Downloaded != 21 || result.Skipped != 0 || result.Failed != 0 {
+		t.Errorf("unexpected result %+v", result.Progress)
+	}
+	if len(writer.tiles) != 21 {
+		t.Errorf("expected 21 stored tiles, got %d", len(writer.tiles))
+	}
+	if got := string(writer.tiles[TileID{Z: 2, X: 3, Y: 1}]); got != "/v4/mapbox.mapbox-streets-v8/2/3/1.mvt" {
+		t.Errorf("unexpected tile data %q", got)
+	}
+	if len(progress) != 21 || progress[20].Downloaded != 21 {
+		t.Errorf("expected 21 progress updates ending at 21 downloaded, got %d", len(progress))
+	}
+}
+
+func TestDownloader_Resume(t *testing.T) {
+	var requests int32
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("tile"))
+	})
+
+	region := &Region{Tileset: "mapbox.satellite", Format: tiles.FormatPNG, BBox: geojson.BBox{West: -180, South: -90, East: 180, North: 90}, MinZoom: 0, MaxZoom: 1}
+	writer := newMemoryWriter()
+	writer.tiles[TileID{Z: 0, X: 0, Y: 0}] = []byte("existing")
+	writer.tiles[TileID{Z: 1, X: 1, Y: 1}] = []byte("existing")
+
+	result, err := NewDownloader(service).Download(context.Background(), region, writer)
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+
+	if result.Skipped != 2 || result.Downloaded != 3 {
+		t.Errorf("expected 2 skipped and 3 downloaded, got %+v", result.Progress)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+	if string(writer.tiles[TileID{Z: 0, X: 0, Y: 0}]) != "existing" {
+		t.Error("expected existing tile to be left untouched")
+	}
+}
+
+func TestDownloader_Antimeridian(t *testing.T) {
+	var requests int32
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// At zoom 0 the columns on both sides of the antimeridian are the same.
+	region := &Region{Tileset: "t", BBox: geojson.BBox{West: 170, South: -20, East: -170, North: -10}, MinZoom: 0, MaxZoom: 2}
+	writer := newMemoryWriter()
+
+	result, err := NewDownloader(service).Download(context.Background(), region, writer)
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+
+	if result.Total != 5 || result.Downloaded != 5 || requests != 5 {
+		t.Errorf("expected 5 tiles downloaded with 5 requests, got %+v and %d requests", result.Progress, requests)
+	}
+	for id, n := range writer.puts {
+		if n != 1 {
+			t.Errorf("tile %s stored %d times", id, n)
+		}
+	}
+}
+
+func TestDownloader_Failures(t *testing.T) {
+	var attempts int32
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v4/t/1/0/0.mvt":
+			w.WriteHeader(http.StatusNotFound)
+		case "/v4/t/1/1/0.mvt":
+			// Fails once, then succeeds.
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+
+	region := &Region{Tileset: "t", BBox: geojson.BBox{West: -180, South: -90, East: 180, North: 90}, MinZoom: 1, MaxZoom: 1}
+
+	result, err := NewDownloader(service, WithRetries(1)).Download(context.Background(), region, newMemoryWriter())
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+
+	if result.Failed != 1 || result.Downloaded != 3 {
+		t.Errorf("expected 1 failed and 3 downloaded, got %+v", result.Progress)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Tile != (TileID{Z: 1, X: 0, Y: 0}) {
+		t.Fatalf("unexpected errors %v", result.Errors)
+	}
+
+	var apiErr *internalhttp.ErrorResponse
+	if !errors.As(result.Errors[0], &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected wrapped 404 error, got %v", result.Errors[0])
+	}
+}
+
+func TestDownloader_MarksRetries(t *testing.T) {
+	var attempts int32
+	server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	var mu sync.Mutex
+	var retries []int
+	record := func(next middleware.RoundTrip) middleware.RoundTrip {
+		return func(ctx context.Context, req *middleware.Request) (*middleware.Response, error) {
+			mu.Lock()
+			retries = append(retries, middleware.RetryFromContext(ctx))
+			mu.Unlock()
+			return next(ctx, req)
+		}
+	}
+	service := tiles.New("test-token", internalhttp.New(server.URL, nil, record))
+
+	region := &Region{Tileset: "t", BBox: geojson.BBox{West: 0, South: 0, East: 1, North: 1}, MinZoom: 0, MaxZoom: 0}
+	if _, err := NewDownloader(service, WithRetries(1)).Download(context.Background(), region, newMemoryWriter()); err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+
+	if fmt.Sprint(retries) != "[0 1]" {
+		t.Errorf("retries = %v, want [0 1]", retries)
+	}
+}
+
+func TestDownloader_WriterError(t *testing.T) {
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	writer := newMemoryWriter()
+	writer.putErr = fmt.Errorf("disk full")
+
+	region := &Region{Tileset: "t", BBox: geojson.BBox{West: -180, South: -90, East: 180, North: 90}, MinZoom: 0, MaxZoom: 3}
+
+	_, err := NewDownloader(service).Download(context.Background(), region, writer)
+	if err == nil || !errors.Is(err, writer.putErr) {
+		t.Errorf("expected writer error, got %v", err)
+	}
+}
+
+func TestDownloader_MaxTiles(t *testing.T) {
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	region := &Region{Tileset: "t", BBox: geojson.BBox{West: -180, South: -90, East: 180, North: 90}, MinZoom: 0, MaxZoom: 5}
+
+	if _, err := NewDownloader(service, WithMaxTiles(100)).Download(context.Background(), region, newMemoryWriter()); err == nil {
+		t.Error("expected error when region exceeds the tile limit")
+	}
+}
+
+func TestDownloader_Canceled(t *testing.T) {
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	region := &Region{Tileset: "t", BBox: geojson.BBox{West: -180, South: -90, East: 180, North: 90}, MinZoom: 0, MaxZoom: 3}
+
+	if _, err := NewDownloader(service).Download(ctx, region, newMemoryWriter()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestDirWriter(t *testing.T) {
+	root := t.TempDir()
+	w := NewDirWriter(root, "mvt")
+	id := TileID{Z: 3, X: 2, Y: 1}
+
+	exists, err := w.Has(id)
+	if err != nil || exists {
+		t.Fatalf("Has() = %v, %v; want false, nil", exists, err)
+	}
+
+	if err := w.Put(id, []byte("data")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	exists, err = w.Has(id)
+	if err != nil || !exists {
+		t.Fatalf("Has() = %v, %v; want true, nil", exists, err)
+	}
+
+	data, err := os.ReadFile(w.Path(id))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != "data" {
+		t.Errorf("unexpected tile data %q", data)
+	}
+
+	if _, err := os.Stat(w.Path(id) + ".tmp"); !os.IsNotExist(err) {
+		t.Error("expected temporary file to be removed")
+	}
+}
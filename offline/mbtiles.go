@@ -0,0 +1,278 @@
+package offline
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/tiles"
+)
+
+// mbtilesSchema creates the tables required by the MBTiles 1.3 specification.
+var mbtilesSchema = []string{
+	`CREATE TABLE IF NOT EXISTS metadata (name TEXT, value TEXT)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS metadata_name ON metadata (name)`,
+	`CREATE TABLE IF NOT EXISTS tiles (zoom_level INTEGER, tile_column INTEGER, tile_row INTEGER, tile_data BLOB)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS tile_index ON tiles (zoom_level, tile_column, tile_row)`,
+}
+
+// Metadata describes an MBTiles archive.
+type Metadata struct {
+	Name        string        // required, human-readable tileset name
+	Format      string        // required, "pbf", "png", "jpg" or "webp"
+	Bounds      *geojson.BBox // spans all longitudes if it crosses the antimeridian
+	MinZoom     int
+	MaxZoom     int
+	Type        string // "baselayer" or "overlay"
+	Description string
+	Attribution string
+
+	// VectorLayers lists the layer IDs of a "pbf" tileset for the json
+	// row. MBTilesWriter adds the layers of the tiles it stores.
+	VectorLayers []string
+}
+
+// MetadataForRegion returns the metadata describing a downloaded region.
+func MetadataForRegion(name string, region *Region) *Metadata {
+	return &Metadata{
+		Name:    name,
+		Format:  mbtilesFormat(region),
+		Bounds:  &region.BBox,
+		MinZoom: region.MinZoom,
+		MaxZoom: region.MaxZoom,
+		Type:    "baselayer",
+	}
+}
+
+// MBTilesWriter stores tiles in an MBTiles (SQLite) database.
+//
+// Vector tiles are stored gzip-compressed, as MBTiles readers expect, and
+// their layers are recorded in the json metadata row.
+//
+// The SDK does not depend on a SQLite driver; open the database with the
+// driver of your choice (e.g. modernc.org/sqlite or github.com/mattn/go-sqlite3)
+// and pass the *sql.DB to NewMBTilesWriter.
+type MBTilesWriter struct {
+	db     *sql.DB
+	format string
+	layers []string // sorted IDs of the vector layers recorded so far
+}
+
+// NewMBTilesWriter creates the MBTiles schema in db if needed and writes the
+// metadata. The vector layers already recorded in db, by an interrupted
+// download, are kept.
+func NewMBTilesWriter(db *sql.DB, meta *Metadata) (*MBTilesWriter, error) {
+	if meta == nil || meta.Name == "" || meta.Format == "" {
+		return nil, fmt.Errorf("metadata name and format are required")
+	}
+
+	for _, stmt := range mbtilesSchema {
+		if _, err := db.Exec(stmt); err != nil {
+			return nil, fmt.Errorf("failed to create MBTiles schema: %w", err)
+		}
+	}
+
+	w := &MBTilesWriter{db: db, format: meta.Format}
+	if w.format == "pbf" {
+		existing, err := w.storedLayers()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read MBTiles metadata: %w", err)
+		}
+		w.addLayers(existing)
+		w.addLayers(meta.VectorLayers)
+	}
+
+	values := meta.values()
+	if w.format == "pbf" {
+		values["json"] = vectorLayersJSON(w.layers)
+	}
+	for name, value := range values {
+		if err := w.setMetadata(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+// Has implements Writer.
+func (w *MBTilesWriter) Has(id TileID) (bool, error) {
+	var one int
+	err := w.db.QueryRow(
+		`SELECT 1 FROM tiles WHERE zoom_level = ? AND tile_column = ? AND tile_row = ?`,
+		id.Z, id.X, id.TMSY(),
+	).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Put implements Writer. Vector tiles are gzip-compressed unless they
+// already are.
+func (w *MBTilesWriter) Put(id TileID, data []byte) error {
+	var layers []string
+	if w.format == "pbf" {
+		tile, err := tiles.Decode(data, id.Z, id.X, id.Y)
+		if err != nil {
+			return fmt.Errorf("failed to decode vector tile %s: %w", id, err)
+		}
+		for _, l := range tile.Layers {
+			layers = append(layers, l.Name)
+		}
+
+		if !isGzip(data) {
+			if data, err = gzipData(data); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := w.db.Exec(
+		`INSERT OR REPLACE INTO tiles (zoom_level, tile_column, tile_row, tile_data) VALUES (?, ?, ?, ?)`,
+		id.Z, id.X, id.TMSY(), data,
+	)
+	if err != nil {
+		return err
+	}
+
+	if w.addLayers(layers) {
+		return w.setMetadata("json", vectorLayersJSON(w.layers))
+	}
+	return nil
+}
+
+// setMetadata writes a metadata row.
+func (w *MBTilesWriter) setMetadata(name, value string) error {
+	if _, err := w.db.Exec(`INSERT OR REPLACE INTO metadata (name, value) VALUES (?, ?)`, name, value); err != nil {
+		return fmt.Errorf("failed to write MBTiles metadata: %w", err)
+	}
+	return nil
+}
+
+// storedLayers returns the vector layer IDs of the json metadata row, if any.
+func (w *MBTilesWriter) storedLayers() ([]string, error) {
+	var value string
+	err := w.db.QueryRow(`SELECT value FROM metadata WHERE name = ?`, "json").Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var doc vectorLayersDoc
+	if err := json.Unmarshal([]byte(value), &doc); err != nil {
+		return nil, nil // rewritten with the layers stored from now on
+	}
+	ids := make([]string, 0, len(doc.VectorLayers))
+	for _, l := range doc.VectorLayers {
+		ids = append(ids, l.ID)
+	}
+	return ids, nil
+}
+
+// addLayers records layer IDs and reports whether any was new.
+func (w *MBTilesWriter) addLayers(ids []string) bool {
+	added := false
+	for _, id := range ids {
+		i, found := slices.BinarySearch(w.layers, id)
+		if !found {
+			w.layers = slices.Insert(w.layers, i, id)
+			added = true
+		}
+	}
+	return added
+}
+
+// vectorLayersDoc is the json metadata row of a vector tileset.
+type vectorLayersDoc struct {
+	VectorLayers []vectorLayer `json:"vector_layers"`
+}
+
+type vectorLayer struct {
+	ID     string            `json:"id"`
+	Fields map[string]string `json:"fields"`
+}
+
+// vectorLayersJSON returns the json metadata row listing the layer IDs.
+func vectorLayersJSON(ids []string) string {
+	doc := vectorLayersDoc{VectorLayers: make([]vectorLayer, 0, len(ids))}
+	for _, id := range ids {
+		doc.VectorLayers = append(doc.VectorLayers, vectorLayer{ID: id, Fields: map[string]string{}})
+	}
+	b, _ := json.Marshal(doc)
+	return string(b)
+}
+
+// isGzip reports whether data starts with the gzip magic number.
+func isGzip(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+// gzipData compresses data.
+func gzipData(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress tile: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress tile: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// values returns the metadata as MBTiles name/value pairs.
+func (m *Metadata) values() map[string]string {
+	values := map[string]string{
+		"name":    m.Name,
+		"format":  m.Format,
+		"minzoom": strconv.Itoa(m.MinZoom),
+		"maxzoom": strconv.Itoa(m.MaxZoom),
+	}
+
+	if b := m.Bounds; b != nil {
+		// MBTiles readers expect West <= East.
+		if b.CrossesAntimeridian() {
+			b = geojson.NewBBox(-180, b.South, 180, b.North)
+		}
+		values["bounds"] = b.String()
+	}
+
+	if m.Type != "" {
+		values["type"] = m.Type
+	}
+	if m.Description != "" {
+		values["description"] = m.Description
+	}
+	if m.Attribution != "" {
+		values["attribution"] = m.Attribution
+	}
+
+	return values
+}
+
+// mbtilesFormat returns the MBTiles format name for a region's tiles.
+func mbtilesFormat(region *Region) string {
+	switch {
+	case region.isVector():
+		return "pbf"
+	case strings.HasPrefix(region.Format, "jpg"):
+		return "jpg"
+	case region.Format == tiles.FormatWebP:
+		return "webp"
+	default:
+		return "png"
+	}
+}
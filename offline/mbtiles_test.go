@@ -0,0 +1,296 @@
+package offline
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/tiles"
+)
+
+// fakeDB is the storage behind the fake SQL driver. It understands exactly the
+// statements issued by MBTilesWriter.
+type fakeDB struct {
+	mu       sync.Mutex
+	schema   []string
+	metadata map[string]string
+	tiles    map[[3]int64][]byte
+}
+
+type fakeDriver struct {
+	mu  sync.Mutex
+	dbs map[string]*fakeDB
+}
+
+var testDriver = &fakeDriver{dbs: map[string]*fakeDB{}}
+
+func init() {
+	sql.Register("offline-fake", testDriver)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	db, ok := d.dbs[name]
+	if !ok {
+		db = &fakeDB{metadata: map[string]string{}, tiles: map[[3]int64][]byte{}}
+		d.dbs[name] = db
+	}
+	return &fakeConn{db: db}, nil
+}
+
+type fakeConn struct{ db *fakeDB }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{db: c.db, query: query}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return nil, fmt.Errorf("transactions not supported") }
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(s.query, "CREATE"):
+		s.db.schema = append(s.db.schema, s.query)
+	case strings.HasPrefix(s.query, "INSERT OR REPLACE INTO metadata"):
+		s.db.metadata[args[0].(string)] = args[1].(string)
+	case strings.HasPrefix(s.query, "INSERT OR REPLACE INTO tiles"):
+		s.db.tiles[[3]int64{args[0].(int64), args[1].(int64), args[2].(int64)}] = args[3].([]byte)
+	default:
+		return nil, fmt.Errorf("unexpected statement %q", s.query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(s.query, "SELECT 1 FROM tiles"):
+		_, ok := s.db.tiles[[3]int64{args[0].(int64), args[1].(int64), args[2].(int64)}]
+		return &fakeRows{found: ok, value: int64(1)}, nil
+	case strings.HasPrefix(s.query, "SELECT value FROM metadata"):
+		value, ok := s.db.metadata[args[0].(string)]
+		return &fakeRows{found: ok, value: value}, nil
+	default:
+		return nil, fmt.Errorf("unexpected query %q", s.query)
+	}
+}
+
+type fakeRows struct {
+	found bool
+	value driver.Value
+	done  bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"value"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if !r.found || r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+func openFakeDB(t *testing.T) (*sql.DB, *fakeDB) {
+	t.Helper()
+	db, err := sql.Open("offline-fake", t.Name())
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.Ping(); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	return db, testDriver.dbs[t.Name()]
+}
+
+// vectorTile encodes a vector tile with empty layers of the given names.
+func vectorTile(names ...string) []byte {
+	var tile []byte
+	for _, name := range names {
+		// name (1), extent (5) = 4096 and version (15) = 2
+		layer := append([]byte{0x0a, byte(len(name))}, name...)
+		layer = append(layer, 0x28, 0x80, 0x20, 0x78, 0x02)
+		tile = append(tile, 0x1a, byte(len(layer)))
+		tile = append(tile, layer...)
+	}
+	return tile
+}
+
+// storedLayers decodes a stored vector tile, failing unless it is gzipped.
+func storedLayers(t *testing.T, data []byte) []string {
+	t.Helper()
+	if !isGzip(data) {
+		t.Fatalf("expected a gzipped tile, got %x", data)
+	}
+	tile, err := tiles.Decode(data, 0, 0, 0)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	var names []string
+	for _, l := range tile.Layers {
+		names = append(names, l.Name)
+	}
+	return names
+}
+
+func TestMBTilesWriter(t *testing.T) {
+	db, store := openFakeDB(t)
+
+	region := &Region{Tileset: "mapbox.mapbox-streets-v8", BBox: geojson.BBox{West: 12.4, South: 41.8, East: 12.6, North: 42.0}, MinZoom: 10, MaxZoom: 14}
+
+	w, err := NewMBTilesWriter(db, MetadataForRegion("Rome", region))
+	if err != nil {
+		t.Fatalf("NewMBTilesWriter() error = %v", err)
+	}
+
+	if len(store.schema) != len(mbtilesSchema) {
+		t.Errorf("expected %d schema statements, got %d", len(mbtilesSchema), len(store.schema))
+	}
+
+	expected := map[string]string{
+		"name":    "Rome",
+		"format":  "pbf",
+		"minzoom": "10",
+		"maxzoom": "14",
+		"bounds":  "12.4,41.8,12.6,42",
+		"type":    "baselayer",
+		"json":    `{"vector_layers":[]}`,
+	}
+	for k, v := range expected {
+		if store.metadata[k] != v {
+			t.Errorf("metadata %s = %q, want %q", k, store.metadata[k], v)
+		}
+	}
+
+	id := TileID{Z: 2, X: 1, Y: 0}
+
+	exists, err := w.Has(id)
+	if err != nil || exists {
+		t.Fatalf("Has() = %v, %v; want false, nil", exists, err)
+	}
+
+	if err := w.Put(id, vectorTile("roads")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	// MBTiles uses TMS rows: y=0 at zoom 2 is stored as row 3.
+	data, ok := store.tiles[[3]int64{2, 1, 3}]
+	if !ok {
+		t.Fatalf("expected tile stored at TMS row 3, got %v", store.tiles)
+	}
+	if got := storedLayers(t, data); !slices.Equal(got, []string{"roads"}) {
+		t.Errorf("stored tile layers = %v, want [roads]", got)
+	}
+
+	exists, err = w.Has(id)
+	if err != nil || !exists {
+		t.Fatalf("Has() = %v, %v; want true, nil", exists, err)
+	}
+
+	// Layers of later tiles are added to the json row; gzipped tiles are
+	// stored as they are.
+	gzipped, err := gzipData(vectorTile("water", "roads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Put(TileID{Z: 2, X: 2, Y: 0}, gzipped); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if !bytes.Equal(store.tiles[[3]int64{2, 2, 3}], gzipped) {
+		t.Error("expected a gzipped tile to be stored unchanged")
+	}
+
+	want := `{"vector_layers":[{"id":"roads","fields":{}},{"id":"water","fields":{}}]}`
+	if got := store.metadata["json"]; got != want {
+		t.Errorf("metadata json = %s, want %s", got, want)
+	}
+
+	// Reopening the archive, as a resumed download does, keeps the layers.
+	if _, err := NewMBTilesWriter(db, MetadataForRegion("Rome", region)); err != nil {
+		t.Fatalf("NewMBTilesWriter() error = %v", err)
+	}
+	if got := store.metadata["json"]; got != want {
+		t.Errorf("metadata json after reopening = %s, want %s", got, want)
+	}
+
+	if err := w.Put(id, []byte("not a tile")); err == nil {
+		t.Error("expected error for an invalid vector tile")
+	}
+}
+
+func TestMBTilesWriter_Raster(t *testing.T) {
+	db, store := openFakeDB(t)
+
+	region := &Region{Tileset: "mapbox.satellite", Format: "jpg90", BBox: geojson.BBox{West: 170, South: -20, East: -170, North: -10}, MinZoom: 0, MaxZoom: 2}
+
+	w, err := NewMBTilesWriter(db, MetadataForRegion("Fiji", region))
+	if err != nil {
+		t.Fatalf("NewMBTilesWriter() error = %v", err)
+	}
+
+	// Readers reject bounds crossing the antimeridian.
+	if got := store.metadata["bounds"]; got != "-180,-20,180,-10" {
+		t.Errorf("metadata bounds = %q, want -180,-20,180,-10", got)
+	}
+	if _, ok := store.metadata["json"]; ok {
+		t.Error("expected no json row for raster tiles")
+	}
+
+	if err := w.Put(TileID{Z: 0}, []byte("jpeg")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if got := string(store.tiles[[3]int64{0, 0, 0}]); got != "jpeg" {
+		t.Errorf("expected raster tile stored as is, got %q", got)
+	}
+}
+
+func TestNewMBTilesWriter_InvalidMetadata(t *testing.T) {
+	db, _ := openFakeDB(t)
+
+	if _, err := NewMBTilesWriter(db, &Metadata{Name: "no format"}); err == nil {
+		t.Error("expected error for missing format")
+	}
+}
+
+func TestMBTilesFormat(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{format: "", expected: "pbf"},
+		{format: "mvt", expected: "pbf"},
+		{format: "png256", expected: "png"},
+		{format: "jpg80", expected: "jpg"},
+		{format: "webp", expected: "webp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := mbtilesFormat(&Region{Format: tt.format}); got != tt.expected {
+				t.Errorf("mbtilesFormat() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
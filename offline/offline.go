@@ -0,0 +1,80 @@
+// Package offline downloads tile pyramids for a region so they can be used
+// without network access, storing them as MBTiles or in a z/x/y directory tree.
+package offline
+
+import (
+	"fmt"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/tilemath"
+	"github.com/pettinz/mapbox-go-sdk/tiles"
+)
+
+// Region describes the area, zoom range and tileset to download.
+type Region struct {
+	// BBox is the area to download. A West edge greater than East describes
+	// a box crossing the antimeridian.
+	BBox geojson.BBox
+
+	// MinZoom and MaxZoom bound the zoom levels to download (inclusive).
+	MinZoom int
+	MaxZoom int
+
+	// Tileset is the tileset ID, e.g. "mapbox.mapbox-streets-v8".
+	Tileset string
+
+	// Format is the tile format: "mvt" (default) for vector tiles or one of
+	// the tiles.Format* raster formats.
+	Format string
+
+	// HighDPI requests @2x raster tiles. Ignored for vector tiles.
+	HighDPI bool
+}
+
+// TileID identifies a tile in the XYZ (slippy map) scheme.
+type TileID = tilemath.Tile
+
+// Writer stores downloaded tiles. Implementations do not need to be safe for
+// concurrent use; the Downloader serializes calls.
+type Writer interface {
+	// Has reports whether the tile is already stored, which lets an
+	// interrupted download resume without fetching it again.
+	Has(id TileID) (bool, error)
+
+	// Put stores the encoded tile data.
+	Put(id TileID, data []byte) error
+}
+
+// formatVector is the Region.Format value for vector tiles.
+const formatVector = "mvt"
+
+// isVector reports whether the region describes vector tiles.
+func (r *Region) isVector() bool {
+	return r.Format == "" || r.Format == formatVector
+}
+
+// validate validates the region parameters.
+func (r *Region) validate() error {
+	if r.Tileset == "" {
+		return fmt.Errorf("tileset is required")
+	}
+
+	if err := r.BBox.Validate(); err != nil {
+		return err
+	}
+
+	if r.MinZoom < 0 || r.MaxZoom > maxZoom || r.MinZoom > r.MaxZoom {
+		return fmt.Errorf("zoom range must satisfy 0 <= min <= max <= %d, got %d-%d", maxZoom, r.MinZoom, r.MaxZoom)
+	}
+
+	if !r.isVector() {
+		switch r.Format {
+		case tiles.FormatPNG, tiles.FormatPNG32, tiles.FormatPNG64, tiles.FormatPNG128, tiles.FormatPNG256,
+			tiles.FormatJPG70, tiles.FormatJPG80, tiles.FormatJPG90, tiles.FormatWebP:
+		default:
+			return fmt.Errorf("unsupported tile format %q", r.Format)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package offline
+
+import "github.com/pettinz/mapbox-go-sdk/tilemath"
+
+// maxZoom is the highest zoom level served by the Tiles API.
+const maxZoom = 22
+
+// ranges returns the tile ranges covering the region at every zoom level.
+// A bbox crossing the antimeridian yields up to two ranges per zoom, which
+// never overlap.
+func (r *Region) ranges() []tilemath.TileRange {
+	var out []tilemath.TileRange
+	for z := r.MinZoom; z <= r.MaxZoom; z++ {
+		zr, err := tilemath.BBoxRanges(r.BBox, z)
+		if err != nil {
+			// The region has been validated; an invalid bbox cannot reach here.
+			return nil
+		}
+		out = append(out, zr...)
+	}
+
+	return out
+}
+
+// Count returns the number of tiles in the region's pyramid.
+func (r *Region) Count() (int, error) {
+	if err := r.validate(); err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, tr := range r.ranges() {
+		total += tr.Count()
+	}
+	return total, nil
+}
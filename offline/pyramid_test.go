@@ -0,0 +1,75 @@
+package offline
+
+import (
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+func TestRegion_Count(t *testing.T) {
+	tests := []struct {
+		name     string
+		region   *Region
+		expected int
+		wantErr  bool
+	}{
+		{
+			name:     "whole world zoom 0-2",
+			region:   &Region{Tileset: "t", BBox: geojson.BBox{West: -180, South: -90, East: 180, North: 90}, MinZoom: 0, MaxZoom: 2},
+			expected: 1 + 4 + 16,
+		},
+		{
+			name:     "single tile",
+			region:   &Region{Tileset: "t", BBox: geojson.BBox{West: 12.49, South: 41.89, East: 12.50, North: 41.90}, MinZoom: 10, MaxZoom: 10},
+			expected: 1,
+		},
+		{
+			name:     "crossing the antimeridian",
+			region:   &Region{Tileset: "t", BBox: geojson.BBox{West: 170, South: -20, East: -170, North: -10}, MinZoom: 2, MaxZoom: 2},
+			expected: 2,
+		},
+		{
+			name:     "crossing the antimeridian at low zoom",
+			region:   &Region{Tileset: "t", BBox: geojson.BBox{West: 170, South: -20, East: -170, North: -10}, MinZoom: 0, MaxZoom: 2},
+			expected: 1 + 2 + 2,
+		},
+		{
+			name:    "missing tileset",
+			region:  &Region{BBox: geojson.BBox{West: -180, South: -90, East: 180, North: 90}},
+			wantErr: true,
+		},
+		{
+			name:    "latitudes swapped",
+			region:  &Region{Tileset: "t", BBox: geojson.BBox{West: 0, South: 10, East: 1, North: 5}},
+			wantErr: true,
+		},
+		{
+			name:    "longitude out of range",
+			region:  &Region{Tileset: "t", BBox: geojson.BBox{West: -190, South: 0, East: 10, North: 10}},
+			wantErr: true,
+		},
+		{
+			name:    "zoom range inverted",
+			region:  &Region{Tileset: "t", BBox: geojson.BBox{West: 0, South: 0, East: 1, North: 1}, MinZoom: 5, MaxZoom: 4},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported format",
+			region:  &Region{Tileset: "t", BBox: geojson.BBox{West: 0, South: 0, East: 1, North: 1}, Format: "gif"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.region.Count()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Count() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.expected {
+				t.Errorf("Count() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
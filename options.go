@@ -1,6 +1,11 @@
 package mapbox
 
-import "net/http"
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+)
 
 // Option is a functional option for configuring the Client.
 type Option func(*Client)
@@ -20,3 +25,29 @@ func WithBaseURL(baseURL string) Option {
 		c.baseURL = baseURL
 	}
 }
+
+// Middleware wraps every API call made by the client. See the middleware
+// package for the request and response it sees and for built-ins.
+type Middleware = middleware.Middleware
+
+// RoundTrip sends an API call through the rest of the middleware chain.
+type RoundTrip = middleware.RoundTrip
+
+// WithMiddleware adds middleware around every API call. Unlike a custom
+// HTTP client, middleware knows which endpoint is called with which typed
+// request and never sees the access token. The first middleware is the
+// outermost; repeated options append to the chain.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// WithLogger logs every API call to logger: requests and responses at Debug
+// level and failures at Warn level, with the access and session tokens
+// redacted. See middleware.Logging for the attributes.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
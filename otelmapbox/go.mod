@@ -0,0 +1,26 @@
+module github.com/pettinz/mapbox-go-sdk/otelmapbox
+
+go 1.25.5
+
+// The release pipeline requires the root version released with each
+// otelmapbox/vX.Y.Z tag. The replace directive builds against the working
+// tree during development and is ignored by consumers.
+require (
+	github.com/pettinz/mapbox-go-sdk v1.0.0
+	go.opentelemetry.io/otel v1.46.0
+	go.opentelemetry.io/otel/metric v1.46.0
+	go.opentelemetry.io/otel/sdk v1.46.0
+	go.opentelemetry.io/otel/sdk/metric v1.46.0
+	go.opentelemetry.io/otel/trace v1.46.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/go-logr/logr v1.4.4 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
+
+replace github.com/pettinz/mapbox-go-sdk => ../
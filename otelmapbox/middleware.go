@@ -0,0 +1,132 @@
+package otelmapbox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware returns a middleware instrumenting every API call:
+//
+//   - A client span named after the endpoint, with the service, endpoint,
+//     method, status code, result count and retry count. The span is an
+//     error for a failed request or an error status.
+//   - The mapbox.client.request.duration histogram, in seconds, and the
+//     mapbox.client.errors counter, by service, endpoint and status code
+//     or error type.
+//   - The trace context, injected into the request headers.
+//
+// The retry count is the one set with middleware.WithRetry.
+func Middleware(opts ...Option) middleware.Middleware {
+	c := newConfig(opts)
+	tracer := c.tracerProvider.Tracer(ScopeName)
+	meter := c.meterProvider.Meter(ScopeName)
+
+	duration, err := meter.Float64Histogram(DurationMetric,
+		metric.WithDescription("Duration of Mapbox API calls."),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	errorCount, err := meter.Int64Counter(ErrorsMetric,
+		metric.WithDescription("Number of failed Mapbox API calls."),
+		metric.WithUnit("{error}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next middleware.RoundTrip) middleware.RoundTrip {
+		return func(ctx context.Context, req *middleware.Request) (*middleware.Response, error) {
+			common := []attribute.KeyValue{
+				attribute.String(ServiceKey, service(req.Endpoint)),
+				attribute.String(EndpointKey, req.Endpoint),
+				attribute.String("http.request.method", req.Method),
+			}
+
+			ctx, span := tracer.Start(ctx, spanName(req),
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(common...),
+				trace.WithAttributes(
+					attribute.Int(RetryCountKey, middleware.RetryFromContext(ctx)),
+				))
+			defer span.End()
+
+			c.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			start := time.Now()
+			resp, err := next(ctx, req)
+			elapsed := time.Since(start).Seconds()
+
+			var errorType string
+			switch {
+			case err != nil:
+				errorType = errorTypeOf(err)
+				message := redact(err.Error())
+				span.RecordError(errors.New(message))
+				span.SetStatus(codes.Error, message)
+			default:
+				span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+				if resp.StatusCode >= 400 {
+					errorType = strconv.Itoa(resp.StatusCode)
+					span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+				} else if n, ok := countResults(resp); ok {
+					span.SetAttributes(attribute.Int(ResultCountKey, n))
+				}
+			}
+
+			attrs := common
+			if resp != nil {
+				attrs = append(attrs, attribute.Int("http.response.status_code", resp.StatusCode))
+			}
+			if errorType != "" {
+				attrs = append(attrs, attribute.String("error.type", errorType))
+				span.SetAttributes(attribute.String("error.type", errorType))
+				if errorCount != nil {
+					errorCount.Add(ctx, 1, metric.WithAttributes(attrs...))
+				}
+			}
+			if duration != nil {
+				duration.Record(ctx, elapsed, metric.WithAttributes(attrs...))
+			}
+
+			return resp, err
+		}
+	}
+}
+
+// spanName names a span after its endpoint, e.g. "geocoding.forward".
+func spanName(req *middleware.Request) string {
+	if req.Endpoint != "" {
+		return req.Endpoint
+	}
+	return "mapbox " + req.Method
+}
+
+// service returns the service of an endpoint, e.g. "geocoding".
+func service(endpoint string) string {
+	s, _, _ := strings.Cut(endpoint, ".")
+	return s
+}
+
+// errorTypeOf classifies a failed request for the error.type attribute.
+func errorTypeOf(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return "transport"
+	}
+}
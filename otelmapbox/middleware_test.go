@@ -0,0 +1,278 @@
+package otelmapbox
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	mapbox "github.com/pettinz/mapbox-go-sdk"
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	"github.com/pettinz/mapbox-go-sdk/mapboxtest"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+	"github.com/pettinz/mapbox-go-sdk/searchbox"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type telemetry struct {
+	spans  *tracetest.InMemoryExporter
+	reader *sdkmetric.ManualReader
+	tracer *sdktrace.TracerProvider
+}
+
+// newClient returns a client for srv instrumented with in-memory exporters.
+func newClient(t *testing.T, srv *mapboxtest.Server, token string) (*mapbox.Client, *telemetry) {
+	t.Helper()
+
+	tel := &telemetry{
+		spans:  tracetest.NewInMemoryExporter(),
+		reader: sdkmetric.NewManualReader(),
+	}
+	tel.tracer = sdktrace.NewTracerProvider(sdktrace.WithSyncer(tel.spans))
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(tel.reader))
+
+	client := mapbox.NewClient(token,
+		mapbox.WithBaseURL(srv.URL),
+		mapbox.WithMiddleware(Middleware(
+			WithTracerProvider(tel.tracer),
+			WithMeterProvider(meter),
+			WithPropagators(propagation.TraceContext{}),
+		)),
+	)
+	return client, tel
+}
+
+func attrs(kvs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := map[attribute.Key]attribute.Value{}
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func (tel *telemetry) metrics(t *testing.T) map[string]metricdata.Aggregation {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := tel.reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	m := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, metric := range sm.Metrics {
+			m[metric.Name] = metric.Data
+		}
+	}
+	return m
+}
+
+func TestMiddleware_Span(t *testing.T) {
+	srv := mapboxtest.NewServer()
+	defer srv.Close()
+	client, tel := newClient(t, srv, mapboxtest.DefaultToken)
+
+	resp, err := client.Geocoding().Forward(context.Background(), &geocoding.ForwardRequest{Query: "Eiffel Tower"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := tel.spans.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != middleware.GeocodingForward || span.SpanKind != trace.SpanKindClient {
+		t.Errorf("span %q of kind %v", span.Name, span.SpanKind)
+	}
+
+	got := attrs(span.Attributes)
+	want := map[attribute.Key]attribute.Value{
+		ServiceKey:                  attribute.StringValue("geocoding"),
+		EndpointKey:                 attribute.StringValue(middleware.GeocodingForward),
+		"http.request.method":       attribute.StringValue(http.MethodGet),
+		"http.response.status_code": attribute.IntValue(http.StatusOK),
+		ResultCountKey:              attribute.IntValue(len(resp.Features)),
+		RetryCountKey:               attribute.IntValue(0),
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("attribute %s = %v, want %v", key, got[key].Emit(), value.Emit())
+		}
+	}
+	for _, kv := range span.Attributes {
+		if strings.Contains(kv.Value.Emit(), mapboxtest.DefaultToken) {
+			t.Errorf("attribute %s contains the token", kv.Key)
+		}
+	}
+	if span.Status.Code == codes.Error {
+		t.Errorf("unexpected error status %v", span.Status)
+	}
+}
+
+func TestMiddleware_ResultCount(t *testing.T) {
+	srv := mapboxtest.NewServer()
+	defer srv.Close()
+	client, tel := newClient(t, srv, mapboxtest.DefaultToken)
+
+	resp, err := client.SearchBox().Suggest(context.Background(), &searchbox.SuggestRequest{Query: "coffee", SessionToken: searchbox.NewSessionToken()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Suggestions) == 0 {
+		t.Fatal("expected suggestions")
+	}
+
+	got := attrs(tel.spans.GetSpans()[0].Attributes)
+	if got[ResultCountKey] != attribute.IntValue(len(resp.Suggestions)) {
+		t.Errorf("result count = %v, want %d", got[ResultCountKey].Emit(), len(resp.Suggestions))
+	}
+}
+
+func TestMiddleware_ErrorStatus(t *testing.T) {
+	srv := mapboxtest.NewServer()
+	defer srv.Close()
+	client, tel := newClient(t, srv, "pk.invalid")
+
+	_, err := client.Geocoding().Reverse(context.Background(), &geocoding.ReverseRequest{Longitude: 2.29, Latitude: 48.86})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	span := tel.spans.GetSpans()[0]
+	if span.Status.Code != codes.Error {
+		t.Errorf("status = %v, want error", span.Status)
+	}
+	got := attrs(span.Attributes)
+	if got["error.type"] != attribute.StringValue("401") || got["http.response.status_code"] != attribute.IntValue(401) {
+		t.Errorf("unexpected attributes %v", span.Attributes)
+	}
+
+	metrics := tel.metrics(t)
+	errors, ok := metrics[ErrorsMetric].(metricdata.Sum[int64])
+	if !ok || len(errors.DataPoints) != 1 || errors.DataPoints[0].Value != 1 {
+		t.Fatalf("unexpected errors metric %+v", metrics[ErrorsMetric])
+	}
+	endpoint, _ := errors.DataPoints[0].Attributes.Value(EndpointKey)
+	if endpoint.AsString() != middleware.GeocodingReverse {
+		t.Errorf("error counted for %q", endpoint.AsString())
+	}
+}
+
+func TestMiddleware_TransportError(t *testing.T) {
+	srv := mapboxtest.NewServer()
+	client, tel := newClient(t, srv, "pk.secret-token")
+	srv.Close()
+
+	if _, err := client.Geocoding().Forward(context.Background(), &geocoding.ForwardRequest{Query: "Rome"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	span := tel.spans.GetSpans()[0]
+	if span.Status.Code != codes.Error {
+		t.Errorf("status = %v, want error", span.Status)
+	}
+	if strings.Contains(span.Status.Description, "secret-token") {
+		t.Errorf("status contains the token: %s", span.Status.Description)
+	}
+	for _, event := range span.Events {
+		for _, kv := range event.Attributes {
+			if strings.Contains(kv.Value.Emit(), "secret-token") {
+				t.Errorf("event attribute %s contains the token: %s", kv.Key, kv.Value.Emit())
+			}
+		}
+	}
+	if got := attrs(span.Attributes)["error.type"]; got.AsString() != "transport" {
+		t.Errorf("error.type = %q, want transport", got.AsString())
+	}
+}
+
+func TestMiddleware_Metrics(t *testing.T) {
+	srv := mapboxtest.NewServer()
+	defer srv.Close()
+	client, tel := newClient(t, srv, mapboxtest.DefaultToken)
+
+	for _, q := range []string{"Rome", "Paris", "Berlin"} {
+		if _, err := client.Geocoding().Forward(context.Background(), &geocoding.ForwardRequest{Query: q}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.SearchBox().ListCategories(context.Background(), &searchbox.ListCategoriesRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := tel.metrics(t)
+	hist, ok := metrics[DurationMetric].(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("unexpected duration metric %+v", metrics[DurationMetric])
+	}
+
+	counts := map[string]uint64{}
+	for _, dp := range hist.DataPoints {
+		endpoint, _ := dp.Attributes.Value(EndpointKey)
+		counts[endpoint.AsString()] += dp.Count
+	}
+	if counts[middleware.GeocodingForward] != 3 || counts[middleware.SearchBoxListCategories] != 1 {
+		t.Errorf("unexpected counts %v", counts)
+	}
+	if _, ok := metrics[ErrorsMetric]; ok {
+		t.Error("expected no errors")
+	}
+}
+
+func TestMiddleware_Propagation(t *testing.T) {
+	srv := mapboxtest.NewServer()
+	defer srv.Close()
+
+	var traceparent string
+	capture := func(next middleware.RoundTrip) middleware.RoundTrip {
+		return func(ctx context.Context, req *middleware.Request) (*middleware.Response, error) {
+			traceparent = req.Header.Get("Traceparent")
+			return next(ctx, req)
+		}
+	}
+
+	spans := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans))
+	client := mapbox.NewClient(mapboxtest.DefaultToken,
+		mapbox.WithBaseURL(srv.URL),
+		mapbox.WithMiddleware(
+			Middleware(WithTracerProvider(provider), WithPropagators(propagation.TraceContext{})),
+			capture,
+		),
+	)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	if _, err := client.Geocoding().Forward(ctx, &geocoding.ForwardRequest{Query: "Rome"}); err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	child := spans.GetSpans()[0]
+	if child.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Error("expected the API call span to be a child of the caller's span")
+	}
+	if !strings.Contains(traceparent, child.SpanContext.SpanID().String()) {
+		t.Errorf("traceparent %q does not carry the API call span", traceparent)
+	}
+}
+
+func TestMiddleware_RetryCount(t *testing.T) {
+	srv := mapboxtest.NewServer()
+	defer srv.Close()
+	client, tel := newClient(t, srv, mapboxtest.DefaultToken)
+
+	ctx := middleware.WithRetry(context.Background(), 2)
+	if _, err := client.Geocoding().Forward(ctx, &geocoding.ForwardRequest{Query: "Rome"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := attrs(tel.spans.GetSpans()[0].Attributes)[RetryCountKey]; got.AsInt64() != 2 {
+		t.Errorf("retry count = %d, want 2", got.AsInt64())
+	}
+}
@@ -0,0 +1,82 @@
+// Package otelmapbox instruments Mapbox API calls with OpenTelemetry.
+//
+// Middleware creates a client span per API call and records its latency and
+// errors per endpoint. It is a separate module so that the SDK itself has
+// no dependencies:
+//
+//	client := mapbox.NewClient(token, mapbox.WithMiddleware(otelmapbox.Middleware()))
+//
+// Spans and metrics carry the service and endpoint of each call, never its
+// query, so the access and session tokens are not recorded. Error messages
+// are redacted before they are recorded.
+package otelmapbox
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/pettinz/mapbox-go-sdk/otelmapbox"
+
+// Attribute keys specific to Mapbox API calls. HTTP attributes follow the
+// OpenTelemetry semantic conventions.
+const (
+	ServiceKey     = "mapbox.service"
+	EndpointKey    = "mapbox.endpoint"
+	ResultCountKey = "mapbox.result_count"
+	RetryCountKey  = "mapbox.retry_count"
+)
+
+// Metric names.
+const (
+	DurationMetric = "mapbox.client.request.duration"
+	ErrorsMetric   = "mapbox.client.errors"
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option configures the instrumentation.
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider. The default is the global
+// provider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the meter provider. The default is the global
+// provider.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// WithPropagators sets the propagators injecting the trace context into
+// request headers. The default is the global propagator.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = propagators
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
@@ -0,0 +1,13 @@
+package otelmapbox
+
+import "regexp"
+
+// secretParam matches the value of a token query parameter, raw or URL
+// encoded, e.g. in the URL of a url.Error.
+var secretParam = regexp.MustCompile(`((?:access_token|session_token)(?:=|%3D|%3d))[^&\s"'#]+`)
+
+// redact replaces token values in s. It mirrors the SDK's internal redaction,
+// which this module cannot import.
+func redact(s string) string {
+	return secretParam.ReplaceAllString(s, "${1}REDACTED")
+}
@@ -0,0 +1,35 @@
+package otelmapbox
+
+import "testing"
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{
+			in:   `Get "https://api.mapbox.com/search?access_token=pk.abc&q=Rome": EOF`,
+			want: `Get "https://api.mapbox.com/search?access_token=REDACTED&q=Rome": EOF`,
+		},
+		{
+			in:   "session_token=4b7c&access_token=sk.xyz",
+			want: "session_token=REDACTED&access_token=REDACTED",
+		},
+		{
+			in:   "access_token%3Dpk.abc",
+			want: "access_token%3DREDACTED",
+		},
+		{
+			in:   "access_token%3dpk.abc#frag",
+			want: "access_token%3dREDACTED#frag",
+		},
+		{
+			in:   "no secrets here",
+			want: "no secrets here",
+		},
+	}
+	for _, tt := range tests {
+		if got := redact(tt.in); got != tt.want {
+			t.Errorf("redact(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
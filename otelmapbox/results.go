@@ -0,0 +1,47 @@
+package otelmapbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+)
+
+// resultKeys are the response fields holding the results of a call, in the
+// order they are looked up.
+var resultKeys = []string{"features", "suggestions", "results", "categories"}
+
+// countResults returns the number of results in a JSON response: the length
+// of its result array, or of the response itself when it is an array. The
+// body is buffered and replaced so that the caller can still read it.
+func countResults(resp *middleware.Response) (int, bool) {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "application/json" || resp.Body == nil {
+		return 0, false
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return 0, false
+	}
+
+	var items []json.RawMessage
+	if json.Unmarshal(body, &items) == nil {
+		return len(items), true
+	}
+
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) != nil {
+		return 0, false
+	}
+	for _, key := range resultKeys {
+		if raw, ok := fields[key]; ok && json.Unmarshal(raw, &items) == nil {
+			return len(items), true
+		}
+	}
+	return 0, false
+}
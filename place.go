@@ -0,0 +1,222 @@
+package mapbox
+
+import (
+	"strings"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	"github.com/pettinz/mapbox-go-sdk/searchbox"
+)
+
+// Sources of a Place.
+const (
+	PlaceSourceGeocoding = "geocoding"
+	PlaceSourceSearchBox = "searchbox"
+)
+
+// Place is a service-independent view of a geocoding or Search Box result,
+// so that application code can handle results from either API the same way.
+type Place struct {
+	// Source is the API the place comes from (one of the PlaceSource*
+	// constants).
+	Source string `json:"source"`
+
+	// MapboxID is the unique Mapbox identifier.
+	MapboxID string `json:"mapbox_id"`
+
+	// FeatureType is the type of feature (e.g., "address", "poi", "place").
+	FeatureType string `json:"feature_type"`
+
+	// Name is the feature's name.
+	Name string `json:"name"`
+
+	// FullAddress is the complete address or place name on one line.
+	FullAddress string `json:"full_address,omitempty"`
+
+	// AddressNumber is the house number (for address features).
+	AddressNumber string `json:"address_number,omitempty"`
+
+	// Street is the street name (for address features).
+	Street string `json:"street,omitempty"`
+
+	// Postcode is the postal code.
+	Postcode string `json:"postcode,omitempty"`
+
+	// Location is the feature's coordinates.
+	Location LngLat `json:"location"`
+
+	// BBox is the bounding box of the feature, if known.
+	BBox *BBox `json:"bbox,omitempty"`
+
+	// Accuracy indicates the precision of the coordinates.
+	Accuracy string `json:"accuracy,omitempty"`
+
+	// Context is the hierarchy of places the feature belongs to.
+	Context PlaceContext `json:"context"`
+
+	// Categories are the POI category names (Search Box only).
+	Categories []string `json:"categories,omitempty"`
+
+	// CategoryIDs are the canonical POI category IDs (Search Box only).
+	CategoryIDs []string `json:"category_ids,omitempty"`
+}
+
+// PlaceContext is the hierarchy of places a Place belongs to. Missing levels
+// are nil.
+type PlaceContext struct {
+	Country      *PlaceContextElement `json:"country,omitempty"`
+	Region       *PlaceContextElement `json:"region,omitempty"`
+	Postcode     *PlaceContextElement `json:"postcode,omitempty"`
+	District     *PlaceContextElement `json:"district,omitempty"`
+	Place        *PlaceContextElement `json:"place,omitempty"`
+	Locality     *PlaceContextElement `json:"locality,omitempty"`
+	Neighborhood *PlaceContextElement `json:"neighborhood,omitempty"`
+	Street       *PlaceContextElement `json:"street,omitempty"`
+}
+
+// PlaceContextElement is a single level of a PlaceContext.
+type PlaceContextElement struct {
+	MapboxID    string `json:"mapbox_id"`
+	Name        string `json:"name"`
+	WikidataID  string `json:"wikidata_id,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+	RegionCode  string `json:"region_code,omitempty"`
+}
+
+// PlaceFromGeocoding converts a geocoding result into a Place.
+func PlaceFromGeocoding(f geocoding.Feature) Place {
+	props := f.Properties
+
+	p := Place{
+		Source:        PlaceSourceGeocoding,
+		MapboxID:      props.MapboxID,
+		FeatureType:   props.FeatureType,
+		Name:          props.Name,
+		FullAddress:   props.PlaceName,
+		AddressNumber: props.AddressNumber,
+		Street:        props.Street,
+		Postcode:      props.Postcode,
+		Location:      props.Coordinates.LngLat(),
+		BBox:          props.BBox,
+		Accuracy:      props.Accuracy,
+	}
+	if props.Coordinates == (geocoding.Coordinates{}) {
+		p.Location = f.Geometry.LngLat()
+	}
+
+	if ctx := props.Context; ctx != nil {
+		convert := func(e *geocoding.ContextElement) *PlaceContextElement {
+			if e == nil {
+				return nil
+			}
+			return &PlaceContextElement{
+				MapboxID:    e.MapboxID,
+				Name:        e.Name,
+				WikidataID:  e.WikidataID,
+				CountryCode: e.CountryCode,
+				RegionCode:  e.RegionCode,
+			}
+		}
+		p.Context = PlaceContext{
+			Country:      convert(ctx.Country),
+			Region:       convert(ctx.Region),
+			Postcode:     convert(ctx.Postcode),
+			District:     convert(ctx.District),
+			Place:        convert(ctx.Place),
+			Locality:     convert(ctx.Locality),
+			Neighborhood: convert(ctx.Neighborhood),
+			Street:       convert(ctx.Street),
+		}
+	}
+
+	return p
+}
+
+// PlaceFromSearchBox converts a Search Box result into a Place.
+func PlaceFromSearchBox(f searchbox.Feature) Place {
+	props := f.Properties
+
+	p := Place{
+		Source:        PlaceSourceSearchBox,
+		MapboxID:      props.MapboxID,
+		FeatureType:   props.FeatureType,
+		Name:          props.Name,
+		FullAddress:   props.FullAddress,
+		AddressNumber: props.AddressNumber,
+		Street:        props.Street,
+		Postcode:      props.Postcode,
+		Location:      props.Coordinates.LngLat(),
+		Accuracy:      props.Accuracy,
+		Categories:    props.POICategory,
+		CategoryIDs:   props.POICategoryIDs,
+	}
+	if props.Coordinates == (searchbox.Coordinates{}) {
+		p.Location = f.Geometry.LngLat()
+	}
+	if p.FullAddress == "" && props.PlaceFormatted != "" {
+		p.FullAddress = props.Name + ", " + props.PlaceFormatted
+	}
+
+	if ctx := props.Context; ctx != nil {
+		convert := func(e *searchbox.ContextElement) *PlaceContextElement {
+			if e == nil {
+				return nil
+			}
+			return &PlaceContextElement{
+				MapboxID:    e.MapboxID,
+				Name:        e.Name,
+				WikidataID:  e.WikidataID,
+				CountryCode: e.CountryCode,
+				RegionCode:  e.RegionCode,
+			}
+		}
+		p.Context = PlaceContext{
+			Country:      convert(ctx.Country),
+			Region:       convert(ctx.Region),
+			Postcode:     convert(ctx.Postcode),
+			District:     convert(ctx.District),
+			Place:        convert(ctx.Place),
+			Locality:     convert(ctx.Locality),
+			Neighborhood: convert(ctx.Neighborhood),
+			Street:       convert(ctx.Street),
+		}
+
+		// Search Box contexts may only carry short codes such as "US-CA".
+		// Fall back to them, as address formatting does, so that both agree.
+		if e := p.Context.Country; e != nil && e.CountryCode == "" {
+			e.CountryCode = codeFromShortCode(ctx.Country.ShortCode)
+		}
+		if e := p.Context.Region; e != nil && e.RegionCode == "" {
+			e.RegionCode = codeFromShortCode(ctx.Region.ShortCode)
+		}
+	}
+
+	return p
+}
+
+// codeFromShortCode returns the last component of a short code, e.g. "CA"
+// for "US-CA", in upper case.
+func codeFromShortCode(code string) string {
+	if i := strings.LastIndex(code, "-"); i >= 0 {
+		code = code[i+1:]
+	}
+	return strings.ToUpper(code)
+}
+
+// PlacesFromGeocoding converts every feature of a geocoding response.
+func PlacesFromGeocoding(resp *geocoding.Response) []Place {
+	places := make([]Place, len(resp.Features))
+	for i, f := range resp.Features {
+		places[i] = PlaceFromGeocoding(f)
+	}
+	return places
+}
+
+// PlacesFromSearchBox converts Search Box features, as returned by Forward,
+// Retrieve, CategorySearch and Reverse.
+func PlacesFromSearchBox(features []searchbox.Feature) []Place {
+	places := make([]Place, len(features))
+	for i, f := range features {
+		places[i] = PlaceFromSearchBox(f)
+	}
+	return places
+}
@@ -0,0 +1,149 @@
+package mapbox
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
+	"github.com/pettinz/mapbox-go-sdk/searchbox"
+)
+
+func decodeFixture[T any](t *testing.T, fixture string) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal([]byte(fixture), &v); err != nil {
+		t.Fatalf("failed to decode fixture: %v", err)
+	}
+	return v
+}
+
+func TestPlaceFromGeocoding(t *testing.T) {
+	forward := decodeFixture[geocoding.Response](t, testutil.ForwardGeocodingResponse).Features[0]
+	noCoordinates := forward
+	noCoordinates.Properties.Coordinates = geocoding.Coordinates{}
+
+	tests := []struct {
+		name            string
+		feature         geocoding.Feature
+		wantFullAddress string
+		wantLocation    LngLat
+		wantRegionCode  string
+		wantCountryCode string
+	}{
+		{
+			name:            "forward result",
+			feature:         forward,
+			wantFullAddress: "1600 Pennsylvania Avenue NW, Washington, District of Columbia 20500, United States",
+			wantLocation:    LngLat{Longitude: -77.036543, Latitude: 38.897676},
+			wantRegionCode:  "DC",
+			wantCountryCode: "US",
+		},
+		{
+			name:            "location from geometry",
+			feature:         noCoordinates,
+			wantFullAddress: "1600 Pennsylvania Avenue NW, Washington, District of Columbia 20500, United States",
+			wantLocation:    LngLat{Longitude: -77.036543, Latitude: 38.897676},
+			wantRegionCode:  "DC",
+			wantCountryCode: "US",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := PlaceFromGeocoding(tt.feature)
+			if p.Source != PlaceSourceGeocoding || p.MapboxID != tt.feature.Properties.MapboxID {
+				t.Errorf("unexpected source %q or ID %q", p.Source, p.MapboxID)
+			}
+			if p.FullAddress != tt.wantFullAddress {
+				t.Errorf("FullAddress = %q, want %q", p.FullAddress, tt.wantFullAddress)
+			}
+			if p.Location != tt.wantLocation {
+				t.Errorf("Location = %v, want %v", p.Location, tt.wantLocation)
+			}
+			if p.Context.Region == nil || p.Context.Region.RegionCode != tt.wantRegionCode {
+				t.Errorf("Region = %+v, want code %q", p.Context.Region, tt.wantRegionCode)
+			}
+			if p.Context.Country == nil || p.Context.Country.CountryCode != tt.wantCountryCode {
+				t.Errorf("Country = %+v, want code %q", p.Context.Country, tt.wantCountryCode)
+			}
+		})
+	}
+}
+
+func TestPlaceFromSearchBox(t *testing.T) {
+	retrieve := decodeFixture[searchbox.RetrieveResponse](t, testutil.SearchBoxRetrieveResponse).Features[0]
+	category := decodeFixture[searchbox.CategorySearchResponse](t, testutil.SearchBoxCategorySearchResponse).Features[0]
+	noCoordinates := decodeFixture[searchbox.ForwardResponse](t, testutil.SearchBoxForwardResponse).Features[0]
+	noCoordinates.Properties.Coordinates = searchbox.Coordinates{}
+
+	tests := []struct {
+		name            string
+		feature         searchbox.Feature
+		wantFullAddress string
+		wantLocation    LngLat
+		wantRegionCode  string
+		wantCountryCode string
+		wantCategories  int
+	}{
+		{
+			name:            "retrieve result with short codes",
+			feature:         retrieve,
+			wantFullAddress: "66 Mint St, San Francisco, CA 94103",
+			wantLocation:    LngLat{Longitude: -122.394447, Latitude: 37.789688},
+			wantRegionCode:  "CA",
+			wantCountryCode: "US",
+			wantCategories:  1,
+		},
+		{
+			name:            "full address from place_formatted",
+			feature:         category,
+			wantFullAddress: "Tartine Bakery, San Francisco, California",
+			wantLocation:    LngLat{Longitude: -122.408226, Latitude: 37.784991},
+			wantCategories:  1,
+		},
+		{
+			name:            "location from geometry",
+			feature:         noCoordinates,
+			wantFullAddress: "Piazza del Colosseo, 1, 00184 Roma RM, Italy",
+			wantLocation:    LngLat{Longitude: 12.496365, Latitude: 41.902916},
+			wantCategories:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := PlaceFromSearchBox(tt.feature)
+			if p.Source != PlaceSourceSearchBox || p.MapboxID != tt.feature.Properties.MapboxID {
+				t.Errorf("unexpected source %q or ID %q", p.Source, p.MapboxID)
+			}
+			if p.FullAddress != tt.wantFullAddress {
+				t.Errorf("FullAddress = %q, want %q", p.FullAddress, tt.wantFullAddress)
+			}
+			if p.Location != tt.wantLocation {
+				t.Errorf("Location = %v, want %v", p.Location, tt.wantLocation)
+			}
+			if len(p.Categories) != tt.wantCategories || len(p.CategoryIDs) != tt.wantCategories {
+				t.Errorf("Categories = %v, CategoryIDs = %v", p.Categories, p.CategoryIDs)
+			}
+			if tt.wantRegionCode != "" && (p.Context.Region == nil || p.Context.Region.RegionCode != tt.wantRegionCode) {
+				t.Errorf("Region = %+v, want code %q", p.Context.Region, tt.wantRegionCode)
+			}
+			if tt.wantCountryCode != "" && (p.Context.Country == nil || p.Context.Country.CountryCode != tt.wantCountryCode) {
+				t.Errorf("Country = %+v, want code %q", p.Context.Country, tt.wantCountryCode)
+			}
+		})
+	}
+}
+
+func TestPlacesFrom(t *testing.T) {
+	geocoded := decodeFixture[geocoding.Response](t, testutil.ForwardGeocodingResponse)
+	if places := PlacesFromGeocoding(&geocoded); len(places) != len(geocoded.Features) || places[0].Name != geocoded.Features[0].Properties.Name {
+		t.Errorf("PlacesFromGeocoding() = %+v", places)
+	}
+
+	searched := decodeFixture[searchbox.RetrieveResponse](t, testutil.SearchBoxRetrieveResponse)
+	if places := PlacesFromSearchBox(searched.Features); len(places) != 1 || places[0].Name != "Blue Bottle Coffee" {
+		t.Errorf("PlacesFromSearchBox() = %+v", places)
+	}
+}
@@ -0,0 +1,22 @@
+package searchbox
+
+import "github.com/pettinz/mapbox-go-sdk/internal/antimeridian"
+
+// Number of results the endpoints return when no limit is set.
+const (
+	defaultSuggestLimit  = 5
+	defaultForwardLimit  = 5
+	defaultCategoryLimit = 10
+)
+
+// mergeFeatures merges the features of split requests by mapbox_id, keeping
+// at most limit, or defaultLimit when limit is nil.
+func mergeFeatures(lists [][]Feature, limit *int, defaultLimit int) []Feature {
+	return antimeridian.Interleave(lists, func(f Feature) string { return f.Properties.MapboxID }, limit, defaultLimit)
+}
+
+// mergeSuggestions merges the suggestions of split requests by mapbox_id,
+// keeping at most limit, or the suggest default when limit is nil.
+func mergeSuggestions(lists [][]Suggestion, limit *int) []Suggestion {
+	return antimeridian.Interleave(lists, func(s Suggestion) string { return s.MapboxID }, limit, defaultSuggestLimit)
+}
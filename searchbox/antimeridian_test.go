@@ -0,0 +1,185 @@
+package searchbox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
+)
+
+// fiji is a bbox around Fiji, which straddles the antimeridian.
+var fiji = geojson.NewBBox(176, -21, -178, -12)
+
+// splitServer serves one response per half of the fiji bbox, built by body
+// from the given mapbox_ids.
+func splitServer(t *testing.T, body func(ids ...string) string) *internalhttp.Client {
+	t.Helper()
+	return splitServerIDs(t, body, []string{"east-1", "shared", "east-2"}, []string{"west-1", "shared"})
+}
+
+// splitServerIDs is splitServer with the mapbox_ids of each half.
+func splitServerIDs(t *testing.T, body func(ids ...string) string, east, west []string) *internalhttp.Client {
+	t.Helper()
+
+	server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch bbox := r.URL.Query().Get("bbox"); bbox {
+		case "176,-21,180,-12":
+			testutil.MockResponse(http.StatusOK, body(east...))(w, r)
+		case "-180,-21,-178,-12":
+			testutil.MockResponse(http.StatusOK, body(west...))(w, r)
+		default:
+			t.Errorf("unexpected bbox %q", bbox)
+			testutil.MockResponse(http.StatusBadRequest, testutil.ValidationErrorResponse)(w, r)
+		}
+	})
+	t.Cleanup(server.Close)
+
+	return internalhttp.New(server.URL, nil)
+}
+
+// numbered returns n mapbox_ids starting with prefix.
+func numbered(prefix string, n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%s-%d", prefix, i+1)
+	}
+	return ids
+}
+
+func featuresBody(ids ...string) string {
+	features := make([]string, len(ids))
+	for i, id := range ids {
+		features[i] = fmt.Sprintf(`{"type":"Feature","id":%q,"geometry":{"type":"Point","coordinates":[178,-18]},"properties":{"mapbox_id":%q,"name":%q}}`, id, id, id)
+	}
+	return fmt.Sprintf(`{"type":"FeatureCollection","features":[%s],"attribution":"test"}`, strings.Join(features, ","))
+}
+
+func suggestionsBody(ids ...string) string {
+	suggestions := make([]string, len(ids))
+	for i, id := range ids {
+		suggestions[i] = fmt.Sprintf(`{"mapbox_id":%q,"name":%q,"feature_type":"poi"}`, id, id)
+	}
+	return fmt.Sprintf(`{"suggestions":[%s],"attribution":"test"}`, strings.Join(suggestions, ","))
+}
+
+func TestService_Suggest_Antimeridian(t *testing.T) {
+	service := New("test-token", splitServer(t, suggestionsBody))
+
+	resp, err := service.Suggest(context.Background(), &SuggestRequest{
+		Query:        "resort",
+		SessionToken: "test-session",
+		BBox:         fiji,
+	})
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+
+	var ids []string
+	for _, s := range resp.Suggestions {
+		ids = append(ids, s.MapboxID)
+	}
+	if expected := []string{"east-1", "west-1", "shared", "east-2"}; fmt.Sprint(ids) != fmt.Sprint(expected) {
+		t.Errorf("merged suggestions = %v, want %v", ids, expected)
+	}
+}
+
+func TestService_Antimeridian_DefaultLimit(t *testing.T) {
+	// Without a limit each half returns up to the endpoint default; the
+	// merged results must not exceed it either.
+	tests := []struct {
+		name         string
+		body         func(ids ...string) string
+		defaultLimit int
+		search       func(*Service) (int, error)
+	}{
+		{
+			name:         "suggest",
+			body:         suggestionsBody,
+			defaultLimit: 5,
+			search: func(s *Service) (int, error) {
+				resp, err := s.Suggest(context.Background(), &SuggestRequest{Query: "resort", SessionToken: "test-session", BBox: fiji})
+				if err != nil {
+					return 0, err
+				}
+				return len(resp.Suggestions), nil
+			},
+		},
+		{
+			name:         "category",
+			body:         featuresBody,
+			defaultLimit: 10,
+			search: func(s *Service) (int, error) {
+				resp, err := s.CategorySearch(context.Background(), &CategorySearchRequest{CategoryID: "hotel", BBox: fiji})
+				if err != nil {
+					return 0, err
+				}
+				return len(resp.Features), nil
+			},
+		},
+		{
+			name:         "forward",
+			body:         featuresBody,
+			defaultLimit: 5,
+			search: func(s *Service) (int, error) {
+				resp, err := s.Forward(context.Background(), &ForwardRequest{Query: "Suva", BBox: fiji})
+				if err != nil {
+					return 0, err
+				}
+				return len(resp.Features), nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := New("test-token", splitServerIDs(t, tt.body, numbered("east", tt.defaultLimit), numbered("west", tt.defaultLimit)))
+
+			got, err := tt.search(service)
+			if err != nil {
+				t.Fatalf("search error = %v", err)
+			}
+			if got != tt.defaultLimit {
+				t.Errorf("expected %d merged results, got %d", tt.defaultLimit, got)
+			}
+		})
+	}
+}
+
+func TestService_CategorySearch_Antimeridian(t *testing.T) {
+	service := New("test-token", splitServer(t, featuresBody))
+
+	resp, err := service.CategorySearch(context.Background(), &CategorySearchRequest{
+		CategoryID: "hotel",
+		BBox:       fiji,
+		Limit:      intPtr(3),
+	})
+	if err != nil {
+		t.Fatalf("CategorySearch() error = %v", err)
+	}
+
+	var ids []string
+	for _, f := range resp.Features {
+		ids = append(ids, f.Properties.MapboxID)
+	}
+	if expected := []string{"east-1", "west-1", "shared"}; fmt.Sprint(ids) != fmt.Sprint(expected) {
+		t.Errorf("merged features = %v, want %v", ids, expected)
+	}
+}
+
+func TestService_Forward_Antimeridian(t *testing.T) {
+	service := New("test-token", splitServer(t, featuresBody))
+
+	resp, err := service.Forward(context.Background(), &ForwardRequest{Query: "Suva", BBox: fiji})
+	if err != nil {
+		t.Fatalf("Forward() error = %v", err)
+	}
+
+	if len(resp.Features) != 4 {
+		t.Errorf("expected 4 merged features, got %d", len(resp.Features))
+	}
+}
@@ -6,29 +6,51 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/internal/antimeridian"
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
 )
 
 // CategorySearch searches for POIs in a specific category.
 // Requires either proximity, bbox, or SAR to define the search area.
 func (s *Service) CategorySearch(ctx context.Context, req *CategorySearchRequest) (*CategorySearchResponse, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.SearchBoxCategory, req)
+
 	if err := validateCategorySearchRequest(req); err != nil {
 		return nil, err
 	}
 
 	// Build path with category ID
 	path := fmt.Sprintf("%s/%s", categorySearchPath, req.CategoryID)
-	query := s.buildCategorySearchQuery(req)
 
-	var result CategorySearchResponse
-	if err := s.httpClient.Get(ctx, path, query, &result); err != nil {
-		return nil, fmt.Errorf("category search failed: %w", err)
+	// A bbox crossing the antimeridian is searched as two requests.
+	var responses []*CategorySearchResponse
+	for _, bbox := range antimeridian.Split(req.BBox) {
+		split := *req
+		split.BBox = bbox
+
+		var result CategorySearchResponse
+		if err := s.httpClient.Get(ctx, path, s.buildCategorySearchQuery(&split), &result); err != nil {
+			return nil, fmt.Errorf("category search failed: %w", err)
+		}
+		responses = append(responses, &result)
+	}
+
+	if len(responses) == 1 {
+		return responses[0], nil
 	}
 
-	return &result, nil
+	merged := *responses[0]
+	merged.Features = mergeFeatures([][]Feature{responses[0].Features, responses[1].Features}, req.Limit, defaultCategoryLimit)
+	return &merged, nil
 }
 
 // ListCategories retrieves all available POI categories.
 func (s *Service) ListCategories(ctx context.Context, req *ListCategoriesRequest) (*ListCategoriesResponse, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.SearchBoxListCategories, req)
+
 	query := s.buildListCategoriesQuery(req)
 
 	var result ListCategoriesResponse
@@ -46,8 +68,8 @@ func validateCategorySearchRequest(req *CategorySearchRequest) error {
 	}
 
 	// Require either proximity, bbox, or SAR
-	hasProximity := req.ProximityIP || len(req.Proximity) == 2
-	hasBBox := len(req.BBox) == 4
+	hasProximity := req.ProximityIP || req.Proximity != nil
+	hasBBox := req.BBox != nil
 	hasSAR := req.SAR != nil && len(req.SAR.Route) > 0
 
 	if !hasProximity && !hasBBox && !hasSAR {
@@ -58,7 +80,19 @@ func validateCategorySearchRequest(req *CategorySearchRequest) error {
 		return fmt.Errorf("limit must be between 1 and 25")
 	}
 
-	return nil
+	if err := validateBias(req.BBox, req.Proximity); err != nil {
+		return err
+	}
+
+	if req.SAR != nil {
+		for i, p := range req.SAR.Route {
+			if err := p.Validate(); err != nil {
+				return fmt.Errorf("invalid route point %d: %w", i, err)
+			}
+		}
+	}
+
+	return validateNavigation(req.Navigation)
 }
 
 // buildCategorySearchQuery builds query parameters for the CategorySearch endpoint.
@@ -69,12 +103,12 @@ func (s *Service) buildCategorySearchQuery(req *CategorySearchRequest) url.Value
 	// Proximity (can be coordinates or "ip")
 	if req.ProximityIP {
 		q.Set("proximity", "ip")
-	} else if len(req.Proximity) == 2 {
-		q.Set("proximity", formatFloatArray(req.Proximity))
+	} else if req.Proximity != nil {
+		q.Set("proximity", req.Proximity.String())
 	}
 
-	if len(req.BBox) == 4 {
-		q.Set("bbox", formatFloatArray(req.BBox))
+	if req.BBox != nil {
+		q.Set("bbox", req.BBox.String())
 	}
 
 	if len(req.Country) > 0 {
@@ -131,12 +165,10 @@ func addSARParams(q url.Values, sar *SAROptions) {
 
 // encodeRoute encodes a route as a semicolon-separated list of coordinate pairs.
 // Format: "lon1,lat1;lon2,lat2;..."
-func encodeRoute(route [][]float64) string {
+func encodeRoute(route []geojson.LngLat) string {
 	parts := make([]string, len(route))
-	for i, coord := range route {
-		if len(coord) >= 2 {
-			parts[i] = fmt.Sprintf("%f,%f", coord[0], coord[1])
-		}
+	for i, p := range route {
+		parts[i] = fmt.Sprintf("%f,%f", p.Longitude, p.Latitude)
 	}
 	return strings.Join(parts, ";")
 }
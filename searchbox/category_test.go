@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/pettinz/mapbox-go-sdk/geojson"
 	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
 	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
 )
@@ -22,7 +23,7 @@ func TestService_CategorySearch(t *testing.T) {
 			name: "successful category search with proximity",
 			request: &CategorySearchRequest{
 				CategoryID: "restaurant",
-				Proximity:  []float64{-122.4194, 37.7749},
+				Proximity:  &geojson.LngLat{Longitude: -122.4194, Latitude: 37.7749},
 				Limit:      intPtr(10),
 			},
 			mockStatus:   http.StatusOK,
@@ -41,7 +42,7 @@ func TestService_CategorySearch(t *testing.T) {
 			name: "successful category search with bbox",
 			request: &CategorySearchRequest{
 				CategoryID: "coffee_shop",
-				BBox:       []float64{-122.5, 37.7, -122.3, 37.8},
+				BBox:       geojson.NewBBox(-122.5, 37.7, -122.3, 37.8),
 				Limit:      intPtr(25),
 			},
 			mockStatus:   http.StatusOK,
@@ -62,10 +63,10 @@ func TestService_CategorySearch(t *testing.T) {
 			name: "category search with navigation",
 			request: &CategorySearchRequest{
 				CategoryID: "gas_station",
-				Proximity:  []float64{-122.4194, 37.7749},
+				Proximity:  &geojson.LngLat{Longitude: -122.4194, Latitude: 37.7749},
 				Navigation: &NavigationOptions{
 					ETAType: "navigation",
-					Origin:  []float64{-122.4, 37.8},
+					Origin:  &geojson.LngLat{Longitude: -122.4, Latitude: 37.8},
 					Profile: "driving",
 				},
 			},
@@ -79,9 +80,9 @@ func TestService_CategorySearch(t *testing.T) {
 				CategoryID: "restaurant",
 				SAR: &SAROptions{
 					Type: "isochrone",
-					Route: [][]float64{
-						{-122.4, 37.8},
-						{-122.5, 37.7},
+					Route: []geojson.LngLat{
+						{Longitude: -122.4, Latitude: 37.8},
+						{Longitude: -122.5, Latitude: 37.7},
 					},
 					TimeDeviation: intPtr(300),
 				},
@@ -93,7 +94,7 @@ func TestService_CategorySearch(t *testing.T) {
 		{
 			name: "missing category ID",
 			request: &CategorySearchRequest{
-				Proximity: []float64{-122.4194, 37.7749},
+				Proximity: &geojson.LngLat{Longitude: -122.4194, Latitude: 37.7749},
 			},
 			mockStatus:   http.StatusOK,
 			mockResponse: "{}",
@@ -112,18 +113,52 @@ func TestService_CategorySearch(t *testing.T) {
 			name: "limit out of range",
 			request: &CategorySearchRequest{
 				CategoryID: "restaurant",
-				Proximity:  []float64{-122.4194, 37.7749},
+				Proximity:  &geojson.LngLat{Longitude: -122.4194, Latitude: 37.7749},
 				Limit:      intPtr(26),
 			},
 			mockStatus:   http.StatusOK,
 			mockResponse: "{}",
 			wantErr:      true,
 		},
+		{
+			name: "swapped bbox edges",
+			request: &CategorySearchRequest{
+				CategoryID: "restaurant",
+				BBox:       geojson.NewBBox(-122.5, 37.8, -122.3, 37.7),
+			},
+			mockStatus:   http.StatusOK,
+			mockResponse: "{}",
+			wantErr:      true,
+		},
+		{
+			name: "invalid route point",
+			request: &CategorySearchRequest{
+				CategoryID: "restaurant",
+				SAR: &SAROptions{
+					Type:  "isochrone",
+					Route: []geojson.LngLat{{Longitude: 37.8, Latitude: -122.4}},
+				},
+			},
+			mockStatus:   http.StatusOK,
+			mockResponse: "{}",
+			wantErr:      true,
+		},
+		{
+			name: "invalid navigation origin",
+			request: &CategorySearchRequest{
+				CategoryID: "restaurant",
+				Proximity:  &geojson.LngLat{Longitude: -122.4194, Latitude: 37.7749},
+				Navigation: &NavigationOptions{Origin: &geojson.LngLat{Longitude: 200}},
+			},
+			mockStatus:   http.StatusOK,
+			mockResponse: "{}",
+			wantErr:      true,
+		},
 		{
 			name: "API error",
 			request: &CategorySearchRequest{
 				CategoryID: "invalid",
-				Proximity:  []float64{-122.4194, 37.7749},
+				Proximity:  &geojson.LngLat{Longitude: -122.4194, Latitude: 37.7749},
 			},
 			mockStatus:   http.StatusNotFound,
 			mockResponse: testutil.NotFoundErrorResponse,
@@ -230,21 +265,21 @@ func TestBuildCategorySearchQuery(t *testing.T) {
 
 	req := &CategorySearchRequest{
 		CategoryID: "restaurant",
-		Proximity:  []float64{-122.4194, 37.7749},
-		BBox:       []float64{-122.5, 37.7, -122.3, 37.8},
+		Proximity:  &geojson.LngLat{Longitude: -122.4194, Latitude: 37.7749},
+		BBox:       geojson.NewBBox(-122.5, 37.7, -122.3, 37.8),
 		Country:    []string{"US"},
 		Language:   "en",
 		Limit:      intPtr(20),
 		Navigation: &NavigationOptions{
 			ETAType: "navigation",
-			Origin:  []float64{-122.4, 37.8},
+			Origin:  &geojson.LngLat{Longitude: -122.4, Latitude: 37.8},
 			Profile: "walking",
 		},
 		SAR: &SAROptions{
 			Type: "isochrone",
-			Route: [][]float64{
-				{-122.4, 37.8},
-				{-122.5, 37.7},
+			Route: []geojson.LngLat{
+				{Longitude: -122.4, Latitude: 37.8},
+				{Longitude: -122.5, Latitude: 37.7},
 			},
 			TimeDeviation: intPtr(300),
 		},
@@ -281,10 +316,10 @@ func TestBuildCategorySearchQuery(t *testing.T) {
 }
 
 func TestEncodeRoute(t *testing.T) {
-	route := [][]float64{
-		{-122.4194, 37.7749},
-		{-122.5, 37.7},
-		{-122.3, 37.8},
+	route := []geojson.LngLat{
+		{Longitude: -122.4194, Latitude: 37.7749},
+		{Longitude: -122.5, Latitude: 37.7},
+		{Longitude: -122.3, Latitude: 37.8},
 	}
 
 	encoded := encodeRoute(route)
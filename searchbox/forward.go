@@ -6,24 +6,42 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+
+	"github.com/pettinz/mapbox-go-sdk/internal/antimeridian"
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
 )
 
 // Forward performs a one-off text search with immediate results including coordinates.
 // Unlike Suggest/Retrieve, this does not require a session token and returns full
 // GeoJSON features in a single request. Use this for simple search without autocomplete.
 func (s *Service) Forward(ctx context.Context, req *ForwardRequest) (*ForwardResponse, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.SearchBoxForward, req)
+
 	if err := validateForwardRequest(req); err != nil {
 		return nil, err
 	}
 
-	query := s.buildForwardQuery(req)
+	// A bbox crossing the antimeridian is searched as two requests.
+	var responses []*ForwardResponse
+	for _, bbox := range antimeridian.Split(req.BBox) {
+		split := *req
+		split.BBox = bbox
+
+		var result ForwardResponse
+		if err := s.httpClient.Get(ctx, forwardPath, s.buildForwardQuery(&split), &result); err != nil {
+			return nil, fmt.Errorf("forward search failed: %w", err)
+		}
+		responses = append(responses, &result)
+	}
 
-	var result ForwardResponse
-	if err := s.httpClient.Get(ctx, forwardPath, query, &result); err != nil {
-		return nil, fmt.Errorf("forward search failed: %w", err)
+	if len(responses) == 1 {
+		return responses[0], nil
 	}
 
-	return &result, nil
+	merged := *responses[0]
+	merged.Features = mergeFeatures([][]Feature{responses[0].Features, responses[1].Features}, req.Limit, defaultForwardLimit)
+	return &merged, nil
 }
 
 // validateForwardRequest validates the Forward request parameters.
@@ -40,7 +58,11 @@ func validateForwardRequest(req *ForwardRequest) error {
 		return fmt.Errorf("limit must be between 1 and 10")
 	}
 
-	return nil
+	if err := validateBias(req.BBox, req.Proximity); err != nil {
+		return err
+	}
+
+	return validateNavigation(req.Navigation)
 }
 
 // buildForwardQuery builds query parameters for the Forward endpoint.
@@ -56,12 +78,12 @@ func (s *Service) buildForwardQuery(req *ForwardRequest) url.Values {
 	// Proximity (can be coordinates or "ip")
 	if req.ProximityIP {
 		q.Set("proximity", "ip")
-	} else if len(req.Proximity) == 2 {
-		q.Set("proximity", formatFloatArray(req.Proximity))
+	} else if req.Proximity != nil {
+		q.Set("proximity", req.Proximity.String())
 	}
 
-	if len(req.BBox) == 4 {
-		q.Set("bbox", formatFloatArray(req.BBox))
+	if req.BBox != nil {
+		q.Set("bbox", req.BBox.String())
 	}
 
 	if len(req.Country) > 0 {
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pettinz/mapbox-go-sdk/geojson"
 	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
 	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
 )
@@ -41,7 +42,7 @@ func TestService_Forward(t *testing.T) {
 					if feature.Properties.Name == "" {
 						t.Error("expected non-empty name")
 					}
-					if feature.Geometry.Coordinates[0] == 0 && feature.Geometry.Coordinates[1] == 0 {
+					if feature.Geometry.Coordinates == (geojson.LngLat{}) {
 						t.Error("expected non-zero coordinates")
 					}
 				}
@@ -52,8 +53,8 @@ func TestService_Forward(t *testing.T) {
 			request: &ForwardRequest{
 				Query:        "restaurant",
 				Autocomplete: boolPtr(true),
-				Proximity:    []float64{-122.4194, 37.7749},
-				BBox:         []float64{-122.5, 37.7, -122.3, 37.8},
+				Proximity:    &geojson.LngLat{Longitude: -122.4194, Latitude: 37.7749},
+				BBox:         geojson.NewBBox(-122.5, 37.7, -122.3, 37.8),
 				Country:      []string{"US"},
 				Language:     "en",
 				Limit:        intPtr(10),
@@ -61,7 +62,7 @@ func TestService_Forward(t *testing.T) {
 				POICategory:  []string{"restaurant"},
 				Navigation: &NavigationOptions{
 					ETAType: "navigation",
-					Origin:  []float64{-122.4, 37.8},
+					Origin:  &geojson.LngLat{Longitude: -122.4, Latitude: 37.8},
 					Profile: "walking",
 				},
 			},
@@ -156,8 +157,8 @@ func TestBuildForwardQuery(t *testing.T) {
 	req := &ForwardRequest{
 		Query:        "restaurant",
 		Autocomplete: boolPtr(false),
-		Proximity:    []float64{-122.4194, 37.7749},
-		BBox:         []float64{-122.5, 37.7, -122.3, 37.8},
+		Proximity:    &geojson.LngLat{Longitude: -122.4194, Latitude: 37.7749},
+		BBox:         geojson.NewBBox(-122.5, 37.7, -122.3, 37.8),
 		Country:      []string{"US", "CA"},
 		Language:     "en",
 		Limit:        intPtr(10),
@@ -165,7 +166,7 @@ func TestBuildForwardQuery(t *testing.T) {
 		POICategory:  []string{"restaurant", "cafe"},
 		Navigation: &NavigationOptions{
 			ETAType: "navigation",
-			Origin:  []float64{-122.4, 37.8},
+			Origin:  &geojson.LngLat{Longitude: -122.4, Latitude: 37.8},
 			Profile: "driving",
 		},
 	}
@@ -0,0 +1,96 @@
+package searchbox
+
+import (
+	"encoding/json"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// ToGeoJSON converts the feature to an RFC 7946 feature with flattened
+// properties. A nil opts keeps every property.
+func (f Feature) ToGeoJSON(opts *geojson.ExportOptions) (*geojson.Feature, error) {
+	location := f.Geometry.Coordinates
+	if location == (geojson.LngLat{}) {
+		location = f.Properties.Coordinates.LngLat()
+	}
+
+	properties, err := geojson.FlattenProperties(f.Properties, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	out := geojson.NewFeature(location.Point())
+	out.Properties = properties
+	out.ID = f.ID
+	if f.ID == "" {
+		out.ID = f.Properties.MapboxID
+	}
+	return out, nil
+}
+
+// ToGeoJSON converts the response to an RFC 7946 feature collection.
+func (r *ForwardResponse) ToGeoJSON(opts *geojson.ExportOptions) (*geojson.FeatureCollection, error) {
+	return featuresToGeoJSON(r.Features, opts)
+}
+
+// MarshalFeatureCollection encodes the response as RFC 7946 GeoJSON.
+func (r *ForwardResponse) MarshalFeatureCollection(opts *geojson.ExportOptions) ([]byte, error) {
+	return marshalFeatures(r.Features, opts)
+}
+
+// ToGeoJSON converts the response to an RFC 7946 feature collection.
+func (r *CategorySearchResponse) ToGeoJSON(opts *geojson.ExportOptions) (*geojson.FeatureCollection, error) {
+	return featuresToGeoJSON(r.Features, opts)
+}
+
+// MarshalFeatureCollection encodes the response as RFC 7946 GeoJSON.
+func (r *CategorySearchResponse) MarshalFeatureCollection(opts *geojson.ExportOptions) ([]byte, error) {
+	return marshalFeatures(r.Features, opts)
+}
+
+// ToGeoJSON converts the response to an RFC 7946 feature collection.
+func (r *ReverseResponse) ToGeoJSON(opts *geojson.ExportOptions) (*geojson.FeatureCollection, error) {
+	return featuresToGeoJSON(r.Features, opts)
+}
+
+// MarshalFeatureCollection encodes the response as RFC 7946 GeoJSON.
+func (r *ReverseResponse) MarshalFeatureCollection(opts *geojson.ExportOptions) ([]byte, error) {
+	return marshalFeatures(r.Features, opts)
+}
+
+// ToGeoJSON converts the response to an RFC 7946 feature collection.
+func (r *RetrieveResponse) ToGeoJSON(opts *geojson.ExportOptions) (*geojson.FeatureCollection, error) {
+	return featuresToGeoJSON(r.Features, opts)
+}
+
+// MarshalFeatureCollection encodes the response as RFC 7946 GeoJSON.
+func (r *RetrieveResponse) MarshalFeatureCollection(opts *geojson.ExportOptions) ([]byte, error) {
+	return marshalFeatures(r.Features, opts)
+}
+
+// featuresToGeoJSON converts features to a collection, computing bboxes when
+// opts asks for them.
+func featuresToGeoJSON(features []Feature, opts *geojson.ExportOptions) (*geojson.FeatureCollection, error) {
+	fc := geojson.NewFeatureCollection()
+	for _, f := range features {
+		feature, err := f.ToGeoJSON(opts)
+		if err != nil {
+			return nil, err
+		}
+		fc.Features = append(fc.Features, feature)
+	}
+
+	if opts != nil && opts.BBox {
+		fc.ComputeBBox()
+	}
+	return fc, nil
+}
+
+// marshalFeatures encodes features as a GeoJSON feature collection.
+func marshalFeatures(features []Feature, opts *geojson.ExportOptions) ([]byte, error) {
+	fc, err := featuresToGeoJSON(features, opts)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fc)
+}
@@ -0,0 +1,116 @@
+package searchbox
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
+)
+
+func TestResponses_ToGeoJSON(t *testing.T) {
+	type exporter interface {
+		ToGeoJSON(*geojson.ExportOptions) (*geojson.FeatureCollection, error)
+		MarshalFeatureCollection(*geojson.ExportOptions) ([]byte, error)
+	}
+
+	tests := []struct {
+		name    string
+		fixture string
+		resp    exporter
+	}{
+		{name: "forward", fixture: testutil.SearchBoxForwardResponse, resp: &ForwardResponse{}},
+		{name: "category search", fixture: testutil.SearchBoxCategorySearchResponse, resp: &CategorySearchResponse{}},
+		{name: "reverse", fixture: testutil.SearchBoxReverseResponse, resp: &ReverseResponse{}},
+		{name: "retrieve", fixture: testutil.SearchBoxRetrieveResponse, resp: &RetrieveResponse{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(tt.fixture), tt.resp); err != nil {
+				t.Fatal(err)
+			}
+
+			fc, err := tt.resp.ToGeoJSON(&geojson.ExportOptions{BBox: true})
+			if err != nil {
+				t.Fatalf("ToGeoJSON() error = %v", err)
+			}
+			if len(fc.Features) == 0 {
+				t.Fatal("expected features")
+			}
+			for _, f := range fc.Features {
+				if _, ok := f.Geometry.(*geojson.Point); !ok {
+					t.Errorf("expected point geometry, got %T", f.Geometry)
+				}
+				if f.Properties["mapbox_id"] == nil || len(f.BBox) != 4 {
+					t.Errorf("unexpected feature %+v", f)
+				}
+			}
+			if len(fc.BBox) != 4 {
+				t.Errorf("expected collection bbox, got %v", fc.BBox)
+			}
+
+			data, err := tt.resp.MarshalFeatureCollection(nil)
+			if err != nil {
+				t.Fatalf("MarshalFeatureCollection() error = %v", err)
+			}
+			var decoded geojson.FeatureCollection
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Errorf("output is not valid GeoJSON: %v", err)
+			}
+		})
+	}
+}
+
+func TestFeature_ToGeoJSON(t *testing.T) {
+	var resp ForwardResponse
+	if err := json.Unmarshal([]byte(testutil.SearchBoxForwardResponse), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := resp.Features[0].ToGeoJSON(&geojson.ExportOptions{
+		Include: []string{"name", "poi_category", "coordinates"},
+		Omit:    []string{"coordinates.latitude"},
+		Rename:  map[string]string{"poi_category": "categories"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]any{
+		"name":                  "Colosseum",
+		"categories":            []any{"historic_site", "landmark"},
+		"coordinates.longitude": 12.496365,
+	}
+	if !reflect.DeepEqual(f.Properties, want) {
+		t.Errorf("Properties = %v, want %v", f.Properties, want)
+	}
+	if f.ID != "poi.789012" {
+		t.Errorf("expected feature ID, got %v", f.ID)
+	}
+}
+
+func TestGeometry_JSON(t *testing.T) {
+	var resp RetrieveResponse
+	if err := json.Unmarshal([]byte(testutil.SearchBoxRetrieveResponse), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	want := geojson.LngLat{Longitude: -122.394447, Latitude: 37.789688}
+	f := resp.Features[0]
+	if f.Geometry.Coordinates != want {
+		t.Errorf("Geometry.Coordinates = %v, want %v", f.Geometry.Coordinates, want)
+	}
+	if len(f.Properties.RoutablePoints) != 1 || f.Properties.RoutablePoints[0].Coordinates != want {
+		t.Errorf("unexpected routable points %+v", f.Properties.RoutablePoints)
+	}
+
+	out, err := json.Marshal(f.Properties.RoutablePoints[0])
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if got := string(out); got != `{"name":"main entrance","coordinates":[-122.394447,37.789688]}` {
+		t.Errorf("Marshal() = %s", got)
+	}
+}
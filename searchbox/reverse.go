@@ -6,10 +6,15 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
 )
 
 // Reverse performs reverse geocoding, converting coordinates into place names.
 func (s *Service) Reverse(ctx context.Context, req *ReverseRequest) (*ReverseResponse, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.SearchBoxReverse, req)
+
 	if err := validateReverseRequest(req); err != nil {
 		return nil, err
 	}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/pettinz/mapbox-go-sdk/geojson"
 	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
 	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
 )
@@ -39,7 +40,7 @@ func TestService_Reverse(t *testing.T) {
 					if feature.Properties.Name == "" {
 						t.Error("expected non-empty name")
 					}
-					if feature.Geometry.Coordinates[0] == 0 && feature.Geometry.Coordinates[1] == 0 {
+					if feature.Geometry.Coordinates == (geojson.LngLat{}) {
 						t.Error("expected non-zero coordinates")
 					}
 				}
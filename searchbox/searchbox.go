@@ -1,6 +1,8 @@
 package searchbox
 
 import (
+	"context"
+
 	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
 )
 
@@ -14,6 +16,20 @@ const (
 	reversePath        = "/search/searchbox/v1/reverse"
 )
 
+// API is the set of Search Box API calls. *Service implements it; accept an
+// API in your own code to substitute a fake, such as the one in the
+// searchboxfakes package, in unit tests.
+type API interface {
+	Suggest(ctx context.Context, req *SuggestRequest) (*SuggestResponse, error)
+	Retrieve(ctx context.Context, req *RetrieveRequest) (*RetrieveResponse, error)
+	Forward(ctx context.Context, req *ForwardRequest) (*ForwardResponse, error)
+	CategorySearch(ctx context.Context, req *CategorySearchRequest) (*CategorySearchResponse, error)
+	ListCategories(ctx context.Context, req *ListCategoriesRequest) (*ListCategoriesResponse, error)
+	Reverse(ctx context.Context, req *ReverseRequest) (*ReverseResponse, error)
+}
+
+var _ API = (*Service)(nil)
+
 // Service provides access to the Mapbox Search Box API.
 type Service struct {
 	token      string
@@ -0,0 +1,577 @@
+// Package searchboxfakes provides a fake searchbox.API for unit tests. FakeAPI
+// records every call and returns canned responses, so code that accepts a
+// searchbox.API can be tested without a server:
+//
+//	fake := new(searchboxfakes.FakeAPI)
+//	fake.SuggestReturns(&searchbox.SuggestResponse{}, nil)
+//	...
+//	if fake.SuggestCallCount() != 1 {
+//		t.Error("expected one call")
+//	}
+package searchboxfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pettinz/mapbox-go-sdk/searchbox"
+)
+
+// FakeAPI is a searchbox.API that records calls and returns canned
+// responses. For each method M:
+//
+//   - MStub, set directly or with MCalls, is called and its results are
+//     returned.
+//   - MReturnsOnCall and MReturns set the results of a given call or of
+//     every call. Without either, M returns zero values.
+//   - MCallCount and MArgsForCall report the calls received.
+//
+// A FakeAPI is safe for concurrent use; the zero value is ready to use.
+type FakeAPI struct {
+	SuggestStub        func(context.Context, *searchbox.SuggestRequest) (*searchbox.SuggestResponse, error)
+	suggestMutex       sync.RWMutex
+	suggestArgsForCall []struct {
+		ctx context.Context
+		req *searchbox.SuggestRequest
+	}
+	suggestReturns struct {
+		result *searchbox.SuggestResponse
+		err    error
+	}
+	suggestReturnsOnCall map[int]struct {
+		result *searchbox.SuggestResponse
+		err    error
+	}
+
+	RetrieveStub        func(context.Context, *searchbox.RetrieveRequest) (*searchbox.RetrieveResponse, error)
+	retrieveMutex       sync.RWMutex
+	retrieveArgsForCall []struct {
+		ctx context.Context
+		req *searchbox.RetrieveRequest
+	}
+	retrieveReturns struct {
+		result *searchbox.RetrieveResponse
+		err    error
+	}
+	retrieveReturnsOnCall map[int]struct {
+		result *searchbox.RetrieveResponse
+		err    error
+	}
+
+	ForwardStub        func(context.Context, *searchbox.ForwardRequest) (*searchbox.ForwardResponse, error)
+	forwardMutex       sync.RWMutex
+	forwardArgsForCall []struct {
+		ctx context.Context
+		req *searchbox.ForwardRequest
+	}
+	forwardReturns struct {
+		result *searchbox.ForwardResponse
+		err    error
+	}
+	forwardReturnsOnCall map[int]struct {
+		result *searchbox.ForwardResponse
+		err    error
+	}
+
+	CategorySearchStub        func(context.Context, *searchbox.CategorySearchRequest) (*searchbox.CategorySearchResponse, error)
+	categorySearchMutex       sync.RWMutex
+	categorySearchArgsForCall []struct {
+		ctx context.Context
+		req *searchbox.CategorySearchRequest
+	}
+	categorySearchReturns struct {
+		result *searchbox.CategorySearchResponse
+		err    error
+	}
+	categorySearchReturnsOnCall map[int]struct {
+		result *searchbox.CategorySearchResponse
+		err    error
+	}
+
+	ListCategoriesStub        func(context.Context, *searchbox.ListCategoriesRequest) (*searchbox.ListCategoriesResponse, error)
+	listCategoriesMutex       sync.RWMutex
+	listCategoriesArgsForCall []struct {
+		ctx context.Context
+		req *searchbox.ListCategoriesRequest
+	}
+	listCategoriesReturns struct {
+		result *searchbox.ListCategoriesResponse
+		err    error
+	}
+	listCategoriesReturnsOnCall map[int]struct {
+		result *searchbox.ListCategoriesResponse
+		err    error
+	}
+
+	ReverseStub        func(context.Context, *searchbox.ReverseRequest) (*searchbox.ReverseResponse, error)
+	reverseMutex       sync.RWMutex
+	reverseArgsForCall []struct {
+		ctx context.Context
+		req *searchbox.ReverseRequest
+	}
+	reverseReturns struct {
+		result *searchbox.ReverseResponse
+		err    error
+	}
+	reverseReturnsOnCall map[int]struct {
+		result *searchbox.ReverseResponse
+		err    error
+	}
+
+	invocationsMutex sync.RWMutex
+	invocations      map[string][][]any
+}
+
+// Suggest implements searchbox.API.
+func (fake *FakeAPI) Suggest(ctx context.Context, req *searchbox.SuggestRequest) (*searchbox.SuggestResponse, error) {
+	fake.suggestMutex.Lock()
+	ret, specificReturn := fake.suggestReturnsOnCall[len(fake.suggestArgsForCall)]
+	fake.suggestArgsForCall = append(fake.suggestArgsForCall, struct {
+		ctx context.Context
+		req *searchbox.SuggestRequest
+	}{ctx, req})
+	stub := fake.SuggestStub
+	fakeReturns := fake.suggestReturns
+	fake.recordInvocation("Suggest", []any{ctx, req})
+	fake.suggestMutex.Unlock()
+	if stub != nil {
+		return stub(ctx, req)
+	}
+	if specificReturn {
+		return ret.result, ret.err
+	}
+	return fakeReturns.result, fakeReturns.err
+}
+
+// SuggestCallCount returns the number of calls to Suggest.
+func (fake *FakeAPI) SuggestCallCount() int {
+	fake.suggestMutex.RLock()
+	defer fake.suggestMutex.RUnlock()
+	return len(fake.suggestArgsForCall)
+}
+
+// SuggestCalls sets the stub called by Suggest.
+func (fake *FakeAPI) SuggestCalls(stub func(context.Context, *searchbox.SuggestRequest) (*searchbox.SuggestResponse, error)) {
+	fake.suggestMutex.Lock()
+	defer fake.suggestMutex.Unlock()
+	fake.SuggestStub = stub
+}
+
+// SuggestArgsForCall returns the arguments of the i-th call to Suggest.
+func (fake *FakeAPI) SuggestArgsForCall(i int) (context.Context, *searchbox.SuggestRequest) {
+	fake.suggestMutex.RLock()
+	defer fake.suggestMutex.RUnlock()
+	args := fake.suggestArgsForCall[i]
+	return args.ctx, args.req
+}
+
+// SuggestReturns sets the results of every call to Suggest.
+func (fake *FakeAPI) SuggestReturns(result *searchbox.SuggestResponse, err error) {
+	fake.suggestMutex.Lock()
+	defer fake.suggestMutex.Unlock()
+	fake.SuggestStub = nil
+	fake.suggestReturns = struct {
+		result *searchbox.SuggestResponse
+		err    error
+	}{result, err}
+}
+
+// SuggestReturnsOnCall sets the results of the i-th call to Suggest, counting
+// from zero.
+func (fake *FakeAPI) SuggestReturnsOnCall(i int, result *searchbox.SuggestResponse, err error) {
+	fake.suggestMutex.Lock()
+	defer fake.suggestMutex.Unlock()
+	fake.SuggestStub = nil
+	if fake.suggestReturnsOnCall == nil {
+		fake.suggestReturnsOnCall = make(map[int]struct {
+			result *searchbox.SuggestResponse
+			err    error
+		})
+	}
+	fake.suggestReturnsOnCall[i] = struct {
+		result *searchbox.SuggestResponse
+		err    error
+	}{result, err}
+}
+
+// Retrieve implements searchbox.API.
+func (fake *FakeAPI) Retrieve(ctx context.Context, req *searchbox.RetrieveRequest) (*searchbox.RetrieveResponse, error) {
+	fake.retrieveMutex.Lock()
+	ret, specificReturn := fake.retrieveReturnsOnCall[len(fake.retrieveArgsForCall)]
+	fake.retrieveArgsForCall = append(fake.retrieveArgsForCall, struct {
+		ctx context.Context
+		req *searchbox.RetrieveRequest
+	}{ctx, req})
+	stub := fake.RetrieveStub
+	fakeReturns := fake.retrieveReturns
+	fake.recordInvocation("Retrieve", []any{ctx, req})
+	fake.retrieveMutex.Unlock()
+	if stub != nil {
+		return stub(ctx, req)
+	}
+	if specificReturn {
+		return ret.result, ret.err
+	}
+	return fakeReturns.result, fakeReturns.err
+}
+
+// RetrieveCallCount returns the number of calls to Retrieve.
+func (fake *FakeAPI) RetrieveCallCount() int {
+	fake.retrieveMutex.RLock()
+	defer fake.retrieveMutex.RUnlock()
+	return len(fake.retrieveArgsForCall)
+}
+
+// RetrieveCalls sets the stub called by Retrieve.
+func (fake *FakeAPI) RetrieveCalls(stub func(context.Context, *searchbox.RetrieveRequest) (*searchbox.RetrieveResponse, error)) {
+	fake.retrieveMutex.Lock()
+	defer fake.retrieveMutex.Unlock()
+	fake.RetrieveStub = stub
+}
+
+// RetrieveArgsForCall returns the arguments of the i-th call to Retrieve.
+func (fake *FakeAPI) RetrieveArgsForCall(i int) (context.Context, *searchbox.RetrieveRequest) {
+	fake.retrieveMutex.RLock()
+	defer fake.retrieveMutex.RUnlock()
+	args := fake.retrieveArgsForCall[i]
+	return args.ctx, args.req
+}
+
+// RetrieveReturns sets the results of every call to Retrieve.
+func (fake *FakeAPI) RetrieveReturns(result *searchbox.RetrieveResponse, err error) {
+	fake.retrieveMutex.Lock()
+	defer fake.retrieveMutex.Unlock()
+	fake.RetrieveStub = nil
+	fake.retrieveReturns = struct {
+		result *searchbox.RetrieveResponse
+		err    error
+	}{result, err}
+}
+
+// RetrieveReturnsOnCall sets the results of the i-th call to Retrieve, counting
+// from zero.
+func (fake *FakeAPI) RetrieveReturnsOnCall(i int, result *searchbox.RetrieveResponse, err error) {
+	fake.retrieveMutex.Lock()
+	defer fake.retrieveMutex.Unlock()
+	fake.RetrieveStub = nil
+	if fake.retrieveReturnsOnCall == nil {
+		fake.retrieveReturnsOnCall = make(map[int]struct {
+			result *searchbox.RetrieveResponse
+			err    error
+		})
+	}
+	fake.retrieveReturnsOnCall[i] = struct {
+		result *searchbox.RetrieveResponse
+		err    error
+	}{result, err}
+}
+
+// Forward implements searchbox.API.
+func (fake *FakeAPI) Forward(ctx context.Context, req *searchbox.ForwardRequest) (*searchbox.ForwardResponse, error) {
+	fake.forwardMutex.Lock()
+	ret, specificReturn := fake.forwardReturnsOnCall[len(fake.forwardArgsForCall)]
+	fake.forwardArgsForCall = append(fake.forwardArgsForCall, struct {
+		ctx context.Context
+		req *searchbox.ForwardRequest
+	}{ctx, req})
+	stub := fake.ForwardStub
+	fakeReturns := fake.forwardReturns
+	fake.recordInvocation("Forward", []any{ctx, req})
+	fake.forwardMutex.Unlock()
+	if stub != nil {
+		return stub(ctx, req)
+	}
+	if specificReturn {
+		return ret.result, ret.err
+	}
+	return fakeReturns.result, fakeReturns.err
+}
+
+// ForwardCallCount returns the number of calls to Forward.
+func (fake *FakeAPI) ForwardCallCount() int {
+	fake.forwardMutex.RLock()
+	defer fake.forwardMutex.RUnlock()
+	return len(fake.forwardArgsForCall)
+}
+
+// ForwardCalls sets the stub called by Forward.
+func (fake *FakeAPI) ForwardCalls(stub func(context.Context, *searchbox.ForwardRequest) (*searchbox.ForwardResponse, error)) {
+	fake.forwardMutex.Lock()
+	defer fake.forwardMutex.Unlock()
+	fake.ForwardStub = stub
+}
+
+// ForwardArgsForCall returns the arguments of the i-th call to Forward.
+func (fake *FakeAPI) ForwardArgsForCall(i int) (context.Context, *searchbox.ForwardRequest) {
+	fake.forwardMutex.RLock()
+	defer fake.forwardMutex.RUnlock()
+	args := fake.forwardArgsForCall[i]
+	return args.ctx, args.req
+}
+
+// ForwardReturns sets the results of every call to Forward.
+func (fake *FakeAPI) ForwardReturns(result *searchbox.ForwardResponse, err error) {
+	fake.forwardMutex.Lock()
+	defer fake.forwardMutex.Unlock()
+	fake.ForwardStub = nil
+	fake.forwardReturns = struct {
+		result *searchbox.ForwardResponse
+		err    error
+	}{result, err}
+}
+
+// ForwardReturnsOnCall sets the results of the i-th call to Forward, counting
+// from zero.
+func (fake *FakeAPI) ForwardReturnsOnCall(i int, result *searchbox.ForwardResponse, err error) {
+	fake.forwardMutex.Lock()
+	defer fake.forwardMutex.Unlock()
+	fake.ForwardStub = nil
+	if fake.forwardReturnsOnCall == nil {
+		fake.forwardReturnsOnCall = make(map[int]struct {
+			result *searchbox.ForwardResponse
+			err    error
+		})
+	}
+	fake.forwardReturnsOnCall[i] = struct {
+		result *searchbox.ForwardResponse
+		err    error
+	}{result, err}
+}
+
+// CategorySearch implements searchbox.API.
+func (fake *FakeAPI) CategorySearch(ctx context.Context, req *searchbox.CategorySearchRequest) (*searchbox.CategorySearchResponse, error) {
+	fake.categorySearchMutex.Lock()
+	ret, specificReturn := fake.categorySearchReturnsOnCall[len(fake.categorySearchArgsForCall)]
+	fake.categorySearchArgsForCall = append(fake.categorySearchArgsForCall, struct {
+		ctx context.Context
+		req *searchbox.CategorySearchRequest
+	}{ctx, req})
+	stub := fake.CategorySearchStub
+	fakeReturns := fake.categorySearchReturns
+	fake.recordInvocation("CategorySearch", []any{ctx, req})
+	fake.categorySearchMutex.Unlock()
+	if stub != nil {
+		return stub(ctx, req)
+	}
+	if specificReturn {
+		return ret.result, ret.err
+	}
+	return fakeReturns.result, fakeReturns.err
+}
+
+// CategorySearchCallCount returns the number of calls to CategorySearch.
+func (fake *FakeAPI) CategorySearchCallCount() int {
+	fake.categorySearchMutex.RLock()
+	defer fake.categorySearchMutex.RUnlock()
+	return len(fake.categorySearchArgsForCall)
+}
+
+// CategorySearchCalls sets the stub called by CategorySearch.
+func (fake *FakeAPI) CategorySearchCalls(stub func(context.Context, *searchbox.CategorySearchRequest) (*searchbox.CategorySearchResponse, error)) {
+	fake.categorySearchMutex.Lock()
+	defer fake.categorySearchMutex.Unlock()
+	fake.CategorySearchStub = stub
+}
+
+// CategorySearchArgsForCall returns the arguments of the i-th call to CategorySearch.
+func (fake *FakeAPI) CategorySearchArgsForCall(i int) (context.Context, *searchbox.CategorySearchRequest) {
+	fake.categorySearchMutex.RLock()
+	defer fake.categorySearchMutex.RUnlock()
+	args := fake.categorySearchArgsForCall[i]
+	return args.ctx, args.req
+}
+
+// CategorySearchReturns sets the results of every call to CategorySearch.
+func (fake *FakeAPI) CategorySearchReturns(result *searchbox.CategorySearchResponse, err error) {
+	fake.categorySearchMutex.Lock()
+	defer fake.categorySearchMutex.Unlock()
+	fake.CategorySearchStub = nil
+	fake.categorySearchReturns = struct {
+		result *searchbox.CategorySearchResponse
+		err    error
+	}{result, err}
+}
+
+// CategorySearchReturnsOnCall sets the results of the i-th call to CategorySearch, counting
+// from zero.
+func (fake *FakeAPI) CategorySearchReturnsOnCall(i int, result *searchbox.CategorySearchResponse, err error) {
+	fake.categorySearchMutex.Lock()
+	defer fake.categorySearchMutex.Unlock()
+	fake.CategorySearchStub = nil
+	if fake.categorySearchReturnsOnCall == nil {
+		fake.categorySearchReturnsOnCall = make(map[int]struct {
+			result *searchbox.CategorySearchResponse
+			err    error
+		})
+	}
+	fake.categorySearchReturnsOnCall[i] = struct {
+		result *searchbox.CategorySearchResponse
+		err    error
+	}{result, err}
+}
+
+// ListCategories implements searchbox.API.
+func (fake *FakeAPI) ListCategories(ctx context.Context, req *searchbox.ListCategoriesRequest) (*searchbox.ListCategoriesResponse, error) {
+	fake.listCategoriesMutex.Lock()
+	ret, specificReturn := fake.listCategoriesReturnsOnCall[len(fake.listCategoriesArgsForCall)]
+	fake.listCategoriesArgsForCall = append(fake.listCategoriesArgsForCall, struct {
+		ctx context.Context
+		req *searchbox.ListCategoriesRequest
+	}{ctx, req})
+	stub := fake.ListCategoriesStub
+	fakeReturns := fake.listCategoriesReturns
+	fake.recordInvocation("ListCategories", []any{ctx, req})
+	fake.listCategoriesMutex.Unlock()
+	if stub != nil {
+		return stub(ctx, req)
+	}
+	if specificReturn {
+		return ret.result, ret.err
+	}
+	return fakeReturns.result, fakeReturns.err
+}
+
+// ListCategoriesCallCount returns the number of calls to ListCategories.
+func (fake *FakeAPI) ListCategoriesCallCount() int {
+	fake.listCategoriesMutex.RLock()
+	defer fake.listCategoriesMutex.RUnlock()
+	return len(fake.listCategoriesArgsForCall)
+}
+
+// ListCategoriesCalls sets the stub called by ListCategories.
+func (fake *FakeAPI) ListCategoriesCalls(stub func(context.Context, *searchbox.ListCategoriesRequest) (*searchbox.ListCategoriesResponse, error)) {
+	fake.listCategoriesMutex.Lock()
+	defer fake.listCategoriesMutex.Unlock()
+	fake.ListCategoriesStub = stub
+}
+
+// ListCategoriesArgsForCall returns the arguments of the i-th call to ListCategories.
+func (fake *FakeAPI) ListCategoriesArgsForCall(i int) (context.Context, *searchbox.ListCategoriesRequest) {
+	fake.listCategoriesMutex.RLock()
+	defer fake.listCategoriesMutex.RUnlock()
+	args := fake.listCategoriesArgsForCall[i]
+	return args.ctx, args.req
+}
+
+// ListCategoriesReturns sets the results of every call to ListCategories.
+func (fake *FakeAPI) ListCategoriesReturns(result *searchbox.ListCategoriesResponse, err error) {
+	fake.listCategoriesMutex.Lock()
+	defer fake.listCategoriesMutex.Unlock()
+	fake.ListCategoriesStub = nil
+	fake.listCategoriesReturns = struct {
+		result *searchbox.ListCategoriesResponse
+		err    error
+	}{result, err}
+}
+
+// ListCategoriesReturnsOnCall sets the results of the i-th call to ListCategories, counting
+// from zero.
+func (fake *FakeAPI) ListCategoriesReturnsOnCall(i int, result *searchbox.ListCategoriesResponse, err error) {
+	fake.listCategoriesMutex.Lock()
+	defer fake.listCategoriesMutex.Unlock()
+	fake.ListCategoriesStub = nil
+	if fake.listCategoriesReturnsOnCall == nil {
+		fake.listCategoriesReturnsOnCall = make(map[int]struct {
+			result *searchbox.ListCategoriesResponse
+			err    error
+		})
+	}
+	fake.listCategoriesReturnsOnCall[i] = struct {
+		result *searchbox.ListCategoriesResponse
+		err    error
+	}{result, err}
+}
+
+// Reverse implements searchbox.API.
+func (fake *FakeAPI) Reverse(ctx context.Context, req *searchbox.ReverseRequest) (*searchbox.ReverseResponse, error) {
+	fake.reverseMutex.Lock()
+	ret, specificReturn := fake.reverseReturnsOnCall[len(fake.reverseArgsForCall)]
+	fake.reverseArgsForCall = append(fake.reverseArgsForCall, struct {
+		ctx context.Context
+		req *searchbox.ReverseRequest
+	}{ctx, req})
+	stub := fake.ReverseStub
+	fakeReturns := fake.reverseReturns
+	fake.recordInvocation("Reverse", []any{ctx, req})
+	fake.reverseMutex.Unlock()
+	if stub != nil {
+		return stub(ctx, req)
+	}
+	if specificReturn {
+		return ret.result, ret.err
+	}
+	return fakeReturns.result, fakeReturns.err
+}
+
+// ReverseCallCount returns the number of calls to Reverse.
+func (fake *FakeAPI) ReverseCallCount() int {
+	fake.reverseMutex.RLock()
+	defer fake.reverseMutex.RUnlock()
+	return len(fake.reverseArgsForCall)
+}
+
+// ReverseCalls sets the stub called by Reverse.
+func (fake *FakeAPI) ReverseCalls(stub func(context.Context, *searchbox.ReverseRequest) (*searchbox.ReverseResponse, error)) {
+	fake.reverseMutex.Lock()
+	defer fake.reverseMutex.Unlock()
+	fake.ReverseStub = stub
+}
+
+// ReverseArgsForCall returns the arguments of the i-th call to Reverse.
+func (fake *FakeAPI) ReverseArgsForCall(i int) (context.Context, *searchbox.ReverseRequest) {
+	fake.reverseMutex.RLock()
+	defer fake.reverseMutex.RUnlock()
+	args := fake.reverseArgsForCall[i]
+	return args.ctx, args.req
+}
+
+// ReverseReturns sets the results of every call to Reverse.
+func (fake *FakeAPI) ReverseReturns(result *searchbox.ReverseResponse, err error) {
+	fake.reverseMutex.Lock()
+	defer fake.reverseMutex.Unlock()
+	fake.ReverseStub = nil
+	fake.reverseReturns = struct {
+		result *searchbox.ReverseResponse
+		err    error
+	}{result, err}
+}
+
+// ReverseReturnsOnCall sets the results of the i-th call to Reverse, counting
+// from zero.
+func (fake *FakeAPI) ReverseReturnsOnCall(i int, result *searchbox.ReverseResponse, err error) {
+	fake.reverseMutex.Lock()
+	defer fake.reverseMutex.Unlock()
+	fake.ReverseStub = nil
+	if fake.reverseReturnsOnCall == nil {
+		fake.reverseReturnsOnCall = make(map[int]struct {
+			result *searchbox.ReverseResponse
+			err    error
+		})
+	}
+	fake.reverseReturnsOnCall[i] = struct {
+		result *searchbox.ReverseResponse
+		err    error
+	}{result, err}
+}
+
+// Invocations returns the arguments of every call, keyed by method name.
+func (fake *FakeAPI) Invocations() map[string][][]any {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copied := make(map[string][][]any, len(fake.invocations))
+	for key, value := range fake.invocations {
+		copied[key] = append([][]any(nil), value...)
+	}
+	return copied
+}
+
+func (fake *FakeAPI) recordInvocation(key string, args []any) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]any{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ searchbox.API = new(FakeAPI)
@@ -0,0 +1,82 @@
+package searchboxfakes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/searchbox"
+)
+
+// pick runs a suggest and retrieve session, as consumer code would.
+func pick(ctx context.Context, api searchbox.API, query string) (*searchbox.RetrieveResponse, error) {
+	token := searchbox.NewSessionToken()
+	suggestions, err := api.Suggest(ctx, &searchbox.SuggestRequest{Query: query, SessionToken: token})
+	if err != nil {
+		return nil, err
+	}
+	if len(suggestions.Suggestions) == 0 {
+		return nil, errors.New("no suggestions")
+	}
+	return api.Retrieve(ctx, &searchbox.RetrieveRequest{MapboxID: suggestions.Suggestions[0].MapboxID, SessionToken: token})
+}
+
+func TestFakeAPI_Session(t *testing.T) {
+	fake := new(FakeAPI)
+	fake.SuggestReturns(&searchbox.SuggestResponse{Suggestions: []searchbox.Suggestion{{MapboxID: "poi.1"}}}, nil)
+	fake.RetrieveReturns(&searchbox.RetrieveResponse{Features: []searchbox.Feature{{ID: "poi.1"}}}, nil)
+
+	resp, err := pick(context.Background(), fake, "coffee")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Features[0].ID != "poi.1" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+
+	_, suggest := fake.SuggestArgsForCall(0)
+	_, retrieve := fake.RetrieveArgsForCall(0)
+	if suggest.Query != "coffee" || retrieve.MapboxID != "poi.1" {
+		t.Errorf("unexpected arguments: %+v, %+v", suggest, retrieve)
+	}
+	if suggest.SessionToken == "" || suggest.SessionToken != retrieve.SessionToken {
+		t.Errorf("expected the same session token, got %q and %q", suggest.SessionToken, retrieve.SessionToken)
+	}
+}
+
+func TestFakeAPI_ReturnsOnCall(t *testing.T) {
+	fake := new(FakeAPI)
+	errUnavailable := errors.New("unavailable")
+	fake.SuggestReturnsOnCall(0, nil, errUnavailable)
+	fake.SuggestReturns(&searchbox.SuggestResponse{}, nil)
+
+	if _, err := pick(context.Background(), fake, "coffee"); !errors.Is(err, errUnavailable) {
+		t.Errorf("expected %v, got %v", errUnavailable, err)
+	}
+	if _, err := pick(context.Background(), fake, "coffee"); err == nil || err.Error() != "no suggestions" {
+		t.Errorf("expected no suggestions, got %v", err)
+	}
+	if n := fake.RetrieveCallCount(); n != 0 {
+		t.Errorf("RetrieveCallCount() = %d, want 0", n)
+	}
+}
+
+func TestFakeAPI_Stub(t *testing.T) {
+	fake := new(FakeAPI)
+	fake.CategorySearchCalls(func(_ context.Context, req *searchbox.CategorySearchRequest) (*searchbox.CategorySearchResponse, error) {
+		return &searchbox.CategorySearchResponse{Features: make([]searchbox.Feature, *req.Limit)}, nil
+	})
+
+	limit := 3
+	resp, err := fake.CategorySearch(context.Background(), &searchbox.CategorySearchRequest{CategoryID: "cafe", Limit: &limit})
+	if err != nil || len(resp.Features) != 3 {
+		t.Errorf("CategorySearch() = %+v, %v", resp, err)
+	}
+
+	if _, err := fake.ListCategories(context.Background(), nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got := len(fake.Invocations()); got != 2 {
+		t.Errorf("expected invocations of 2 methods, got %d", got)
+	}
+}
@@ -6,30 +6,51 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/internal/antimeridian"
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
 )
 
 // Suggest performs an autocomplete search and returns suggestions without coordinates.
 // This is the first step in the Suggest/Retrieve workflow for interactive autocomplete.
 // Use the same session_token when calling Retrieve to complete the workflow.
 func (s *Service) Suggest(ctx context.Context, req *SuggestRequest) (*SuggestResponse, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.SearchBoxSuggest, req)
+
 	if err := validateSuggestRequest(req); err != nil {
 		return nil, err
 	}
 
-	query := s.buildSuggestQuery(req)
+	// A bbox crossing the antimeridian is searched as two requests.
+	var responses []*SuggestResponse
+	for _, bbox := range antimeridian.Split(req.BBox) {
+		split := *req
+		split.BBox = bbox
 
-	var result SuggestResponse
-	if err := s.httpClient.Get(ctx, suggestPath, query, &result); err != nil {
-		return nil, fmt.Errorf("suggest search failed: %w", err)
+		var result SuggestResponse
+		if err := s.httpClient.Get(ctx, suggestPath, s.buildSuggestQuery(&split), &result); err != nil {
+			return nil, fmt.Errorf("suggest search failed: %w", err)
+		}
+		responses = append(responses, &result)
 	}
 
-	return &result, nil
+	if len(responses) == 1 {
+		return responses[0], nil
+	}
+
+	merged := *responses[0]
+	merged.Suggestions = mergeSuggestions([][]Suggestion{responses[0].Suggestions, responses[1].Suggestions}, req.Limit)
+	return &merged, nil
 }
 
 // Retrieve gets the full feature details (including coordinates) for a selected suggestion.
 // This is the second step in the Suggest/Retrieve workflow, called after the user selects
 // a suggestion. Use the same session_token from the Suggest request.
 func (s *Service) Retrieve(ctx context.Context, req *RetrieveRequest) (*RetrieveResponse, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.SearchBoxRetrieve, req)
+
 	if err := validateRetrieveRequest(req); err != nil {
 		return nil, err
 	}
@@ -64,7 +85,11 @@ func validateSuggestRequest(req *SuggestRequest) error {
 		return fmt.Errorf("limit must be between 1 and 10")
 	}
 
-	return nil
+	if err := validateBias(req.BBox, req.Proximity); err != nil {
+		return err
+	}
+
+	return validateNavigation(req.Navigation)
 }
 
 // validateRetrieveRequest validates the Retrieve request parameters.
@@ -77,7 +102,7 @@ func validateRetrieveRequest(req *RetrieveRequest) error {
 		return fmt.Errorf("session_token is required")
 	}
 
-	return nil
+	return validateNavigation(req.Navigation)
 }
 
 // buildSuggestQuery builds query parameters for the Suggest endpoint.
@@ -90,12 +115,12 @@ func (s *Service) buildSuggestQuery(req *SuggestRequest) url.Values {
 	// Proximity (can be coordinates or "ip")
 	if req.ProximityIP {
 		q.Set("proximity", "ip")
-	} else if len(req.Proximity) == 2 {
-		q.Set("proximity", formatFloatArray(req.Proximity))
+	} else if req.Proximity != nil {
+		q.Set("proximity", req.Proximity.String())
 	}
 
-	if len(req.BBox) == 4 {
-		q.Set("bbox", formatFloatArray(req.BBox))
+	if req.BBox != nil {
+		q.Set("bbox", req.BBox.String())
 	}
 
 	if len(req.Country) > 0 {
@@ -146,8 +171,8 @@ func addNavigationParams(q url.Values, nav *NavigationOptions) {
 		q.Set("eta_type", nav.ETAType)
 	}
 
-	if len(nav.Origin) == 2 {
-		q.Set("origin", formatFloatArray(nav.Origin))
+	if nav.Origin != nil {
+		q.Set("origin", nav.Origin.String())
 	}
 
 	if nav.Profile != "" {
@@ -155,11 +180,30 @@ func addNavigationParams(q url.Values, nav *NavigationOptions) {
 	}
 }
 
-// formatFloatArray formats a float array as a comma-separated string.
-func formatFloatArray(arr []float64) string {
-	parts := make([]string, len(arr))
-	for i, v := range arr {
-		parts[i] = strconv.FormatFloat(v, 'f', -1, 64)
+// validateBias validates the optional bbox and proximity of a request.
+func validateBias(bbox *geojson.BBox, proximity *geojson.LngLat) error {
+	if bbox != nil {
+		if err := bbox.Validate(); err != nil {
+			return fmt.Errorf("invalid bbox: %w", err)
+		}
 	}
-	return strings.Join(parts, ",")
+
+	if proximity != nil {
+		if err := proximity.Validate(); err != nil {
+			return fmt.Errorf("invalid proximity: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateNavigation validates the optional navigation options of a request.
+func validateNavigation(nav *NavigationOptions) error {
+	if nav != nil && nav.Origin != nil {
+		if err := nav.Origin.Validate(); err != nil {
+			return fmt.Errorf("invalid navigation origin: %w", err)
+		}
+	}
+
+	return nil
 }
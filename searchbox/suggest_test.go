@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pettinz/mapbox-go-sdk/geojson"
 	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
 	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
 )
@@ -24,7 +25,7 @@ func TestService_Suggest(t *testing.T) {
 			request: &SuggestRequest{
 				Query:        "coffee",
 				SessionToken: "550e8400-e29b-41d4-a716-446655440000",
-				Proximity:    []float64{-122.4194, 37.7749},
+				Proximity:    &geojson.LngLat{Longitude: -122.4194, Latitude: 37.7749},
 				Limit:        intPtr(5),
 			},
 			mockStatus:   http.StatusOK,
@@ -50,8 +51,8 @@ func TestService_Suggest(t *testing.T) {
 			request: &SuggestRequest{
 				Query:        "restaurant",
 				SessionToken: "550e8400-e29b-41d4-a716-446655440000",
-				Proximity:    []float64{-122.4194, 37.7749},
-				BBox:         []float64{-122.5, 37.7, -122.3, 37.8},
+				Proximity:    &geojson.LngLat{Longitude: -122.4194, Latitude: 37.7749},
+				BBox:         geojson.NewBBox(-122.5, 37.7, -122.3, 37.8),
 				Country:      []string{"US"},
 				Language:     "en",
 				Limit:        intPtr(10),
@@ -59,7 +60,7 @@ func TestService_Suggest(t *testing.T) {
 				POICategory:  []string{"restaurant"},
 				Navigation: &NavigationOptions{
 					ETAType: "navigation",
-					Origin:  []float64{-122.4, 37.8},
+					Origin:  &geojson.LngLat{Longitude: -122.4, Latitude: 37.8},
 					Profile: "driving",
 				},
 			},
@@ -182,7 +183,7 @@ func TestService_Retrieve(t *testing.T) {
 					if feature.Properties.MapboxID == "" {
 						t.Error("expected non-empty mapbox_id")
 					}
-					if feature.Geometry.Coordinates[0] == 0 && feature.Geometry.Coordinates[1] == 0 {
+					if feature.Geometry.Coordinates == (geojson.LngLat{}) {
 						t.Error("expected non-zero coordinates")
 					}
 				}
@@ -195,7 +196,7 @@ func TestService_Retrieve(t *testing.T) {
 				SessionToken: "550e8400-e29b-41d4-a716-446655440000",
 				Navigation: &NavigationOptions{
 					ETAType: "navigation",
-					Origin:  []float64{-122.4, 37.8},
+					Origin:  &geojson.LngLat{Longitude: -122.4, Latitude: 37.8},
 					Profile: "walking",
 				},
 			},
@@ -262,8 +263,8 @@ func TestBuildSuggestQuery(t *testing.T) {
 	req := &SuggestRequest{
 		Query:        "coffee shop",
 		SessionToken: "550e8400-e29b-41d4-a716-446655440000",
-		Proximity:    []float64{-122.4194, 37.7749},
-		BBox:         []float64{-122.5, 37.7, -122.3, 37.8},
+		Proximity:    &geojson.LngLat{Longitude: -122.4194, Latitude: 37.7749},
+		BBox:         geojson.NewBBox(-122.5, 37.7, -122.3, 37.8),
 		Country:      []string{"US", "CA"},
 		Language:     "en",
 		Limit:        intPtr(10),
@@ -271,7 +272,7 @@ func TestBuildSuggestQuery(t *testing.T) {
 		POICategory:  []string{"coffee_shop"},
 		Navigation: &NavigationOptions{
 			ETAType: "navigation",
-			Origin:  []float64{-122.4, 37.8},
+			Origin:  &geojson.LngLat{Longitude: -122.4, Latitude: 37.8},
 			Profile: "driving",
 		},
 	}
@@ -331,7 +332,7 @@ func TestBuildRetrieveQuery(t *testing.T) {
 		SessionToken: "550e8400-e29b-41d4-a716-446655440000",
 		Navigation: &NavigationOptions{
 			ETAType: "navigation",
-			Origin:  []float64{-122.4, 37.8},
+			Origin:  &geojson.LngLat{Longitude: -122.4, Latitude: 37.8},
 			Profile: "cycling",
 		},
 	}
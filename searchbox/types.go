@@ -1,29 +1,35 @@
 package searchbox
 
+import (
+	"encoding/json"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
 // NavigationOptions configures navigation and ETA calculations.
 type NavigationOptions struct {
-	ETAType string    // "navigation" for ETA calculations
-	Origin  []float64 // [lon, lat] for ETA from origin
-	Profile string    // "driving", "walking", "cycling"
+	ETAType string          // "navigation" for ETA calculations
+	Origin  *geojson.LngLat // origin for ETA calculations
+	Profile string          // "driving", "walking", "cycling"
 }
 
 // SAROptions configures Search Along Route parameters.
 type SAROptions struct {
-	Type          string      // "isochrone"
-	Route         [][]float64 // route coordinates [[lon, lat], ...]
-	TimeDeviation *int        // seconds of acceptable time deviation
+	Type          string           // "isochrone"
+	Route         []geojson.LngLat // route coordinates
+	TimeDeviation *int             // seconds of acceptable time deviation
 }
 
 // SuggestRequest represents a request to the Suggest endpoint.
 type SuggestRequest struct {
 	Query        string             // required, max 256 chars
 	SessionToken string             // required, UUIDv4
-	Proximity    []float64          // [lon, lat] or use ProximityIP
+	Proximity    *geojson.LngLat    // or use ProximityIP
 	ProximityIP  bool               // set to true to use IP-based proximity
-	BBox         []float64          // [min_lon, min_lat, max_lon, max_lat]
+	BBox         *geojson.BBox      // limits results to a bounding box
 	Country      []string           // ISO 3166-1 alpha-2 codes
 	Language     string             // IETF language tag
-	Limit        *int               // max 10
+	Limit        *int               // default 5, max 10
 	Types        []string           // feature types
 	POICategory  []string           // POI categories
 	Navigation   *NavigationOptions // ETA options
@@ -74,12 +80,12 @@ type RetrieveResponse struct {
 type ForwardRequest struct {
 	Query        string             // required
 	Autocomplete *bool              // default true
-	Proximity    []float64          // [lon, lat] or use ProximityIP
+	Proximity    *geojson.LngLat    // or use ProximityIP
 	ProximityIP  bool               // set to true to use IP-based proximity
-	BBox         []float64          // [min_lon, min_lat, max_lon, max_lat]
+	BBox         *geojson.BBox      // limits results to a bounding box
 	Country      []string           // ISO 3166-1 alpha-2 codes
 	Language     string             // IETF language tag
-	Limit        *int               // max 10
+	Limit        *int               // default 5, max 10
 	Types        []string           // feature types
 	POICategory  []string           // POI categories
 	Navigation   *NavigationOptions // ETA options
@@ -96,12 +102,12 @@ type ForwardResponse struct {
 // CategorySearchRequest represents a request to search by category.
 type CategorySearchRequest struct {
 	CategoryID  string             // required, canonical category ID
-	Proximity   []float64          // [lon, lat], required if no bbox or SAR
+	Proximity   *geojson.LngLat    // required if no bbox or SAR
 	ProximityIP bool               // set to true to use IP-based proximity
-	BBox        []float64          // [min_lon, min_lat, max_lon, max_lat], required if no proximity or SAR
+	BBox        *geojson.BBox      // required if no proximity or SAR
 	Country     []string           // ISO 3166-1 alpha-2 codes
 	Language    string             // IETF language tag
-	Limit       *int               // max 25
+	Limit       *int               // default 10, max 25
 	Navigation  *NavigationOptions // ETA options
 	SAR         *SAROptions        // Search Along Route, required if no proximity or bbox
 }
@@ -219,10 +225,37 @@ type ContextElement struct {
 	RegionCode  string `json:"region_code,omitempty"`
 }
 
-// RoutablePoint represents a point suitable for navigation.
+// RoutablePoint represents a point suitable for navigation. Its coordinates
+// are encoded as [lon, lat].
 type RoutablePoint struct {
+	Name        string
+	Coordinates geojson.LngLat
+}
+
+// LngLat returns the routable point coordinates.
+func (r RoutablePoint) LngLat() geojson.LngLat {
+	return r.Coordinates
+}
+
+// routablePointJSON is the wire form of RoutablePoint.
+type routablePointJSON struct {
 	Name        string    `json:"name"`
-	Coordinates []float64 `json:"coordinates"` // [lon, lat]
+	Coordinates []float64 `json:"coordinates"`
+}
+
+// MarshalJSON encodes the point with [lon, lat] coordinates.
+func (r RoutablePoint) MarshalJSON() ([]byte, error) {
+	return json.Marshal(routablePointJSON{Name: r.Name, Coordinates: r.Coordinates.Position()})
+}
+
+// UnmarshalJSON decodes a point with [lon, lat] coordinates.
+func (r *RoutablePoint) UnmarshalJSON(data []byte) error {
+	var raw routablePointJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = RoutablePoint{Name: raw.Name, Coordinates: (&geojson.Point{Coordinates: raw.Coordinates}).LngLat()}
+	return nil
 }
 
 // Coordinates represents a geographic coordinate pair.
@@ -231,8 +264,41 @@ type Coordinates struct {
 	Latitude  float64 `json:"latitude"`
 }
 
-// Geometry represents a GeoJSON geometry.
+// LngLat returns the coordinates as a geojson.LngLat for use with the geo and
+// tilemath packages.
+func (c Coordinates) LngLat() geojson.LngLat {
+	return geojson.LngLat{Longitude: c.Longitude, Latitude: c.Latitude}
+}
+
+// Geometry represents a GeoJSON point geometry. Its coordinates are encoded
+// as [lon, lat].
 type Geometry struct {
+	Type        string
+	Coordinates geojson.LngLat
+}
+
+// LngLat returns the point coordinates.
+func (g Geometry) LngLat() geojson.LngLat {
+	return g.Coordinates
+}
+
+// geometryJSON is the wire form of Geometry.
+type geometryJSON struct {
 	Type        string    `json:"type"`
-	Coordinates []float64 `json:"coordinates"` // [lon, lat]
+	Coordinates []float64 `json:"coordinates"`
+}
+
+// MarshalJSON encodes the geometry with [lon, lat] coordinates.
+func (g Geometry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(geometryJSON{Type: g.Type, Coordinates: g.Coordinates.Position()})
+}
+
+// UnmarshalJSON decodes a geometry with [lon, lat] coordinates.
+func (g *Geometry) UnmarshalJSON(data []byte) error {
+	var raw geometryJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*g = Geometry{Type: raw.Type, Coordinates: (&geojson.Point{Coordinates: raw.Coordinates}).LngLat()}
+	return nil
 }
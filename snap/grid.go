@@ -0,0 +1,89 @@
+package snap
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pettinz/mapbox-go-sdk/geo"
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/spatial"
+)
+
+// Grid divides the world into cells.
+type Grid interface {
+	// Snap returns the cell containing p.
+	Snap(p geojson.LngLat) Cell
+}
+
+// Cell is a cell of a Grid.
+type Cell struct {
+	// ID identifies the cell within its grid.
+	ID string
+
+	// Center is the point the cell snaps to.
+	Center geojson.LngLat
+}
+
+// Geohash returns a grid of geohash cells of the given precision, from 1 to
+// 12 characters; values out of range are clamped. Precision 7 cells are
+// about 150 m wide, precision 8 cells about 40 m.
+func Geohash(precision int) Grid {
+	return geohashGrid(precision)
+}
+
+type geohashGrid int
+
+func (g geohashGrid) Snap(p geojson.LngLat) Cell {
+	hash := spatial.EncodeGeohash(p, int(g))
+	return Cell{ID: string(hash), Center: hash.Center()}
+}
+
+// Hex returns a grid of the cells of a spatial.HexGrid.
+func Hex(grid spatial.HexGrid) Grid {
+	return hexGrid{grid}
+}
+
+type hexGrid struct {
+	grid spatial.HexGrid
+}
+
+func (g hexGrid) Snap(p geojson.LngLat) Cell {
+	cell := g.grid.Cell(p)
+	return Cell{ID: fmt.Sprintf("hex%g:%s", g.grid.Size(), cell), Center: g.grid.Center(cell)}
+}
+
+// metersPerDegree is the length of a degree of latitude in meters.
+const metersPerDegree = geo.EarthRadius * math.Pi / 180
+
+// Meters returns a grid of cells about size meters on a side. Rows are size
+// meters tall and each row is divided into cells size meters wide at its
+// center latitude, so cells stay about square away from the equator. Meters
+// panics if size is not positive.
+func Meters(size float64) Grid {
+	if !(size > 0) {
+		panic(fmt.Sprintf("snap: non-positive cell size %v", size))
+	}
+	return meterGrid(size)
+}
+
+type meterGrid float64
+
+func (g meterGrid) Snap(p geojson.LngLat) Cell {
+	size := float64(g)
+	height := size / metersPerDegree
+
+	row := math.Floor(p.Latitude / height)
+	lat := math.Max(-90, math.Min(90, (row+0.5)*height))
+
+	columns := math.Max(1, math.Floor(360*math.Cos(lat*math.Pi/180)*metersPerDegree/size))
+	width := 360 / columns
+	column := math.Mod(math.Floor((p.Longitude+180)/width), columns)
+	if column < 0 {
+		column += columns
+	}
+
+	return Cell{
+		ID:     fmt.Sprintf("%gm:%d:%d", size, int64(row), int64(column)),
+		Center: geojson.LngLat{Longitude: -180 + (column+0.5)*width, Latitude: lat},
+	}
+}
@@ -0,0 +1,82 @@
+package snap
+
+import (
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geo"
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/spatial"
+)
+
+func TestGeohash(t *testing.T) {
+	tests := []struct {
+		p         geojson.LngLat
+		precision int
+		want      string
+	}{
+		{geojson.LngLat{Longitude: -5.6, Latitude: 42.6}, 5, "ezs42"},
+		{geojson.LngLat{Longitude: 10.40744, Latitude: 57.64911}, 11, "u4pruydqqvj"},
+		{geojson.LngLat{Longitude: 12.4924, Latitude: 41.8902}, 0, "s"},
+	}
+	for _, tt := range tests {
+		cell := Geohash(tt.precision).Snap(tt.p)
+		if cell.ID != tt.want {
+			t.Errorf("Geohash(%d).Snap(%v).ID = %q, want %q", tt.precision, tt.p, cell.ID, tt.want)
+		}
+		if got := string(spatial.EncodeGeohash(cell.Center, len(cell.ID))); got != cell.ID {
+			t.Errorf("center %v of %q is in cell %q", cell.Center, cell.ID, got)
+		}
+	}
+
+	if id := Geohash(20).Snap(geojson.LngLat{Longitude: 10.40744, Latitude: 57.64911}).ID; len(id) != 12 || id[:11] != "u4pruydqqvj" {
+		t.Errorf("expected precision to be clamped to 12, got %q", id)
+	}
+}
+
+func TestMeters(t *testing.T) {
+	grid := Meters(100)
+	for _, p := range []geojson.LngLat{
+		{Longitude: 12.4924, Latitude: 41.8902},
+		{Longitude: -0.1276, Latitude: 51.5072},
+		{Longitude: 179.9999, Latitude: -16.5},
+		{Longitude: -180, Latitude: 0},
+		{Longitude: 25, Latitude: 89.9999},
+	} {
+		cell := grid.Snap(p)
+		if d := geo.Distance(p, cell.Center); d > 100 {
+			t.Errorf("Snap(%v) center %v is %.1f m away", p, cell.Center, d)
+		}
+		if again := grid.Snap(cell.Center); again != cell {
+			t.Errorf("Snap(%v) = %v, but its center snaps to %v", p, cell, again)
+		}
+	}
+
+	// Points a few meters apart share a cell unless they straddle an edge.
+	a := grid.Snap(geojson.LngLat{Longitude: 12.49241, Latitude: 41.89021})
+	b := grid.Snap(geojson.LngLat{Longitude: 12.49242, Latitude: 41.89022})
+	c := grid.Snap(geojson.LngLat{Longitude: 12.5, Latitude: 41.89})
+	if a != b || a == c {
+		t.Errorf("unexpected cells %v, %v, %v", a, b, c)
+	}
+}
+
+func TestHex(t *testing.T) {
+	grid := Hex(spatial.NewHexGrid(200))
+	p := geojson.LngLat{Longitude: 12.4924, Latitude: 41.8902}
+	cell := grid.Snap(p)
+	if cell.ID != "hex200:"+spatial.NewHexGrid(200).Cell(p).String() {
+		t.Errorf("unexpected cell ID %q", cell.ID)
+	}
+	if d := geo.Distance(p, cell.Center); d > 200 {
+		t.Errorf("center %v is %.1f m away", cell.Center, d)
+	}
+}
+
+func TestMeters_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	Meters(0)
+}
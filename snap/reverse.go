@@ -0,0 +1,215 @@
+// Package snap reverse geocodes points snapped to the cells of a grid, so that
+// streams of nearby points, such as the position pings of a vehicle, call the
+// Geocoding API once per cell instead of once per point.
+//
+// A Reverser snaps each point to a cell of a Grid, geohash, hexagon or meter
+// based, and reverse geocodes the cell center the first time the cell is
+// seen. Later points in the cell get the cached response together with their
+// distance from the center, which bounds the error snapping introduces:
+//
+//	r := snap.NewReverser(client.Geocoding(), snap.Meters(50))
+//	result, err := r.Reverse(ctx, &geocoding.ReverseRequest{Longitude: lon, Latitude: lat})
+//	if err == nil && result.Feature != nil {
+//		fmt.Println(result.Feature.Properties.PlaceName, result.Distance)
+//	}
+//
+// Caching results stores them: the Mapbox terms of service only allow
+// storing the results of permanent requests, so set Permanent on requests
+// whose results are kept beyond a session.
+package snap
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pettinz/mapbox-go-sdk/geo"
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+const defaultMaxCells = 10000
+
+// Reverser reverse geocodes points snapped to the cells of a grid, caching
+// the response of each cell. A Reverser is safe for concurrent use.
+//
+// Concurrent misses for the same cell each call the API; install
+// middleware.Dedup on the client to collapse them.
+type Reverser struct {
+	api      geocoding.API
+	grid     Grid
+	maxCells int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // of *entry, most recently used first
+}
+
+type entry struct {
+	key     string
+	resp    *geocoding.Response
+	expires time.Time
+}
+
+// Option is a functional option for configuring the Reverser.
+type Option func(*Reverser)
+
+// WithMaxCells sets how many cells are cached; the least recently used
+// cells are evicted first (default: 10000).
+func WithMaxCells(n int) Option {
+	return func(r *Reverser) {
+		if n > 0 {
+			r.maxCells = n
+		}
+	}
+}
+
+// WithTTL expires cached cells after d. Zero, the default, keeps them until
+// they are evicted.
+func WithTTL(d time.Duration) Option {
+	return func(r *Reverser) {
+		r.ttl = d
+	}
+}
+
+// NewReverser creates a Reverser calling api for the cells of grid.
+func NewReverser(api geocoding.API, grid Grid, opts ...Option) *Reverser {
+	r := &Reverser{
+		api:      api,
+		grid:     grid,
+		maxCells: defaultMaxCells,
+		now:      time.Now,
+		entries:  map[string]*list.Element{},
+		lru:      list.New(),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Result is the outcome of Reverse.
+type Result struct {
+	// Response is the response for the cell center. It is shared by every
+	// point of the cell and must not be modified.
+	Response *geocoding.Response
+
+	// Feature is the first feature of Response, or nil if there is none.
+	Feature *geocoding.Feature
+
+	// Cell is the cell the point snapped to.
+	Cell Cell
+
+	// Distance is the distance in meters between the point and the cell
+	// center.
+	Distance float64
+
+	// Cached is true when the response comes from the cache.
+	Cached bool
+}
+
+// Reverse reverse geocodes the center of the cell containing the request
+// coordinates, or returns the cached response for the cell. The other
+// request fields, such as Types and Language, are sent as given and are part
+// of the cache key. Errors are not cached.
+func (r *Reverser) Reverse(ctx context.Context, req *geocoding.ReverseRequest) (*Result, error) {
+	p := geojson.LngLat{Longitude: req.Longitude, Latitude: req.Latitude}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	cell := r.grid.Snap(p)
+	result := &Result{Cell: cell, Distance: geo.Distance(p, cell.Center)}
+
+	key := cacheKey(cell, req)
+	if resp, ok := r.get(key); ok {
+		result.Response, result.Cached = resp, true
+	} else {
+		snapped := *req
+		snapped.Longitude, snapped.Latitude = cell.Center.Longitude, cell.Center.Latitude
+
+		resp, err := r.api.Reverse(ctx, &snapped)
+		if err != nil {
+			return nil, err
+		}
+		r.put(key, resp)
+		result.Response = resp
+	}
+
+	if len(result.Response.Features) > 0 {
+		result.Feature = &result.Response.Features[0]
+	}
+	return result, nil
+}
+
+// Len returns the number of cached cells.
+func (r *Reverser) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lru.Len()
+}
+
+// Purge empties the cache.
+func (r *Reverser) Purge() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.entries)
+	r.lru.Init()
+}
+
+// get returns the cached response for key, dropping it if it has expired.
+func (r *Reverser) get(key string) (*geocoding.Response, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	el, ok := r.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if !e.expires.IsZero() && !r.now().Before(e.expires) {
+		r.lru.Remove(el)
+		delete(r.entries, key)
+		return nil, false
+	}
+	r.lru.MoveToFront(el)
+	return e.resp, true
+}
+
+// put caches resp for key, evicting the least recently used cells beyond
+// the limit.
+func (r *Reverser) put(key string, resp *geocoding.Response) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e := &entry{key: key, resp: resp}
+	if r.ttl > 0 {
+		e.expires = r.now().Add(r.ttl)
+	}
+	if el, ok := r.entries[key]; ok {
+		el.Value = e
+		r.lru.MoveToFront(el)
+		return
+	}
+	r.entries[key] = r.lru.PushFront(e)
+
+	for r.lru.Len() > r.maxCells {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.entries, oldest.Value.(*entry).key)
+	}
+}
+
+// cacheKey identifies the response for cell to requests like req.
+func cacheKey(cell Cell, req *geocoding.ReverseRequest) string {
+	options := *req
+	options.Longitude, options.Latitude = 0, 0
+	b, _ := json.Marshal(options)
+	return cell.ID + " " + string(b)
+}
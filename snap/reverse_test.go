@@ -0,0 +1,125 @@
+package snap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	"github.com/pettinz/mapbox-go-sdk/geocoding/geocodingfakes"
+)
+
+// newFake returns a fake API answering reverse requests with a feature named
+// after the requested coordinates.
+func newFake() *geocodingfakes.FakeAPI {
+	fake := new(geocodingfakes.FakeAPI)
+	fake.ReverseCalls(func(_ context.Context, req *geocoding.ReverseRequest) (*geocoding.Response, error) {
+		return &geocoding.Response{
+			Type:     "FeatureCollection",
+			Features: []geocoding.Feature{{ID: req.Language}},
+		}, nil
+	})
+	return fake
+}
+
+func TestReverser(t *testing.T) {
+	fake := newFake()
+	r := NewReverser(fake, Meters(50))
+	ctx := context.Background()
+
+	first, err := r.Reverse(ctx, &geocoding.ReverseRequest{Longitude: 12.49241, Latitude: 41.89021, Language: "it"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Cached || first.Feature == nil || first.Feature.ID != "it" {
+		t.Errorf("unexpected first result %+v", first)
+	}
+	_, sent := fake.ReverseArgsForCall(0)
+	if sent.Longitude != first.Cell.Center.Longitude || sent.Latitude != first.Cell.Center.Latitude || sent.Language != "it" {
+		t.Errorf("expected the cell center to be geocoded, got %+v", sent)
+	}
+
+	// A nearby ping hits the cache.
+	second, err := r.Reverse(ctx, &geocoding.ReverseRequest{Longitude: 12.49242, Latitude: 41.89022, Language: "it"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !second.Cached || second.Response != first.Response || second.Cell != first.Cell {
+		t.Errorf("expected a cached result, got %+v", second)
+	}
+	if second.Distance <= 0 || second.Distance > 50 {
+		t.Errorf("Distance = %f", second.Distance)
+	}
+
+	// Other options or cells are geocoded.
+	r.Reverse(ctx, &geocoding.ReverseRequest{Longitude: 12.49242, Latitude: 41.89022, Language: "en"})
+	r.Reverse(ctx, &geocoding.ReverseRequest{Longitude: 12.6, Latitude: 41.9, Language: "it"})
+	if n := fake.ReverseCallCount(); n != 3 {
+		t.Errorf("expected 3 calls, got %d", n)
+	}
+	if n := r.Len(); n != 3 {
+		t.Errorf("Len() = %d, want 3", n)
+	}
+
+	r.Purge()
+	if n := r.Len(); n != 0 {
+		t.Errorf("Len() after Purge = %d", n)
+	}
+}
+
+func TestReverser_Errors(t *testing.T) {
+	fake := new(geocodingfakes.FakeAPI)
+	errFailed := errors.New("failed")
+	fake.ReverseReturnsOnCall(0, nil, errFailed)
+	fake.ReverseReturns(&geocoding.Response{}, nil)
+	r := NewReverser(fake, Geohash(7))
+	req := &geocoding.ReverseRequest{Longitude: 12.4924, Latitude: 41.8902}
+
+	if _, err := r.Reverse(context.Background(), req); !errors.Is(err, errFailed) {
+		t.Fatalf("expected the API error, got %v", err)
+	}
+	result, err := r.Reverse(context.Background(), req)
+	if err != nil || result.Cached || result.Feature != nil {
+		t.Errorf("expected errors not to be cached, got %+v, %v", result, err)
+	}
+
+	if _, err := r.Reverse(context.Background(), &geocoding.ReverseRequest{Longitude: 200}); err == nil {
+		t.Error("expected error for invalid coordinates")
+	}
+	if n := fake.ReverseCallCount(); n != 2 {
+		t.Errorf("expected 2 calls, got %d", n)
+	}
+}
+
+func TestReverser_Eviction(t *testing.T) {
+	fake := newFake()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := NewReverser(fake, Geohash(5), WithMaxCells(2), WithTTL(time.Hour))
+	r.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	rome := &geocoding.ReverseRequest{Longitude: 12.4924, Latitude: 41.8902}
+	paris := &geocoding.ReverseRequest{Longitude: 2.3522, Latitude: 48.8566}
+	london := &geocoding.ReverseRequest{Longitude: -0.1276, Latitude: 51.5072}
+	cached := func(req *geocoding.ReverseRequest) bool {
+		result, err := r.Reverse(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result.Cached
+	}
+
+	cached(rome)
+	cached(paris)
+	cached(rome) // Paris is now the least recently used
+	cached(london)
+	if !cached(rome) || cached(paris) {
+		t.Error("expected Paris to be evicted")
+	}
+
+	now = now.Add(time.Hour)
+	if cached(rome) {
+		t.Error("expected Rome to expire")
+	}
+}
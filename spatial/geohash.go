@@ -0,0 +1,163 @@
+package spatial
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// The geohash alphabet, and the longest geohash encoded.
+const (
+	geohashAlphabet     = "0123456789bcdefghjkmnpqrstuvwxyz"
+	MaxGeohashPrecision = 12
+)
+
+// Geohash is a geohash cell, e.g. "u4pruyd". Each character narrows the cell
+// about 32 times: precision 5 cells are about 5 km wide, precision 7 about
+// 150 m and precision 9 about 5 m.
+type Geohash string
+
+// Direction is one of the eight neighbors of a cell.
+type Direction int
+
+// Directions, clockwise from north.
+const (
+	North Direction = iota
+	NorthEast
+	East
+	SouthEast
+	South
+	SouthWest
+	West
+	NorthWest
+)
+
+// offsets are the column and row steps to the neighbor in each direction.
+var offsets = [8][2]float64{{0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}, {-1, 0}, {-1, 1}}
+
+// EncodeGeohash returns the geohash of p with precision characters, from 1
+// to MaxGeohashPrecision; values out of range are clamped.
+func EncodeGeohash(p geojson.LngLat, precision int) Geohash {
+	lon := [2]float64{-180, 180}
+	lat := [2]float64{-90, 90}
+	hash := make([]byte, min(max(precision, 1), MaxGeohashPrecision))
+	even := true
+	for i := range hash {
+		var index int
+		for range 5 {
+			index <<= 1
+			interval, v := &lat, p.Latitude
+			if even {
+				interval, v = &lon, p.Longitude
+			}
+			if mid := (interval[0] + interval[1]) / 2; v >= mid {
+				index |= 1
+				interval[0] = mid
+			} else {
+				interval[1] = mid
+			}
+			even = !even
+		}
+		hash[i] = geohashAlphabet[index]
+	}
+	return Geohash(hash)
+}
+
+// ParseGeohash validates a geohash. Upper-case characters are accepted.
+func ParseGeohash(s string) (Geohash, error) {
+	s = strings.ToLower(s)
+	if s == "" || len(s) > MaxGeohashPrecision {
+		return "", fmt.Errorf("invalid geohash %q: length must be between 1 and %d", s, MaxGeohashPrecision)
+	}
+	if i := strings.IndexFunc(s, func(r rune) bool { return !strings.ContainsRune(geohashAlphabet, r) }); i >= 0 {
+		return "", fmt.Errorf("invalid geohash %q: invalid character %q", s, s[i])
+	}
+	return Geohash(s), nil
+}
+
+// Precision returns the number of characters of the geohash.
+func (h Geohash) Precision() int {
+	return len(h)
+}
+
+// Bounds returns the cell's bounding box.
+func (h Geohash) Bounds() geojson.BBox {
+	lon := [2]float64{-180, 180}
+	lat := [2]float64{-90, 90}
+	even := true
+	for i := range len(h) {
+		index := strings.IndexByte(geohashAlphabet, h[i])
+		for bit := 4; bit >= 0; bit-- {
+			interval := &lat
+			if even {
+				interval = &lon
+			}
+			mid := (interval[0] + interval[1]) / 2
+			if index>>bit&1 == 1 {
+				interval[0] = mid
+			} else {
+				interval[1] = mid
+			}
+			even = !even
+		}
+	}
+	return geojson.BBox{West: lon[0], South: lat[0], East: lon[1], North: lat[1]}
+}
+
+// Center returns the center of the cell.
+func (h Geohash) Center() geojson.LngLat {
+	b := h.Bounds()
+	return geojson.LngLat{Longitude: (b.West + b.East) / 2, Latitude: (b.South + b.North) / 2}
+}
+
+// Boundary returns the outline of the cell as a polygon.
+func (h Geohash) Boundary() *geojson.Polygon {
+	b := h.Bounds()
+	return geojson.NewPolygon([][][]float64{{
+		{b.West, b.South}, {b.East, b.South}, {b.East, b.North}, {b.West, b.North}, {b.West, b.South},
+	}})
+}
+
+// Parent returns the cell one character shorter containing h, or h itself at
+// precision 1.
+func (h Geohash) Parent() Geohash {
+	if len(h) <= 1 {
+		return h
+	}
+	return h[:len(h)-1]
+}
+
+// Children returns the 32 cells one character longer inside h.
+func (h Geohash) Children() [32]Geohash {
+	var children [32]Geohash
+	for i := range children {
+		children[i] = h + Geohash(geohashAlphabet[i])
+	}
+	return children
+}
+
+// Neighbor returns the adjacent cell of the same precision in direction d.
+// Neighbors wrap around the antimeridian; beyond the poles there are none and
+// Neighbor returns "".
+func (h Geohash) Neighbor(d Direction) Geohash {
+	b := h.Bounds()
+	lat := (b.South+b.North)/2 + offsets[d][1]*(b.North-b.South)
+	if lat < -90 || lat > 90 {
+		return ""
+	}
+	lon := (b.West+b.East)/2 + offsets[d][0]*(b.East-b.West)
+	lon = math.Mod(lon+540, 360) - 180
+	return EncodeGeohash(geojson.LngLat{Longitude: lon, Latitude: lat}, len(h))
+}
+
+// Neighbors returns the eight adjacent cells, indexed by Direction. Cells
+// beyond the poles are "".
+func (h Geohash) Neighbors() [8]Geohash {
+	var neighbors [8]Geohash
+	for d := range neighbors {
+		neighbors[d] = h.Neighbor(Direction(d))
+	}
+	return neighbors
+}
@@ -0,0 +1,99 @@
+package spatial
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+func TestEncodeGeohash(t *testing.T) {
+	tests := []struct {
+		p         geojson.LngLat
+		precision int
+		want      Geohash
+	}{
+		{geojson.LngLat{Longitude: -5.6, Latitude: 42.6}, 5, "ezs42"},
+		{geojson.LngLat{Longitude: 10.40744, Latitude: 57.64911}, 11, "u4pruydqqvj"},
+		{geojson.LngLat{Longitude: 12.4924, Latitude: 41.8902}, 0, "s"},
+		{geojson.LngLat{Longitude: -180, Latitude: -90}, 3, "000"},
+		{geojson.LngLat{Longitude: 180, Latitude: 90}, 3, "zzz"},
+	}
+	for _, tt := range tests {
+		if got := EncodeGeohash(tt.p, tt.precision); got != tt.want {
+			t.Errorf("EncodeGeohash(%v, %d) = %q, want %q", tt.p, tt.precision, got, tt.want)
+		}
+	}
+
+	if got := EncodeGeohash(geojson.LngLat{Longitude: 10.40744, Latitude: 57.64911}, 20); got.Precision() != MaxGeohashPrecision || got[:11] != "u4pruydqqvj" {
+		t.Errorf("expected precision to be clamped, got %q", got)
+	}
+}
+
+func TestParseGeohash(t *testing.T) {
+	if h, err := ParseGeohash("EZS42"); err != nil || h != "ezs42" {
+		t.Errorf("ParseGeohash(EZS42) = %q, %v", h, err)
+	}
+	for _, s := range []string{"", "ezs4a", "0123456789bcd"} {
+		if _, err := ParseGeohash(s); err == nil {
+			t.Errorf("ParseGeohash(%q): expected error", s)
+		}
+	}
+}
+
+func TestGeohash_Bounds(t *testing.T) {
+	h := Geohash("ezs42")
+	b := h.Bounds()
+	want := geojson.BBox{West: -5.625, South: 42.5830078125, East: -5.5810546875, North: 42.626953125}
+	if b != want {
+		t.Errorf("Bounds() = %v, want %v", b, want)
+	}
+
+	center := h.Center()
+	if math.Abs(center.Longitude+5.60302734375) > 1e-9 || math.Abs(center.Latitude-42.60498046875) > 1e-9 {
+		t.Errorf("Center() = %v", center)
+	}
+	if got := EncodeGeohash(center, 5); got != h {
+		t.Errorf("center encodes to %q", got)
+	}
+
+	ring := h.Boundary().Coordinates[0]
+	if len(ring) != 5 || ring[0][0] != b.West || ring[2][1] != b.North {
+		t.Errorf("unexpected boundary %v", ring)
+	}
+}
+
+func TestGeohash_Hierarchy(t *testing.T) {
+	h := Geohash("ezs42")
+	if p := h.Parent(); p != "ezs4" {
+		t.Errorf("Parent() = %q", p)
+	}
+	if p := Geohash("e").Parent(); p != "e" {
+		t.Errorf("Parent() at precision 1 = %q", p)
+	}
+	children := h.Children()
+	if children[0] != "ezs420" || children[31] != "ezs42z" {
+		t.Errorf("unexpected children %v", children)
+	}
+	for _, c := range children {
+		if c.Parent() != h || !h.Bounds().Contains(c.Center()) {
+			t.Errorf("child %q is not inside %q", c, h)
+		}
+	}
+}
+
+func TestGeohash_Neighbors(t *testing.T) {
+	got := Geohash("dqcjq").Neighbors()
+	want := [8]Geohash{"dqcjw", "dqcjx", "dqcjr", "dqcjp", "dqcjn", "dqcjj", "dqcjm", "dqcjt"}
+	if got != want {
+		t.Errorf("Neighbors() = %v, want %v", got, want)
+	}
+
+	// Across the antimeridian and beyond the poles.
+	if n := Geohash("2").Neighbor(West); n != "r" {
+		t.Errorf("Neighbor(West) of 2 = %q, want r", n)
+	}
+	if n := Geohash("b").Neighbor(North); n != "" {
+		t.Errorf("Neighbor(North) of b = %q, want none", n)
+	}
+}
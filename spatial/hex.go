@@ -0,0 +1,149 @@
+package spatial
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/tilemath"
+)
+
+// HexGrid is a grid of pointy-top hexagons laid out in Web Mercator, the
+// projection of Mapbox maps, so cells look regular on a map. The grid is
+// flat: it does not wrap around the antimeridian, and latitudes beyond the
+// Web Mercator limit are clamped.
+type HexGrid struct {
+	size float64
+}
+
+// NewHexGrid returns a grid of hexagons whose corners are size Web Mercator
+// meters from their center. A Web Mercator meter is a meter on the ground at
+// the equator and cos(latitude) meters elsewhere: divide the ground size by
+// the cosine of the latitude of the area of interest to get cells of that
+// size. NewHexGrid panics if size is not positive.
+func NewHexGrid(size float64) HexGrid {
+	if !(size > 0) {
+		panic(fmt.Sprintf("spatial: non-positive hexagon size %v", size))
+	}
+	return HexGrid{size: size}
+}
+
+// Size returns the distance from the center of a cell to its corners, in
+// Web Mercator meters.
+func (g HexGrid) Size() float64 {
+	return g.size
+}
+
+// Cell returns the cell containing p.
+func (g HexGrid) Cell(p geojson.LngLat) HexCell {
+	x, y := tilemath.Project(p.Longitude, p.Latitude)
+	q := (math.Sqrt(3)/3*x - y/3) / g.size
+	r := (2 * y / 3) / g.size
+	return roundHex(q, r)
+}
+
+// Center returns the center of c.
+func (g HexGrid) Center(c HexCell) geojson.LngLat {
+	x, y := g.center(c)
+	lon, lat := tilemath.Unproject(x, y)
+	return geojson.LngLat{Longitude: lon, Latitude: lat}
+}
+
+// Boundary returns the outline of c as a polygon, counterclockwise from its
+// south-east corner.
+func (g HexGrid) Boundary(c HexCell) *geojson.Polygon {
+	cx, cy := g.center(c)
+	ring := make([][]float64, 7)
+	for i := range 6 {
+		angle := math.Pi / 180 * float64(60*i-30)
+		lon, lat := tilemath.Unproject(cx+g.size*math.Cos(angle), cy+g.size*math.Sin(angle))
+		ring[i] = []float64{lon, lat}
+	}
+	ring[6] = ring[0]
+	return geojson.NewPolygon([][][]float64{ring})
+}
+
+// center returns the center of c in Web Mercator meters.
+func (g HexGrid) center(c HexCell) (x, y float64) {
+	x = g.size * math.Sqrt(3) * (float64(c.Q) + float64(c.R)/2)
+	y = g.size * 1.5 * float64(c.R)
+	return x, y
+}
+
+// HexCell is a cell of a HexGrid in axial coordinates: Q grows eastward and R
+// grows north-eastward.
+type HexCell struct {
+	Q, R int
+}
+
+// String returns the cell as "q,r".
+func (c HexCell) String() string {
+	return fmt.Sprintf("%d,%d", c.Q, c.R)
+}
+
+// hexDirections are the axial steps to the six neighbors, counterclockwise
+// from east.
+var hexDirections = [6]HexCell{{1, 0}, {0, 1}, {-1, 1}, {-1, 0}, {0, -1}, {1, -1}}
+
+// Neighbors returns the six adjacent cells, counterclockwise from east.
+func (c HexCell) Neighbors() [6]HexCell {
+	var neighbors [6]HexCell
+	for i, d := range hexDirections {
+		neighbors[i] = HexCell{c.Q + d.Q, c.R + d.R}
+	}
+	return neighbors
+}
+
+// Distance returns the number of steps between c and o.
+func (c HexCell) Distance(o HexCell) int {
+	dq, dr := c.Q-o.Q, c.R-o.R
+	return (abs(dq) + abs(dr) + abs(dq+dr)) / 2
+}
+
+// Ring returns the 6k cells exactly k steps from c, or c alone when k is 0.
+func (c HexCell) Ring(k int) []HexCell {
+	if k <= 0 {
+		return []HexCell{c}
+	}
+	cells := make([]HexCell, 0, 6*k)
+	// Start k steps south-west and walk each side of the ring.
+	cell := HexCell{c.Q + hexDirections[4].Q*k, c.R + hexDirections[4].R*k}
+	for _, d := range hexDirections {
+		for range k {
+			cells = append(cells, cell)
+			cell = HexCell{cell.Q + d.Q, cell.R + d.R}
+		}
+	}
+	return cells
+}
+
+// KRing returns the cells at most k steps from c, nearest first, starting
+// with c.
+func (c HexCell) KRing(k int) []HexCell {
+	cells := make([]HexCell, 0, 1+3*k*(k+1))
+	for i := range max(k, 0) + 1 {
+		cells = append(cells, c.Ring(i)...)
+	}
+	return cells
+}
+
+// roundHex returns the cell containing fractional axial coordinates.
+func roundHex(q, r float64) HexCell {
+	s := -q - r
+	rq, rr, rs := math.Round(q), math.Round(r), math.Round(s)
+	dq, dr, ds := math.Abs(rq-q), math.Abs(rr-r), math.Abs(rs-s)
+	switch {
+	case dq > dr && dq > ds:
+		rq = -rr - rs
+	case dr > ds:
+		rr = -rq - rs
+	}
+	return HexCell{int(rq), int(rr)}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
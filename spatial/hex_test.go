@@ -0,0 +1,112 @@
+package spatial
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geo"
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+func TestHexGrid_Cell(t *testing.T) {
+	grid := NewHexGrid(1000)
+	points := []geojson.LngLat{
+		{Longitude: 0, Latitude: 0},
+		{Longitude: 12.4924, Latitude: 41.8902},
+		{Longitude: -73.9857, Latitude: 40.7484},
+		{Longitude: 151.2093, Latitude: -33.8688},
+	}
+	for _, p := range points {
+		cell := grid.Cell(p)
+		center := grid.Center(cell)
+		if got := grid.Cell(center); got != cell {
+			t.Errorf("center %v of %v is in %v", center, cell, got)
+		}
+		// Web Mercator meters shrink by cos(latitude) on the ground.
+		if d := geo.Distance(p, center); d > 1000*math.Cos(p.Latitude*math.Pi/180)*1.01 {
+			t.Errorf("%v is %.0f m from the center of its cell", p, d)
+		}
+		boundary := grid.Boundary(cell)
+		if !geo.Contains(boundary, p) {
+			t.Errorf("%v is outside the boundary of its cell %v", p, boundary.Coordinates)
+		}
+	}
+
+	if c := grid.Cell(geojson.LngLat{}); c != (HexCell{}) {
+		t.Errorf("Cell(0,0) = %v", c)
+	}
+}
+
+func TestHexGrid_Boundary(t *testing.T) {
+	grid := NewHexGrid(1000)
+	ring := grid.Boundary(HexCell{Q: 3, R: -2}).Coordinates[0]
+	if len(ring) != 7 || ring[0][0] != ring[6][0] || ring[0][1] != ring[6][1] {
+		t.Fatalf("expected a closed ring of 6 corners, got %v", ring)
+	}
+
+	// Adjacent cells share an edge: two corners.
+	shared := 0
+	for _, a := range ring[:6] {
+		for _, b := range grid.Boundary(HexCell{Q: 4, R: -2}).Coordinates[0][:6] {
+			if math.Abs(a[0]-b[0]) < 1e-9 && math.Abs(a[1]-b[1]) < 1e-9 {
+				shared++
+			}
+		}
+	}
+	if shared != 2 {
+		t.Errorf("expected neighbors to share 2 corners, got %d", shared)
+	}
+}
+
+func TestHexCell_KRing(t *testing.T) {
+	c := HexCell{Q: 2, R: -1}
+
+	for _, n := range c.Neighbors() {
+		if d := c.Distance(n); d != 1 {
+			t.Errorf("neighbor %v at distance %d", n, d)
+		}
+	}
+
+	for k := range 4 {
+		ring := c.Ring(k)
+		want := max(6*k, 1)
+		if len(ring) != want {
+			t.Errorf("Ring(%d) has %d cells, want %d", k, len(ring), want)
+		}
+		for _, cell := range ring {
+			if d := c.Distance(cell); d != k {
+				t.Errorf("Ring(%d) contains %v at distance %d", k, cell, d)
+			}
+		}
+	}
+
+	kring := c.KRing(2)
+	if len(kring) != 19 || kring[0] != c {
+		t.Fatalf("KRing(2) = %v", kring)
+	}
+	seen := map[HexCell]bool{}
+	for _, cell := range kring {
+		if seen[cell] {
+			t.Errorf("KRing(2) contains %v twice", cell)
+		}
+		seen[cell] = true
+	}
+	if got := c.KRing(0); len(got) != 1 || got[0] != c {
+		t.Errorf("KRing(0) = %v", got)
+	}
+}
+
+func TestHexCell_String(t *testing.T) {
+	if s := (HexCell{Q: -3, R: 7}).String(); s != "-3,7" {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+func TestNewHexGrid_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	NewHexGrid(-1)
+}
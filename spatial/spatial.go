@@ -0,0 +1,55 @@
+// Package spatial indexes positions into cells for aggregation: geohashes,
+// with their bounds and neighbors, and a hexagonal grid with cell outlines
+// and k-rings. Quadtree cells, the other common hierarchy, are the tiles of
+// the tilemath package.
+//
+// Cells are computed in pure Go. The hexagonal grid is laid out in Web
+// Mercator; its cells are not H3 cells and its IDs are not compatible with
+// H3.
+//
+// Bucket groups results by cell, with a function giving the position of each
+// result. GeocodingLocation and SearchBoxLocation return the position of the
+// service results:
+//
+//	grid := spatial.NewHexGrid(500)
+//	cells := spatial.Bucket(resp.Features, grid.Cell, spatial.SearchBoxLocation)
+//	for cell, features := range cells {
+//		fmt.Println(grid.Center(cell), len(features))
+//	}
+package spatial
+
+import (
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/searchbox"
+)
+
+// GeohashIndex returns a function indexing positions by their geohash of
+// the given precision, for use with Bucket.
+func GeohashIndex(precision int) func(geojson.LngLat) Geohash {
+	return func(p geojson.LngLat) Geohash {
+		return EncodeGeohash(p, precision)
+	}
+}
+
+// Bucket groups items by the cell containing their position. index maps a
+// position to its cell, e.g. HexGrid.Cell or GeohashIndex, and location
+// returns the position of an item. Items keep their order within a cell.
+func Bucket[K comparable, T any](items []T, index func(geojson.LngLat) K, location func(T) geojson.LngLat) map[K][]T {
+	buckets := make(map[K][]T)
+	for _, item := range items {
+		cell := index(location(item))
+		buckets[cell] = append(buckets[cell], item)
+	}
+	return buckets
+}
+
+// GeocodingLocation returns the position of a geocoding result.
+func GeocodingLocation(f geocoding.Feature) geojson.LngLat {
+	return f.Properties.Coordinates.LngLat()
+}
+
+// SearchBoxLocation returns the position of a Search Box result.
+func SearchBoxLocation(f searchbox.Feature) geojson.LngLat {
+	return f.Properties.Coordinates.LngLat()
+}
@@ -0,0 +1,46 @@
+package spatial
+
+import (
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/searchbox"
+)
+
+func TestBucket(t *testing.T) {
+	poi := func(name string, lon, lat float64) searchbox.Feature {
+		var f searchbox.Feature
+		f.Properties.Name = name
+		f.Properties.Coordinates = searchbox.Coordinates{Longitude: lon, Latitude: lat}
+		return f
+	}
+	features := []searchbox.Feature{
+		poi("Colosseum", 12.4924, 41.8902),
+		poi("Louvre", 2.3376, 48.8606),
+		poi("Arch of Constantine", 12.4907, 41.8898),
+	}
+
+	byHash := Bucket(features, GeohashIndex(5), SearchBoxLocation)
+	if len(byHash) != 2 {
+		t.Fatalf("expected 2 geohash buckets, got %v", byHash)
+	}
+	rome := byHash["sr2yk"]
+	if len(rome) != 2 || rome[0].Properties.Name != "Colosseum" || rome[1].Properties.Name != "Arch of Constantine" {
+		t.Errorf("unexpected Rome bucket %v", rome)
+	}
+
+	grid := NewHexGrid(5000)
+	byHex := Bucket(features, grid.Cell, SearchBoxLocation)
+	if len(byHex) != 2 || len(byHex[grid.Cell(geojson.LngLat{Longitude: 12.4924, Latitude: 41.8902})]) != 2 {
+		t.Errorf("unexpected hex buckets %v", byHex)
+	}
+}
+
+func TestGeocodingLocation(t *testing.T) {
+	var f geocoding.Feature
+	f.Properties.Coordinates = geocoding.Coordinates{Longitude: 12.4924, Latitude: 41.8902}
+	if got := GeocodingLocation(f); got != (geojson.LngLat{Longitude: 12.4924, Latitude: 41.8902}) {
+		t.Errorf("GeocodingLocation() = %v", got)
+	}
+}
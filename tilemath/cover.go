@@ -0,0 +1,211 @@
+package tilemath
+
+import (
+	"math"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// edgeEpsilon shrinks tiles slightly when testing polygon intersection so that
+// geometry lying exactly on a tile edge does not pull in the neighbor.
+const edgeEpsilon = 1e-9
+
+// TileRange is an inclusive rectangle of tiles at a single zoom level.
+type TileRange struct {
+	Z    int
+	MinX int
+	MaxX int
+	MinY int
+	MaxY int
+}
+
+// Count returns the number of tiles in the range.
+func (r TileRange) Count() int {
+	return (r.MaxX - r.MinX + 1) * (r.MaxY - r.MinY + 1)
+}
+
+// Tiles returns every tile in the range, row by row.
+func (r TileRange) Tiles() []Tile {
+	out := make([]Tile, 0, r.Count())
+	for y := r.MinY; y <= r.MaxY; y++ {
+		for x := r.MinX; x <= r.MaxX; x++ {
+			out = append(out, Tile{Z: r.Z, X: x, Y: y})
+		}
+	}
+	return out
+}
+
+// BBoxRanges returns the tile ranges covering bbox at zoom z. A bbox crossing
+// the antimeridian yields two ranges, one on each side, unless its columns
+// meet or overlap at this zoom, in which case every column is covered by a
+// single range.
+func BBoxRanges(bbox geojson.BBox, z int) ([]TileRange, error) {
+	if err := bbox.Validate(); err != nil {
+		return nil, err
+	}
+
+	nw := TileAt(bbox.West, bbox.North, z)
+	se := TileAt(bbox.East, bbox.South, z)
+
+	if !bbox.CrossesAntimeridian() {
+		return []TileRange{{Z: z, MinX: nw.X, MaxX: se.X, MinY: nw.Y, MaxY: se.Y}}, nil
+	}
+
+	last := 1<<z - 1
+	if nw.X <= se.X {
+		return []TileRange{{Z: z, MinX: 0, MaxX: last, MinY: nw.Y, MaxY: se.Y}}, nil
+	}
+	return []TileRange{
+		{Z: z, MinX: nw.X, MaxX: last, MinY: nw.Y, MaxY: se.Y},
+		{Z: z, MinX: 0, MaxX: se.X, MinY: nw.Y, MaxY: se.Y},
+	}, nil
+}
+
+// CoverBBox returns the tiles intersecting bbox at zoom z.
+func CoverBBox(bbox geojson.BBox, z int) ([]Tile, error) {
+	ranges, err := BBoxRanges(bbox, z)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Tile
+	for _, r := range ranges {
+		out = append(out, r.Tiles()...)
+	}
+	return out, nil
+}
+
+// CoverPolygon returns the tiles intersecting the polygon at zoom z, taking
+// holes into account.
+func CoverPolygon(polygon *geojson.Polygon, z int) []Tile {
+	return cover([][][][]float64{polygon.Coordinates}, z)
+}
+
+// CoverMultiPolygon returns the tiles intersecting any polygon of the
+// multipolygon at zoom z.
+func CoverMultiPolygon(multi *geojson.MultiPolygon, z int) []Tile {
+	return cover(multi.Coordinates, z)
+}
+
+// cover returns the tiles intersecting a set of polygons at zoom z.
+func cover(polygons [][][][]float64, z int) []Tile {
+	seen := map[Tile]bool{}
+	var out []Tile
+
+	n := 1 << z
+	for _, polygon := range polygons {
+		rings := toTileSpace(polygon, z)
+		if len(rings) == 0 {
+			continue
+		}
+
+		minX, minY, maxX, maxY := ringBounds(rings[0])
+		for y := clamp(int(math.Floor(minY)), n); y <= clamp(int(math.Floor(maxY)), n); y++ {
+			for x := clamp(int(math.Floor(minX)), n); x <= clamp(int(math.Floor(maxX)), n); x++ {
+				t := Tile{Z: z, X: x, Y: y}
+				if seen[t] || !intersectsTile(rings, float64(x), float64(y)) {
+					continue
+				}
+				seen[t] = true
+				out = append(out, t)
+			}
+		}
+	}
+
+	return out
+}
+
+// toTileSpace projects polygon rings into fractional tile coordinates.
+func toTileSpace(polygon [][][]float64, z int) [][][2]float64 {
+	out := make([][][2]float64, 0, len(polygon))
+	for _, ring := range polygon {
+		projected := make([][2]float64, 0, len(ring))
+		for _, pos := range ring {
+			if len(pos) < 2 {
+				continue
+			}
+			x, y := LngLatToTile(pos[0], pos[1], z)
+			projected = append(projected, [2]float64{x, y})
+		}
+		if len(projected) > 0 {
+			out = append(out, projected)
+		}
+	}
+	return out
+}
+
+// ringBounds returns the bounding rectangle of a ring.
+func ringBounds(ring [][2]float64) (minX, minY, maxX, maxY float64) {
+	minX, minY = math.Inf(1), math.Inf(1)
+	maxX, maxY = math.Inf(-1), math.Inf(-1)
+	for _, p := range ring {
+		minX, maxX = math.Min(minX, p[0]), math.Max(maxX, p[0])
+		minY, maxY = math.Min(minY, p[1]), math.Max(maxY, p[1])
+	}
+	return minX, minY, maxX, maxY
+}
+
+// intersectsTile reports whether the polygon intersects the unit tile at (x, y).
+// Either an edge crosses the tile, or the tile lies entirely inside the polygon.
+func intersectsTile(rings [][][2]float64, x, y float64) bool {
+	minX, minY := x+edgeEpsilon, y+edgeEpsilon
+	maxX, maxY := x+1-edgeEpsilon, y+1-edgeEpsilon
+
+	for _, ring := range rings {
+		for i := range ring {
+			a, b := ring[i], ring[(i+1)%len(ring)]
+			if segmentIntersectsRect(a, b, minX, minY, maxX, maxY) {
+				return true
+			}
+		}
+	}
+
+	return containsPoint(rings, x+0.5, y+0.5)
+}
+
+// segmentIntersectsRect clips the segment a-b against a rectangle
+// (Liang-Barsky) and reports whether any part of it remains.
+func segmentIntersectsRect(a, b [2]float64, minX, minY, maxX, maxY float64) bool {
+	t0, t1 := 0.0, 1.0
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	p := [4]float64{-dx, dx, -dy, dy}
+	q := [4]float64{a[0] - minX, maxX - a[0], a[1] - minY, maxY - a[1]}
+
+	for i := 0; i < 4; i++ {
+		if p[i] == 0 {
+			if q[i] < 0 {
+				return false
+			}
+			continue
+		}
+		r := q[i] / p[i]
+		if p[i] < 0 {
+			if r > t1 {
+				return false
+			}
+			t0 = math.Max(t0, r)
+		} else {
+			if r < t0 {
+				return false
+			}
+			t1 = math.Min(t1, r)
+		}
+	}
+
+	return t0 <= t1
+}
+
+// containsPoint reports whether (x, y) is inside the polygon using the
+// even-odd rule, so points inside holes are excluded.
+func containsPoint(rings [][][2]float64, x, y float64) bool {
+	inside := false
+	for _, ring := range rings {
+		for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+			a, b := ring[i], ring[j]
+			if (a[1] > y) != (b[1] > y) && x < (b[0]-a[0])*(y-a[1])/(b[1]-a[1])+a[0] {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
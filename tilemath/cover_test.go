@@ -0,0 +1,131 @@
+package tilemath
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+func sortTiles(tiles []Tile) []Tile {
+	sort.Slice(tiles, func(i, j int) bool {
+		if tiles[i].Y != tiles[j].Y {
+			return tiles[i].Y < tiles[j].Y
+		}
+		return tiles[i].X < tiles[j].X
+	})
+	return tiles
+}
+
+func TestBBoxRanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		bbox     geojson.BBox
+		z        int
+		expected int
+		ranges   int
+		wantErr  bool
+	}{
+		{name: "whole world zoom 2", bbox: geojson.BBox{West: -180, South: -90, East: 180, North: 90}, z: 2, expected: 16, ranges: 1},
+		{name: "single tile", bbox: geojson.BBox{West: 12.49, South: 41.89, East: 12.50, North: 41.90}, z: 10, expected: 1, ranges: 1},
+		{name: "crossing the antimeridian", bbox: geojson.BBox{West: 170, South: -20, East: -170, North: -10}, z: 2, expected: 2, ranges: 2},
+		{name: "crossing the antimeridian at zoom 0", bbox: geojson.BBox{West: 170, South: -20, East: -170, North: -10}, z: 0, expected: 1, ranges: 1},
+		{name: "crossing the antimeridian with overlapping columns", bbox: geojson.BBox{West: 10, South: -20, East: 5, North: -10}, z: 1, expected: 2, ranges: 1},
+		{name: "crossing the antimeridian with adjacent columns", bbox: geojson.BBox{West: 10, South: -20, East: -10, North: -10}, z: 1, expected: 2, ranges: 2},
+		{name: "latitudes swapped", bbox: geojson.BBox{West: 0, South: 10, East: 1, North: 5}, wantErr: true},
+		{name: "longitude out of range", bbox: geojson.BBox{West: -190, South: 0, East: 10, North: 10}, wantErr: true},
+		{name: "latitude out of range", bbox: geojson.BBox{West: 0, South: -91, East: 10, North: 10}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges, err := BBoxRanges(tt.bbox, tt.z)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BBoxRanges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(ranges) != tt.ranges {
+				t.Errorf("expected %d ranges, got %d", tt.ranges, len(ranges))
+			}
+
+			tiles, _ := CoverBBox(tt.bbox, tt.z)
+			if len(tiles) != tt.expected {
+				t.Errorf("CoverBBox() returned %d tiles, want %d", len(tiles), tt.expected)
+			}
+			seen := map[Tile]bool{}
+			for _, tile := range tiles {
+				if seen[tile] {
+					t.Errorf("CoverBBox() returned %v twice", tile)
+				}
+				seen[tile] = true
+			}
+		})
+	}
+}
+
+func TestCoverPolygon(t *testing.T) {
+	t.Run("single tile", func(t *testing.T) {
+		polygon := geojson.NewPolygon([][][]float64{{
+			{12.49, 41.89}, {12.50, 41.89}, {12.50, 41.90}, {12.49, 41.90}, {12.49, 41.89},
+		}})
+
+		got := CoverPolygon(polygon, 10)
+		if len(got) != 1 || got[0] != (Tile{Z: 10, X: 547, Y: 380}) {
+			t.Errorf("CoverPolygon() = %v, want [10/547/380]", got)
+		}
+	})
+
+	t.Run("triangle skips corner tile", func(t *testing.T) {
+		// A triangle over the north-west, north-east and south-west quadrants
+		// at zoom 1, never reaching the south-east quadrant.
+		polygon := geojson.NewPolygon([][][]float64{{
+			{-170, 80}, {170, 80}, {-170, -80}, {-170, 80},
+		}})
+
+		got := sortTiles(CoverPolygon(polygon, 1))
+		expected := []Tile{{Z: 1, X: 0, Y: 0}, {Z: 1, X: 1, Y: 0}, {Z: 1, X: 0, Y: 1}}
+		if len(got) != len(expected) {
+			t.Fatalf("CoverPolygon() = %v, want %v", got, expected)
+		}
+		for i := range expected {
+			if got[i] != expected[i] {
+				t.Errorf("CoverPolygon()[%d] = %v, want %v", i, got[i], expected[i])
+			}
+		}
+	})
+
+	t.Run("hole excludes interior tiles", func(t *testing.T) {
+		// A 4x4 block of zoom 2 tiles with a hole around the central 2x2.
+		outer := [][]float64{{-179, 84}, {179, 84}, {179, -84}, {-179, -84}, {-179, 84}}
+		hole := [][]float64{{-80, 60}, {-80, -60}, {80, -60}, {80, 60}, {-80, 60}}
+
+		withoutHole := CoverPolygon(geojson.NewPolygon([][][]float64{outer}), 3)
+		withHole := CoverPolygon(geojson.NewPolygon([][][]float64{outer, hole}), 3)
+
+		if len(withoutHole) != 64 {
+			t.Fatalf("expected 64 tiles without hole, got %d", len(withoutHole))
+		}
+		if len(withHole) >= len(withoutHole) {
+			t.Errorf("expected hole to remove tiles, got %d", len(withHole))
+		}
+
+		// The tile at the origin's south-east lies wholly inside the hole.
+		for _, tile := range withHole {
+			if tile == (Tile{Z: 3, X: 4, Y: 4}) {
+				t.Errorf("tile %v inside the hole should be excluded", tile)
+			}
+		}
+	})
+
+	t.Run("multipolygon dedupes", func(t *testing.T) {
+		square := [][][]float64{{{1, 1}, {2, 1}, {2, 2}, {1, 2}, {1, 1}}}
+		multi := geojson.NewMultiPolygon([][][][]float64{square, square})
+
+		if got := CoverMultiPolygon(multi, 0); len(got) != 1 {
+			t.Errorf("CoverMultiPolygon() = %v, want a single tile", got)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package tilemath
+
+import "math"
+
+const (
+	// EarthRadius is the WGS84 semi-major axis used by Web Mercator, in meters.
+	EarthRadius = 6378137.0
+
+	// DefaultTileSize is the tile size in pixels used by Mapbox vector styles.
+	DefaultTileSize = 512
+)
+
+// originShift is half the circumference of the Web Mercator world, in meters.
+const originShift = math.Pi * EarthRadius
+
+// Project converts a position to Web Mercator (EPSG:3857) meters.
+// Latitudes beyond the Web Mercator limit are clamped.
+func Project(lon, lat float64) (x, y float64) {
+	lat = clampLatitude(lat)
+	x = lon * originShift / 180
+	y = math.Log(math.Tan((90+lat)*math.Pi/360)) * EarthRadius
+	return x, y
+}
+
+// Unproject converts Web Mercator (EPSG:3857) meters to a position.
+func Unproject(x, y float64) (lon, lat float64) {
+	lon = x / originShift * 180
+	lat = (2*math.Atan(math.Exp(y/EarthRadius)) - math.Pi/2) * 180 / math.Pi
+	return lon, lat
+}
+
+// LngLatToPixel converts a position to global pixel coordinates at a
+// (possibly fractional) zoom level for tiles of tileSize pixels.
+// The origin is the top-left corner of the world.
+func LngLatToPixel(lon, lat, zoom float64, tileSize int) (px, py float64) {
+	x, y := LngLatToTile(lon, lat, 0)
+	scale := worldSize(zoom, tileSize)
+	return x * scale, y * scale
+}
+
+// PixelToLngLat converts global pixel coordinates at a (possibly fractional)
+// zoom level for tiles of tileSize pixels back to a position.
+func PixelToLngLat(px, py, zoom float64, tileSize int) (lon, lat float64) {
+	scale := worldSize(zoom, tileSize)
+	return TileToLngLat(px/scale, py/scale, 0)
+}
+
+// Resolution returns the ground resolution in meters per pixel at the given
+// latitude and zoom level for tiles of tileSize pixels.
+func Resolution(lat, zoom float64, tileSize int) float64 {
+	lat = clampLatitude(lat)
+	return math.Cos(lat*math.Pi/180) * 2 * originShift / worldSize(zoom, tileSize)
+}
+
+// worldSize returns the width of the world in pixels.
+func worldSize(zoom float64, tileSize int) float64 {
+	return float64(tileSize) * math.Exp2(zoom)
+}
@@ -0,0 +1,57 @@
+package tilemath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProject(t *testing.T) {
+	tests := []struct {
+		name     string
+		lon, lat float64
+		wantX    float64
+		wantY    float64
+	}{
+		{name: "origin", lon: 0, lat: 0, wantX: 0, wantY: 0},
+		{name: "antimeridian", lon: 180, lat: 0, wantX: originShift, wantY: 0},
+		{name: "north limit", lon: -180, lat: MaxLatitude, wantX: -originShift, wantY: originShift},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := Project(tt.lon, tt.lat)
+			if math.Abs(x-tt.wantX) > 1e-3 || math.Abs(y-tt.wantY) > 1e-3 {
+				t.Errorf("Project() = (%f, %f), want (%f, %f)", x, y, tt.wantX, tt.wantY)
+			}
+
+			lon, lat := Unproject(x, y)
+			if !almostEqual(lon, tt.lon) || !almostEqual(lat, tt.lat) {
+				t.Errorf("Unproject() = (%f, %f), want (%f, %f)", lon, lat, tt.lon, tt.lat)
+			}
+		})
+	}
+}
+
+func TestLngLatToPixel(t *testing.T) {
+	px, py := LngLatToPixel(0, 0, 1, DefaultTileSize)
+	if !almostEqual(px, 512) || !almostEqual(py, 512) {
+		t.Errorf("LngLatToPixel() = (%f, %f), want (512, 512)", px, py)
+	}
+
+	lon, lat := PixelToLngLat(768, 256, 1, DefaultTileSize)
+	wantLon, wantLat := TileToLngLat(1.5, 0.5, 1)
+	if !almostEqual(lon, wantLon) || !almostEqual(lat, wantLat) {
+		t.Errorf("PixelToLngLat() = (%f, %f), want (%f, %f)", lon, lat, wantLon, wantLat)
+	}
+}
+
+func TestResolution(t *testing.T) {
+	// 512px tiles at zoom 0 cover the equator at about 78271.517 m/px.
+	if got := Resolution(0, 0, DefaultTileSize); math.Abs(got-78271.517) > 1e-3 {
+		t.Errorf("Resolution() = %f, want 78271.517", got)
+	}
+
+	if got := Resolution(60, 0, DefaultTileSize); math.Abs(got-78271.517/2) > 1e-3 {
+		t.Errorf("Resolution() at 60° = %f, want half the equatorial value", got)
+	}
+}
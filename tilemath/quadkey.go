@@ -0,0 +1,46 @@
+package tilemath
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Quadkey returns the Bing Maps quadkey of the tile. The zoom 0 tile has an
+// empty quadkey.
+func (t Tile) Quadkey() string {
+	var b strings.Builder
+	b.Grow(t.Z)
+	for i := t.Z; i > 0; i-- {
+		digit := byte('0')
+		mask := 1 << (i - 1)
+		if t.X&mask != 0 {
+			digit++
+		}
+		if t.Y&mask != 0 {
+			digit += 2
+		}
+		b.WriteByte(digit)
+	}
+	return b.String()
+}
+
+// TileFromQuadkey parses a quadkey into a tile.
+func TileFromQuadkey(quadkey string) (Tile, error) {
+	t := Tile{Z: len(quadkey)}
+	for i := 0; i < len(quadkey); i++ {
+		mask := 1 << (t.Z - i - 1)
+		switch quadkey[i] {
+		case '0':
+		case '1':
+			t.X |= mask
+		case '2':
+			t.Y |= mask
+		case '3':
+			t.X |= mask
+			t.Y |= mask
+		default:
+			return Tile{}, fmt.Errorf("invalid quadkey digit %q at position %d", quadkey[i], i)
+		}
+	}
+	return t, nil
+}
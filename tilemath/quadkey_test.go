@@ -0,0 +1,37 @@
+package tilemath
+
+import "testing"
+
+func TestQuadkey(t *testing.T) {
+	tests := []struct {
+		tile    Tile
+		quadkey string
+	}{
+		{tile: Tile{Z: 0, X: 0, Y: 0}, quadkey: ""},
+		{tile: Tile{Z: 1, X: 1, Y: 0}, quadkey: "1"},
+		{tile: Tile{Z: 1, X: 0, Y: 1}, quadkey: "2"},
+		{tile: Tile{Z: 3, X: 3, Y: 5}, quadkey: "213"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tile.String(), func(t *testing.T) {
+			if got := tt.tile.Quadkey(); got != tt.quadkey {
+				t.Errorf("Quadkey() = %q, want %q", got, tt.quadkey)
+			}
+
+			got, err := TileFromQuadkey(tt.quadkey)
+			if err != nil {
+				t.Fatalf("TileFromQuadkey() error = %v", err)
+			}
+			if got != tt.tile {
+				t.Errorf("TileFromQuadkey() = %v, want %v", got, tt.tile)
+			}
+		})
+	}
+}
+
+func TestTileFromQuadkey_Invalid(t *testing.T) {
+	if _, err := TileFromQuadkey("0142"); err == nil {
+		t.Error("expected error for invalid digit")
+	}
+}
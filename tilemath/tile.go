@@ -0,0 +1,116 @@
+// Package tilemath provides slippy map tile, quadkey and Web Mercator
+// calculations used throughout the SDK.
+package tilemath
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// MaxLatitude is the latitude limit of the Web Mercator projection.
+const MaxLatitude = 85.0511287798066
+
+// Tile identifies a tile in the XYZ (slippy map) scheme, with the origin at
+// the top-left (north-west) corner of the world.
+type Tile struct {
+	Z int
+	X int
+	Y int
+}
+
+// String returns the tile address as "z/x/y".
+func (t Tile) String() string {
+	return fmt.Sprintf("%d/%d/%d", t.Z, t.X, t.Y)
+}
+
+// Valid reports whether the tile address exists at its zoom level.
+func (t Tile) Valid() bool {
+	if t.Z < 0 || t.Z > 30 {
+		return false
+	}
+	n := 1 << t.Z
+	return t.X >= 0 && t.X < n && t.Y >= 0 && t.Y < n
+}
+
+// Bounds returns the geographic extent of the tile.
+func (t Tile) Bounds() geojson.BBox {
+	west, north := TileToLngLat(float64(t.X), float64(t.Y), t.Z)
+	east, south := TileToLngLat(float64(t.X+1), float64(t.Y+1), t.Z)
+	return geojson.BBox{West: west, South: south, East: east, North: north}
+}
+
+// Center returns the longitude and latitude of the tile center.
+func (t Tile) Center() (lon, lat float64) {
+	return TileToLngLat(float64(t.X)+0.5, float64(t.Y)+0.5, t.Z)
+}
+
+// Parent returns the tile one zoom level up that contains t.
+// The parent of a zoom 0 tile is the tile itself.
+func (t Tile) Parent() Tile {
+	if t.Z == 0 {
+		return t
+	}
+	return Tile{Z: t.Z - 1, X: t.X >> 1, Y: t.Y >> 1}
+}
+
+// Children returns the four tiles one zoom level down, in quadkey order
+// (top-left, top-right, bottom-left, bottom-right).
+func (t Tile) Children() [4]Tile {
+	z, x, y := t.Z+1, t.X<<1, t.Y<<1
+	return [4]Tile{
+		{Z: z, X: x, Y: y},
+		{Z: z, X: x + 1, Y: y},
+		{Z: z, X: x, Y: y + 1},
+		{Z: z, X: x + 1, Y: y + 1},
+	}
+}
+
+// TMSY returns the row of the tile in the TMS scheme, whose origin is the
+// bottom-left corner (as used by MBTiles).
+func (t Tile) TMSY() int {
+	return (1 << t.Z) - 1 - t.Y
+}
+
+// TileAt returns the tile containing the given position at zoom z.
+// Latitudes beyond the Web Mercator limit are clamped.
+func TileAt(lon, lat float64, z int) Tile {
+	x, y := LngLatToTile(lon, lat, z)
+	n := 1 << z
+	return Tile{Z: z, X: clamp(int(math.Floor(x)), n), Y: clamp(int(math.Floor(y)), n)}
+}
+
+// LngLatToTile returns the fractional tile coordinates of a position at zoom z.
+func LngLatToTile(lon, lat float64, z int) (x, y float64) {
+	n := math.Exp2(float64(z))
+	lat = clampLatitude(lat)
+	rad := lat * math.Pi / 180
+	x = (lon + 180) / 360 * n
+	y = (1 - math.Log(math.Tan(rad)+1/math.Cos(rad))/math.Pi) / 2 * n
+	return x, y
+}
+
+// TileToLngLat converts fractional tile coordinates at zoom z to a position.
+func TileToLngLat(x, y float64, z int) (lon, lat float64) {
+	n := math.Exp2(float64(z))
+	lon = x/n*360 - 180
+	lat = math.Atan(math.Sinh(math.Pi-2*math.Pi*y/n)) * 180 / math.Pi
+	return lon, lat
+}
+
+// clamp clamps a tile index to [0, n).
+func clamp(v, n int) int {
+	if v < 0 {
+		return 0
+	}
+	if v >= n {
+		return n - 1
+	}
+	return v
+}
+
+// clampLatitude clamps a latitude to the Web Mercator limits.
+func clampLatitude(lat float64) float64 {
+	return math.Max(-MaxLatitude, math.Min(MaxLatitude, lat))
+}
@@ -0,0 +1,123 @@
+package tilemath
+
+import (
+	"math"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestTileAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		lon, lat float64
+		z        int
+		expected Tile
+	}{
+		{name: "origin zoom 0", lon: 0, lat: 0, z: 0, expected: Tile{Z: 0, X: 0, Y: 0}},
+		{name: "north-west corner", lon: -180, lat: 90, z: 3, expected: Tile{Z: 3, X: 0, Y: 0}},
+		{name: "south-east corner", lon: 180, lat: -90, z: 3, expected: Tile{Z: 3, X: 7, Y: 7}},
+		{name: "Rome zoom 10", lon: 12.4964, lat: 41.9028, z: 10, expected: Tile{Z: 10, X: 547, Y: 380}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TileAt(tt.lon, tt.lat, tt.z); got != tt.expected {
+				t.Errorf("TileAt() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTileToLngLat(t *testing.T) {
+	tests := []struct {
+		name    string
+		x, y    float64
+		z       int
+		wantLon float64
+		wantLat float64
+	}{
+		{name: "world origin", x: 0, y: 0, z: 0, wantLon: -180, wantLat: MaxLatitude},
+		{name: "world center", x: 0.5, y: 0.5, z: 0, wantLon: 0, wantLat: 0},
+		{name: "zoom 1 corner", x: 2, y: 2, z: 1, wantLon: 180, wantLat: -MaxLatitude},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lon, lat := TileToLngLat(tt.x, tt.y, tt.z)
+			if !almostEqual(lon, tt.wantLon) || !almostEqual(lat, tt.wantLat) {
+				t.Errorf("TileToLngLat() = (%f, %f), want (%f, %f)", lon, lat, tt.wantLon, tt.wantLat)
+			}
+
+			x, y := LngLatToTile(lon, lat, tt.z)
+			if !almostEqual(x, tt.x) || !almostEqual(y, tt.y) {
+				t.Errorf("LngLatToTile() = (%f, %f), want (%f, %f)", x, y, tt.x, tt.y)
+			}
+		})
+	}
+}
+
+func TestTile_Bounds(t *testing.T) {
+	bounds := Tile{Z: 1, X: 1, Y: 0}.Bounds()
+
+	if !almostEqual(bounds.West, 0) || !almostEqual(bounds.South, 0) ||
+		!almostEqual(bounds.East, 180) || !almostEqual(bounds.North, MaxLatitude) {
+		t.Fatalf("Bounds() = %v, want 0,0,180,%f", bounds, MaxLatitude)
+	}
+
+	lon, lat := Tile{Z: 1, X: 1, Y: 0}.Center()
+	if !almostEqual(lon, 90) || lat <= 0 {
+		t.Errorf("Center() = (%f, %f), want lon 90 in the northern hemisphere", lon, lat)
+	}
+}
+
+func TestTile_Hierarchy(t *testing.T) {
+	tile := Tile{Z: 3, X: 5, Y: 2}
+
+	if got := tile.Parent(); got != (Tile{Z: 2, X: 2, Y: 1}) {
+		t.Errorf("Parent() = %v, want 2/2/1", got)
+	}
+
+	if got := (Tile{}).Parent(); got != (Tile{}) {
+		t.Errorf("Parent() of root = %v, want 0/0/0", got)
+	}
+
+	for _, child := range tile.Children() {
+		if child.Parent() != tile {
+			t.Errorf("child %v does not have %v as parent", child, tile)
+		}
+	}
+
+	if got := tile.Children()[3]; got != (Tile{Z: 4, X: 11, Y: 5}) {
+		t.Errorf("Children()[3] = %v, want 4/11/5", got)
+	}
+}
+
+func TestTile_Valid(t *testing.T) {
+	tests := []struct {
+		tile     Tile
+		expected bool
+	}{
+		{tile: Tile{Z: 0, X: 0, Y: 0}, expected: true},
+		{tile: Tile{Z: 2, X: 3, Y: 3}, expected: true},
+		{tile: Tile{Z: 2, X: 4, Y: 0}, expected: false},
+		{tile: Tile{Z: 2, X: 0, Y: -1}, expected: false},
+		{tile: Tile{Z: -1}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tile.String(), func(t *testing.T) {
+			if got := tt.tile.Valid(); got != tt.expected {
+				t.Errorf("Valid() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTile_TMSY(t *testing.T) {
+	if got := (Tile{Z: 2, X: 1, Y: 0}).TMSY(); got != 3 {
+		t.Errorf("TMSY() = %d, want 3", got)
+	}
+}
@@ -0,0 +1,104 @@
+package tiles
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+)
+
+// Vector fetches a Mapbox Vector Tile. Use Tile.Decode or Decode to parse it.
+func (s *Service) Vector(ctx context.Context, req *VectorRequest) (*Tile, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.TilesVector, req)
+
+	if err := validateTile(req.Tileset, req.Z, req.X, req.Y); err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("%s/%s/%d/%d/%d.mvt", tilesPath, req.Tileset, req.Z, req.X, req.Y)
+
+	data, contentType, err := s.httpClient.GetRaw(ctx, path, s.buildQuery())
+	if err != nil {
+		return nil, fmt.Errorf("vector tile request failed: %w", err)
+	}
+
+	return &Tile{Z: req.Z, X: req.X, Y: req.Y, Data: data, ContentType: contentType}, nil
+}
+
+// Raster fetches a raster tile image.
+func (s *Service) Raster(ctx context.Context, req *RasterRequest) (*Tile, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.TilesRaster, req)
+
+	if err := validateRasterRequest(req); err != nil {
+		return nil, err
+	}
+
+	format := req.Format
+	if format == "" {
+		format = FormatPNG
+	}
+
+	scale := ""
+	if req.HighDPI {
+		scale = "@2x"
+	}
+
+	path := fmt.Sprintf("%s/%s/%d/%d/%d%s.%s", tilesPath, req.Tileset, req.Z, req.X, req.Y, scale, format)
+
+	data, contentType, err := s.httpClient.GetRaw(ctx, path, s.buildQuery())
+	if err != nil {
+		return nil, fmt.Errorf("raster tile request failed: %w", err)
+	}
+
+	return &Tile{Z: req.Z, X: req.X, Y: req.Y, Data: data, ContentType: contentType}, nil
+}
+
+// Decode parses the tile as a Mapbox Vector Tile.
+func (t *Tile) Decode() (*VectorTile, error) {
+	return Decode(t.Data, t.Z, t.X, t.Y)
+}
+
+// validateRasterRequest validates the Raster request parameters.
+func validateRasterRequest(req *RasterRequest) error {
+	if err := validateTile(req.Tileset, req.Z, req.X, req.Y); err != nil {
+		return err
+	}
+
+	switch req.Format {
+	case "", FormatPNG, FormatPNG32, FormatPNG64, FormatPNG128, FormatPNG256,
+		FormatJPG70, FormatJPG80, FormatJPG90, FormatWebP:
+		return nil
+	default:
+		return fmt.Errorf("unsupported raster format %q", req.Format)
+	}
+}
+
+// validateTile validates a tileset ID and tile address.
+func validateTile(tileset string, z, x, y int) error {
+	if tileset == "" {
+		return fmt.Errorf("tileset is required")
+	}
+
+	if z < 0 || z > maxZoom {
+		return fmt.Errorf("zoom must be between 0 and %d, got %d", maxZoom, z)
+	}
+
+	n := 1 << z
+	if x < 0 || x >= n {
+		return fmt.Errorf("x must be between 0 and %d at zoom %d, got %d", n-1, z, x)
+	}
+	if y < 0 || y >= n {
+		return fmt.Errorf("y must be between 0 and %d at zoom %d, got %d", n-1, z, y)
+	}
+
+	return nil
+}
+
+// buildQuery builds query parameters for tile requests.
+func (s *Service) buildQuery() url.Values {
+	q := url.Values{}
+	q.Set("access_token", s.token)
+	return q
+}
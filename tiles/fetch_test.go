@@ -0,0 +1,156 @@
+package tiles
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
+)
+
+func TestService_Vector(t *testing.T) {
+	payload := encodeTile(encodeLayer("poi", 4096, nil, nil, []testFeature{
+		{typ: GeomPoint, geometry: []uint32{command(cmdMoveTo, 1), zz(2048), zz(2048)}},
+	}))
+
+	tests := []struct {
+		name       string
+		request    *VectorRequest
+		mockStatus int
+		wantErr    bool
+	}{
+		{
+			name:       "successful fetch",
+			request:    &VectorRequest{Tileset: "mapbox.mapbox-streets-v8", Z: 1, X: 1, Y: 0},
+			mockStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name:       "missing tileset",
+			request:    &VectorRequest{Z: 1, X: 1, Y: 0},
+			mockStatus: http.StatusOK,
+			wantErr:    true,
+		},
+		{
+			name:       "x out of range",
+			request:    &VectorRequest{Tileset: "mapbox.mapbox-streets-v8", Z: 1, X: 2, Y: 0},
+			mockStatus: http.StatusOK,
+			wantErr:    true,
+		},
+		{
+			name:       "zoom out of range",
+			request:    &VectorRequest{Tileset: "mapbox.mapbox-streets-v8", Z: 23},
+			mockStatus: http.StatusOK,
+			wantErr:    true,
+		},
+		{
+			name:       "tile not found",
+			request:    &VectorRequest{Tileset: "mapbox.mapbox-streets-v8", Z: 1, X: 1, Y: 0},
+			mockStatus: http.StatusNotFound,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v4/mapbox.mapbox-streets-v8/1/1/0.mvt" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				testutil.AssertQueryParam(t, r, "access_token", "test-token")
+
+				w.Header().Set("Content-Type", "application/vnd.mapbox-vector-tile")
+				w.WriteHeader(tt.mockStatus)
+				w.Write(payload)
+			})
+			defer server.Close()
+
+			service := New("test-token", internalhttp.New(server.URL, nil))
+
+			tile, err := service.Vector(context.Background(), tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Vector() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if tile.ContentType != "application/vnd.mapbox-vector-tile" {
+				t.Errorf("unexpected content type %q", tile.ContentType)
+			}
+
+			decoded, err := tile.Decode()
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if decoded.Z != 1 || decoded.X != 1 || decoded.Y != 0 {
+				t.Errorf("unexpected tile address %d/%d/%d", decoded.Z, decoded.X, decoded.Y)
+			}
+			if len(decoded.Layers) != 1 {
+				t.Errorf("expected 1 layer, got %d", len(decoded.Layers))
+			}
+		})
+	}
+}
+
+func TestService_Raster(t *testing.T) {
+	tests := []struct {
+		name         string
+		request      *RasterRequest
+		expectedPath string
+		wantErr      bool
+	}{
+		{
+			name:         "default format",
+			request:      &RasterRequest{Tileset: "mapbox.satellite", Z: 2, X: 1, Y: 3},
+			expectedPath: "/v4/mapbox.satellite/2/1/3.png",
+		},
+		{
+			name:         "high DPI webp",
+			request:      &RasterRequest{Tileset: "mapbox.satellite", Z: 2, X: 1, Y: 3, Format: FormatWebP, HighDPI: true},
+			expectedPath: "/v4/mapbox.satellite/2/1/3@2x.webp",
+		},
+		{
+			name:         "jpeg",
+			request:      &RasterRequest{Tileset: "mapbox.satellite", Z: 0, X: 0, Y: 0, Format: FormatJPG90},
+			expectedPath: "/v4/mapbox.satellite/0/0/0.jpg90",
+		},
+		{
+			name:    "unsupported format",
+			request: &RasterRequest{Tileset: "mapbox.satellite", Format: "gif"},
+			wantErr: true,
+		},
+		{
+			name:    "negative y",
+			request: &RasterRequest{Tileset: "mapbox.satellite", Z: 1, Y: -1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.expectedPath {
+					t.Errorf("expected path %s, got %s", tt.expectedPath, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "image/png")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("\x89PNG"))
+			})
+			defer server.Close()
+
+			service := New("test-token", internalhttp.New(server.URL, nil))
+
+			tile, err := service.Raster(context.Background(), tt.request)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Raster() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && string(tile.Data) != "\x89PNG" {
+				t.Errorf("unexpected tile data %q", tile.Data)
+			}
+		})
+	}
+}
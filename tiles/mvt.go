@@ -0,0 +1,503 @@
+package tiles
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+	"github.com/pettinz/mapbox-go-sdk/tilemath"
+)
+
+// GeomType is the geometry type of a vector tile feature.
+type GeomType int
+
+// Vector tile geometry types.
+const (
+	GeomUnknown    GeomType = 0
+	GeomPoint      GeomType = 1
+	GeomLineString GeomType = 2
+	GeomPolygon    GeomType = 3
+)
+
+// String returns the name of the geometry type.
+func (g GeomType) String() string {
+	switch g {
+	case GeomPoint:
+		return "Point"
+	case GeomLineString:
+		return "LineString"
+	case GeomPolygon:
+		return "Polygon"
+	default:
+		return "Unknown"
+	}
+}
+
+// defaultExtent is the layer extent assumed when a layer does not declare one.
+const defaultExtent = 4096
+
+// Geometry commands.
+const (
+	cmdMoveTo    = 1
+	cmdLineTo    = 2
+	cmdClosePath = 7
+)
+
+// VectorTile is a decoded Mapbox Vector Tile.
+type VectorTile struct {
+	Z      int
+	X      int
+	Y      int
+	Layers []*Layer
+}
+
+// Layer returns the layer with the given name, or nil if the tile has no such layer.
+func (t *VectorTile) Layer(name string) *Layer {
+	for _, l := range t.Layers {
+		if l.Name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// Layer is a named collection of features within a vector tile.
+type Layer struct {
+	Name     string
+	Version  int
+	Extent   int
+	Features []*Feature
+}
+
+// FeatureCollection converts the layer into a GeoJSON FeatureCollection.
+func (l *Layer) FeatureCollection() *geojson.FeatureCollection {
+	features := make([]*geojson.Feature, 0, len(l.Features))
+	for _, f := range l.Features {
+		features = append(features, f.GeoJSON())
+	}
+	return geojson.NewFeatureCollection(features...)
+}
+
+// Feature is a single vector tile feature with its geometry in lon/lat.
+type Feature struct {
+	ID         uint64
+	Type       GeomType
+	Properties map[string]any
+	Geometry   geojson.Geometry
+}
+
+// GeoJSON converts the feature into a GeoJSON Feature.
+func (f *Feature) GeoJSON() *geojson.Feature {
+	out := geojson.NewFeature(f.Geometry)
+	if f.ID != 0 {
+		out.ID = f.ID
+	}
+	for k, v := range f.Properties {
+		out.Properties[k] = v
+	}
+	return out
+}
+
+// Decode parses a Mapbox Vector Tile (optionally gzip-compressed) located at
+// z/x/y and converts all feature geometries to lon/lat coordinates.
+func Decode(data []byte, z, x, y int) (*VectorTile, error) {
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress tile: %w", err)
+		}
+		defer zr.Close()
+
+		data, err = io.ReadAll(zr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress tile: %w", err)
+		}
+	}
+
+	tile := &VectorTile{Z: z, X: x, Y: y}
+
+	r := pbfReader{buf: data}
+	for r.more() {
+		num, wt, err := r.field()
+		if err != nil {
+			return nil, err
+		}
+
+		if num != 3 || wt != wireBytes {
+			if err := r.skip(wt); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		raw, err := r.bytes()
+		if err != nil {
+			return nil, err
+		}
+
+		layer, err := decodeLayer(raw, z, x, y)
+		if err != nil {
+			return nil, err
+		}
+		tile.Layers = append(tile.Layers, layer)
+	}
+
+	return tile, nil
+}
+
+// decodeLayer decodes a single layer message.
+func decodeLayer(data []byte, z, x, y int) (*Layer, error) {
+	layer := &Layer{Version: 1, Extent: defaultExtent}
+
+	var (
+		keys     []string
+		values   []any
+		features [][]byte
+	)
+
+	r := pbfReader{buf: data}
+	for r.more() {
+		num, wt, err := r.field()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case num == 15 && wt == wireVarint:
+			v, err := r.varint()
+			if err != nil {
+				return nil, err
+			}
+			layer.Version = int(v)
+		case num == 1 && wt == wireBytes:
+			b, err := r.bytes()
+			if err != nil {
+				return nil, err
+			}
+			layer.Name = string(b)
+		case num == 2 && wt == wireBytes:
+			b, err := r.bytes()
+			if err != nil {
+				return nil, err
+			}
+			features = append(features, b)
+		case num == 3 && wt == wireBytes:
+			b, err := r.bytes()
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, string(b))
+		case num == 4 && wt == wireBytes:
+			b, err := r.bytes()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeValue(b)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		case num == 5 && wt == wireVarint:
+			v, err := r.varint()
+			if err != nil {
+				return nil, err
+			}
+			layer.Extent = int(v)
+		default:
+			if err := r.skip(wt); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if layer.Extent <= 0 {
+		return nil, fmt.Errorf("layer %q: invalid extent %d", layer.Name, layer.Extent)
+	}
+
+	proj := projector{extent: float64(layer.Extent), z: z, x: x, y: y}
+	for _, raw := range features {
+		f, err := decodeFeature(raw, keys, values, proj)
+		if err != nil {
+			return nil, fmt.Errorf("layer %q: %w", layer.Name, err)
+		}
+		layer.Features = append(layer.Features, f)
+	}
+
+	return layer, nil
+}
+
+// decodeFeature decodes a single feature message.
+func decodeFeature(data []byte, keys []string, values []any, proj projector) (*Feature, error) {
+	f := &Feature{Properties: map[string]any{}}
+
+	var (
+		tags     []uint32
+		commands []uint32
+	)
+
+	r := pbfReader{buf: data}
+	for r.more() {
+		num, wt, err := r.field()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case num == 1 && wt == wireVarint:
+			f.ID, err = r.varint()
+		case num == 2 && wt == wireBytes:
+			tags, err = r.packedUint32()
+		case num == 3 && wt == wireVarint:
+			var v uint64
+			v, err = r.varint()
+			f.Type = GeomType(v)
+		case num == 4 && wt == wireBytes:
+			commands, err = r.packedUint32()
+		default:
+			err = r.skip(wt)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(tags)%2 != 0 {
+		return nil, fmt.Errorf("feature %d: odd number of tags", f.ID)
+	}
+	for i := 0; i < len(tags); i += 2 {
+		k, v := int(tags[i]), int(tags[i+1])
+		if k >= len(keys) || v >= len(values) {
+			return nil, fmt.Errorf("feature %d: tag index out of range", f.ID)
+		}
+		f.Properties[keys[k]] = values[v]
+	}
+
+	parts, err := decodeCommands(commands)
+	if err != nil {
+		return nil, fmt.Errorf("feature %d: %w", f.ID, err)
+	}
+
+	f.Geometry = buildGeometry(f.Type, parts, proj)
+
+	return f, nil
+}
+
+// decodeValue decodes a layer value message.
+func decodeValue(data []byte) (any, error) {
+	var value any
+
+	r := pbfReader{buf: data}
+	for r.more() {
+		num, wt, err := r.field()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case num == 1 && wt == wireBytes:
+			var b []byte
+			b, err = r.bytes()
+			value = string(b)
+		case num == 2 && wt == wireFixed32:
+			var v float32
+			v, err = r.float()
+			value = float64(v)
+		case num == 3 && wt == wireFixed64:
+			value, err = r.double()
+		case num == 4 && wt == wireVarint:
+			var v uint64
+			v, err = r.varint()
+			value = int64(v)
+		case num == 5 && wt == wireVarint:
+			value, err = r.varint()
+		case num == 6 && wt == wireVarint:
+			var v uint64
+			v, err = r.varint()
+			value = zigzag(v)
+		case num == 7 && wt == wireVarint:
+			var v uint64
+			v, err = r.varint()
+			value = v != 0
+		default:
+			err = r.skip(wt)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return value, nil
+}
+
+// tilePoint is a position in tile coordinates.
+type tilePoint struct {
+	x, y int64
+}
+
+// decodeCommands runs the geometry command stream and returns its parts.
+// Every MoveTo starts a new part; ClosePath repeats the first point of the
+// current part so rings come out closed.
+func decodeCommands(commands []uint32) ([][]tilePoint, error) {
+	var (
+		parts  [][]tilePoint
+		cx, cy int64
+	)
+
+	for i := 0; i < len(commands); {
+		id := commands[i] & 0x7
+		count := int(commands[i] >> 3)
+		i++
+
+		switch id {
+		case cmdMoveTo, cmdLineTo:
+			if len(commands)-i < 2*count {
+				return nil, fmt.Errorf("geometry command %d truncated", id)
+			}
+			if id == cmdLineTo && len(parts) == 0 {
+				return nil, fmt.Errorf("LineTo before MoveTo")
+			}
+			for j := 0; j < count; j++ {
+				cx += zigzag(uint64(commands[i]))
+				cy += zigzag(uint64(commands[i+1]))
+				i += 2
+				if id == cmdMoveTo {
+					parts = append(parts, []tilePoint{{cx, cy}})
+				} else {
+					parts[len(parts)-1] = append(parts[len(parts)-1], tilePoint{cx, cy})
+				}
+			}
+		case cmdClosePath:
+			if len(parts) == 0 {
+				return nil, fmt.Errorf("ClosePath before MoveTo")
+			}
+			last := parts[len(parts)-1]
+			parts[len(parts)-1] = append(last, last[0])
+		default:
+			return nil, fmt.Errorf("unknown geometry command %d", id)
+		}
+	}
+
+	return parts, nil
+}
+
+// buildGeometry converts decoded parts into a lon/lat GeoJSON geometry.
+func buildGeometry(typ GeomType, parts [][]tilePoint, proj projector) geojson.Geometry {
+	if len(parts) == 0 {
+		return nil
+	}
+
+	switch typ {
+	case GeomPoint:
+		coords := make([][]float64, 0, len(parts))
+		for _, part := range parts {
+			for _, p := range part {
+				coords = append(coords, proj.lngLat(p))
+			}
+		}
+		if len(coords) == 1 {
+			return geojson.NewPoint(coords[0][0], coords[0][1])
+		}
+		return geojson.NewMultiPoint(coords)
+
+	case GeomLineString:
+		lines := make([][][]float64, 0, len(parts))
+		for _, part := range parts {
+			lines = append(lines, proj.line(part))
+		}
+		if len(lines) == 1 {
+			return geojson.NewLineString(lines[0])
+		}
+		return geojson.NewMultiLineString(lines)
+
+	case GeomPolygon:
+		polygons := classifyRings(parts)
+		out := make([][][][]float64, 0, len(polygons))
+		for _, rings := range polygons {
+			polygon := make([][][]float64, 0, len(rings))
+			for _, ring := range rings {
+				polygon = append(polygon, proj.line(ring))
+			}
+			out = append(out, polygon)
+		}
+		switch len(out) {
+		case 0:
+			return nil
+		case 1:
+			return geojson.NewPolygon(out[0])
+		default:
+			return geojson.NewMultiPolygon(out)
+		}
+
+	default:
+		return nil
+	}
+}
+
+// classifyRings groups rings into polygons. A ring with the same winding
+// order as the first ring starts a new polygon; rings with the opposite
+// winding are holes of the current polygon. Degenerate rings are dropped.
+func classifyRings(rings [][]tilePoint) [][][]tilePoint {
+	var (
+		polygons [][][]tilePoint
+		exterior int
+	)
+
+	for _, ring := range rings {
+		area := signedArea(ring)
+		if area == 0 {
+			continue
+		}
+
+		sign := 1
+		if area < 0 {
+			sign = -1
+		}
+		if exterior == 0 {
+			exterior = sign
+		}
+
+		if sign == exterior {
+			polygons = append(polygons, [][]tilePoint{ring})
+		} else if len(polygons) > 0 {
+			polygons[len(polygons)-1] = append(polygons[len(polygons)-1], ring)
+		}
+	}
+
+	return polygons
+}
+
+// signedArea returns twice the signed area of a ring using the surveyor's formula.
+func signedArea(ring []tilePoint) int64 {
+	var sum int64
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		sum += ring[j].x*ring[i].y - ring[i].x*ring[j].y
+	}
+	return sum
+}
+
+// projector converts tile coordinates to lon/lat for a specific tile.
+type projector struct {
+	extent float64
+	z      int
+	x      int
+	y      int
+}
+
+// lngLat converts a tile coordinate into a [lon, lat] position.
+func (p projector) lngLat(pt tilePoint) []float64 {
+	worldX := float64(p.x) + float64(pt.x)/p.extent
+	worldY := float64(p.y) + float64(pt.y)/p.extent
+	lon, lat := tilemath.TileToLngLat(worldX, worldY, p.z)
+	return []float64{lon, lat}
+}
+
+// line converts a sequence of tile coordinates into [lon, lat] positions.
+func (p projector) line(points []tilePoint) [][]float64 {
+	out := make([][]float64, len(points))
+	for i, pt := range points {
+		out[i] = p.lngLat(pt)
+	}
+	return out
+}
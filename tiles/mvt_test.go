@@ -0,0 +1,401 @@
+package tiles
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/pettinz/mapbox-go-sdk/geojson"
+)
+
+// pbfWriter is a minimal protocol buffer encoder used to build test tiles.
+type pbfWriter struct {
+	buf []byte
+}
+
+func (w *pbfWriter) key(num, wireType int) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(num<<3|wireType))
+}
+
+func (w *pbfWriter) varint(num int, v uint64) {
+	w.key(num, wireVarint)
+	w.buf = binary.AppendUvarint(w.buf, v)
+}
+
+func (w *pbfWriter) bytes(num int, b []byte) {
+	w.key(num, wireBytes)
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(b)))
+	w.buf = append(w.buf, b...)
+}
+
+func (w *pbfWriter) double(num int, v float64) {
+	w.key(num, wireFixed64)
+	w.buf = binary.LittleEndian.AppendUint64(w.buf, math.Float64bits(v))
+}
+
+func (w *pbfWriter) packed(num int, values []uint32) {
+	var inner []byte
+	for _, v := range values {
+		inner = binary.AppendUvarint(inner, uint64(v))
+	}
+	w.bytes(num, inner)
+}
+
+func command(id, count int) uint32 {
+	return uint32(id&0x7 | count<<3)
+}
+
+func zz(v int64) uint32 {
+	return uint32((v << 1) ^ (v >> 63))
+}
+
+type testFeature struct {
+	id       uint64
+	typ      GeomType
+	tags     []uint32
+	geometry []uint32
+}
+
+func encodeFeature(f testFeature) []byte {
+	w := &pbfWriter{}
+	if f.id != 0 {
+		w.varint(1, f.id)
+	}
+	if len(f.tags) > 0 {
+		w.packed(2, f.tags)
+	}
+	w.varint(3, uint64(f.typ))
+	w.packed(4, f.geometry)
+	return w.buf
+}
+
+func encodeLayer(name string, extent int, keys []string, values [][]byte, features []testFeature) []byte {
+	w := &pbfWriter{}
+	w.varint(15, 2)
+	w.bytes(1, []byte(name))
+	for _, f := range features {
+		w.bytes(2, encodeFeature(f))
+	}
+	for _, k := range keys {
+		w.bytes(3, []byte(k))
+	}
+	for _, v := range values {
+		w.bytes(4, v)
+	}
+	w.varint(5, uint64(extent))
+	return w.buf
+}
+
+func stringValue(s string) []byte {
+	w := &pbfWriter{}
+	w.bytes(1, []byte(s))
+	return w.buf
+}
+
+func doubleValue(v float64) []byte {
+	w := &pbfWriter{}
+	w.double(3, v)
+	return w.buf
+}
+
+func sintValue(v int64) []byte {
+	w := &pbfWriter{}
+	w.varint(6, uint64(zz(v)))
+	return w.buf
+}
+
+func boolValue(v bool) []byte {
+	w := &pbfWriter{}
+	if v {
+		w.varint(7, 1)
+	} else {
+		w.varint(7, 0)
+	}
+	return w.buf
+}
+
+func encodeTile(layers ...[]byte) []byte {
+	w := &pbfWriter{}
+	for _, l := range layers {
+		w.bytes(3, l)
+	}
+	return w.buf
+}
+
+// squareRing returns commands for a closed square ring starting at (x, y).
+// A positive size yields a clockwise ring in screen coordinates (exterior),
+// a negative size a counter-clockwise one (hole).
+func squareRing(x, y, size int64) []uint32 {
+	if size > 0 {
+		return []uint32{
+			command(cmdMoveTo, 1), zz(x), zz(y),
+			command(cmdLineTo, 3), zz(size), zz(0), zz(0), zz(size), zz(-size), zz(0),
+			command(cmdClosePath, 1),
+		}
+	}
+	size = -size
+	return []uint32{
+		command(cmdMoveTo, 1), zz(x), zz(y),
+		command(cmdLineTo, 3), zz(0), zz(size), zz(size), zz(0), zz(0), zz(-size),
+		command(cmdClosePath, 1),
+	}
+}
+
+func TestDecode(t *testing.T) {
+	layer := encodeLayer("poi", 4096,
+		[]string{"name", "height", "rank", "open"},
+		[][]byte{stringValue("Cafe"), doubleValue(12.5), sintValue(-3), boolValue(true)},
+		[]testFeature{
+			{
+				id:       42,
+				typ:      GeomPoint,
+				tags:     []uint32{0, 0, 1, 1, 2, 2, 3, 3},
+				geometry: []uint32{command(cmdMoveTo, 1), zz(2048), zz(2048)},
+			},
+		},
+	)
+
+	tile, err := Decode(encodeTile(layer), 0, 0, 0)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	l := tile.Layer("poi")
+	if l == nil {
+		t.Fatal("expected poi layer")
+	}
+	if l.Version != 2 || l.Extent != 4096 {
+		t.Errorf("unexpected layer header version=%d extent=%d", l.Version, l.Extent)
+	}
+	if len(l.Features) != 1 {
+		t.Fatalf("expected 1 feature, got %d", len(l.Features))
+	}
+
+	f := l.Features[0]
+	if f.ID != 42 {
+		t.Errorf("expected ID 42, got %d", f.ID)
+	}
+	if f.Properties["name"] != "Cafe" {
+		t.Errorf("expected name Cafe, got %v", f.Properties["name"])
+	}
+	if f.Properties["height"] != 12.5 {
+		t.Errorf("expected height 12.5, got %v", f.Properties["height"])
+	}
+	if f.Properties["rank"] != int64(-3) {
+		t.Errorf("expected rank -3, got %v", f.Properties["rank"])
+	}
+	if f.Properties["open"] != true {
+		t.Errorf("expected open true, got %v", f.Properties["open"])
+	}
+
+	point, ok := f.Geometry.(*geojson.Point)
+	if !ok {
+		t.Fatalf("expected *geojson.Point, got %T", f.Geometry)
+	}
+	if !almostEqual(point.Longitude(), 0) || !almostEqual(point.Latitude(), 0) {
+		t.Errorf("expected tile center at 0,0, got %v", point.Coordinates)
+	}
+
+	if tile.Layer("missing") != nil {
+		t.Error("expected nil for missing layer")
+	}
+}
+
+func TestDecode_Gzip(t *testing.T) {
+	layer := encodeLayer("poi", 4096, nil, nil, []testFeature{
+		{typ: GeomPoint, geometry: []uint32{command(cmdMoveTo, 1), zz(0), zz(0)}},
+	})
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(encodeTile(layer))
+	zw.Close()
+
+	tile, err := Decode(buf.Bytes(), 0, 0, 0)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	point := tile.Layers[0].Features[0].Geometry.(*geojson.Point)
+	if !almostEqual(point.Longitude(), -180) || !almostEqual(point.Latitude(), 85.0511287798) {
+		t.Errorf("expected top-left corner, got %v", point.Coordinates)
+	}
+}
+
+func TestDecode_Geometries(t *testing.T) {
+	tests := []struct {
+		name     string
+		feature  testFeature
+		wantType string
+		validate func(*testing.T, geojson.Geometry)
+	}{
+		{
+			name: "multi point",
+			feature: testFeature{
+				typ:      GeomPoint,
+				geometry: []uint32{command(cmdMoveTo, 2), zz(0), zz(0), zz(10), zz(10)},
+			},
+			wantType: geojson.TypeMultiPoint,
+		},
+		{
+			name: "line string",
+			feature: testFeature{
+				typ: GeomLineString,
+				geometry: []uint32{
+					command(cmdMoveTo, 1), zz(0), zz(0),
+					command(cmdLineTo, 2), zz(10), zz(0), zz(0), zz(10),
+				},
+			},
+			wantType: geojson.TypeLineString,
+			validate: func(t *testing.T, g geojson.Geometry) {
+				if n := len(g.(*geojson.LineString).Coordinates); n != 3 {
+					t.Errorf("expected 3 positions, got %d", n)
+				}
+			},
+		},
+		{
+			name: "multi line string",
+			feature: testFeature{
+				typ: GeomLineString,
+				geometry: []uint32{
+					command(cmdMoveTo, 1), zz(0), zz(0),
+					command(cmdLineTo, 1), zz(10), zz(0),
+					command(cmdMoveTo, 1), zz(0), zz(10),
+					command(cmdLineTo, 1), zz(-10), zz(0),
+				},
+			},
+			wantType: geojson.TypeMultiLineString,
+		},
+		{
+			name: "polygon with hole",
+			feature: testFeature{
+				typ:      GeomPolygon,
+				geometry: append(squareRing(0, 0, 100), squareRing(10, 10, -20)...),
+			},
+			wantType: geojson.TypePolygon,
+			validate: func(t *testing.T, g geojson.Geometry) {
+				rings := g.(*geojson.Polygon).Coordinates
+				if len(rings) != 2 {
+					t.Fatalf("expected exterior ring and hole, got %d rings", len(rings))
+				}
+				ring := rings[0]
+				if ring[0][0] != ring[len(ring)-1][0] || ring[0][1] != ring[len(ring)-1][1] {
+					t.Error("expected closed ring")
+				}
+			},
+		},
+		{
+			name: "multi polygon",
+			feature: testFeature{
+				typ:      GeomPolygon,
+				geometry: append(squareRing(0, 0, 100), squareRing(100, 100, 50)...),
+			},
+			wantType: geojson.TypeMultiPolygon,
+			validate: func(t *testing.T, g geojson.Geometry) {
+				if n := len(g.(*geojson.MultiPolygon).Coordinates); n != 2 {
+					t.Errorf("expected 2 polygons, got %d", n)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := encodeTile(encodeLayer("test", 4096, nil, nil, []testFeature{tt.feature}))
+
+			tile, err := Decode(data, 14, 8192, 5461)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+
+			g := tile.Layers[0].Features[0].Geometry
+			if g == nil {
+				t.Fatal("expected geometry")
+			}
+			if g.GeometryType() != tt.wantType {
+				t.Fatalf("expected %s, got %s", tt.wantType, g.GeometryType())
+			}
+			if tt.validate != nil {
+				tt.validate(t, g)
+			}
+		})
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "truncated layer",
+			data: []byte{0x1a, 0x10, 0x01},
+		},
+		{
+			name: "tag out of range",
+			data: encodeTile(encodeLayer("test", 4096, []string{"name"}, nil, []testFeature{
+				{typ: GeomPoint, tags: []uint32{0, 0}, geometry: []uint32{command(cmdMoveTo, 1), 0, 0}},
+			})),
+		},
+		{
+			name: "LineTo before MoveTo",
+			data: encodeTile(encodeLayer("test", 4096, nil, nil, []testFeature{
+				{typ: GeomLineString, geometry: []uint32{command(cmdLineTo, 1), 0, 0}},
+			})),
+		},
+		{
+			name: "unknown command",
+			data: encodeTile(encodeLayer("test", 4096, nil, nil, []testFeature{
+				{typ: GeomPoint, geometry: []uint32{command(3, 1), 0, 0}},
+			})),
+		},
+		{
+			name: "truncated command parameters",
+			data: encodeTile(encodeLayer("test", 4096, nil, nil, []testFeature{
+				{typ: GeomPoint, geometry: []uint32{command(cmdMoveTo, 2), 0, 0}},
+			})),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(tt.data, 0, 0, 0); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestLayer_FeatureCollection(t *testing.T) {
+	data := encodeTile(encodeLayer("poi", 4096, []string{"name"}, [][]byte{stringValue("Cafe")}, []testFeature{
+		{id: 7, typ: GeomPoint, tags: []uint32{0, 0}, geometry: []uint32{command(cmdMoveTo, 1), zz(10), zz(10)}},
+	}))
+
+	tile, err := Decode(data, 0, 0, 0)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	fc := tile.Layer("poi").FeatureCollection()
+	if fc.Type != "FeatureCollection" || len(fc.Features) != 1 {
+		t.Fatalf("unexpected feature collection %+v", fc)
+	}
+	if fc.Features[0].ID != uint64(7) {
+		t.Errorf("expected ID 7, got %v", fc.Features[0].ID)
+	}
+	if fc.Features[0].Properties["name"] != "Cafe" {
+		t.Errorf("expected name Cafe, got %v", fc.Features[0].Properties["name"])
+	}
+}
+
+func TestGeomType_String(t *testing.T) {
+	if GeomPolygon.String() != "Polygon" || GeomUnknown.String() != "Unknown" {
+		t.Error("unexpected geometry type names")
+	}
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
@@ -0,0 +1,137 @@
+package tiles
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Protocol buffer wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errTruncated = errors.New("unexpected end of protobuf data")
+
+// pbfReader is a minimal protocol buffer decoder covering the subset of the
+// wire format used by the vector tile specification.
+type pbfReader struct {
+	buf []byte
+	pos int
+}
+
+// more reports whether there is data left to read.
+func (r *pbfReader) more() bool {
+	return r.pos < len(r.buf)
+}
+
+// field reads the next field key and returns its number and wire type.
+func (r *pbfReader) field() (int, int, error) {
+	key, err := r.varint()
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(key >> 3), int(key & 0x7), nil
+}
+
+// varint reads a base-128 varint.
+func (r *pbfReader) varint() (uint64, error) {
+	v, n := binary.Uvarint(r.buf[r.pos:])
+	if n <= 0 {
+		return 0, errTruncated
+	}
+	r.pos += n
+	return v, nil
+}
+
+// bytes reads a length-delimited field.
+func (r *pbfReader) bytes() ([]byte, error) {
+	n, err := r.varint()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(r.buf)-r.pos) {
+		return nil, errTruncated
+	}
+	b := r.buf[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b, nil
+}
+
+// fixed32 reads a little-endian 32-bit value.
+func (r *pbfReader) fixed32() (uint32, error) {
+	if len(r.buf)-r.pos < 4 {
+		return 0, errTruncated
+	}
+	v := binary.LittleEndian.Uint32(r.buf[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+// fixed64 reads a little-endian 64-bit value.
+func (r *pbfReader) fixed64() (uint64, error) {
+	if len(r.buf)-r.pos < 8 {
+		return 0, errTruncated
+	}
+	v := binary.LittleEndian.Uint64(r.buf[r.pos:])
+	r.pos += 8
+	return v, nil
+}
+
+// float reads a 32-bit float.
+func (r *pbfReader) float() (float32, error) {
+	v, err := r.fixed32()
+	return math.Float32frombits(v), err
+}
+
+// double reads a 64-bit float.
+func (r *pbfReader) double() (float64, error) {
+	v, err := r.fixed64()
+	return math.Float64frombits(v), err
+}
+
+// packedUint32 reads a packed repeated uint32 field.
+func (r *pbfReader) packedUint32() ([]uint32, error) {
+	data, err := r.bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	inner := pbfReader{buf: data}
+	var out []uint32
+	for inner.more() {
+		v, err := inner.varint()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, uint32(v))
+	}
+	return out, nil
+}
+
+// skip discards a field of the given wire type.
+func (r *pbfReader) skip(wireType int) error {
+	var err error
+	switch wireType {
+	case wireVarint:
+		_, err = r.varint()
+	case wireFixed64:
+		_, err = r.fixed64()
+	case wireBytes:
+		_, err = r.bytes()
+	case wireFixed32:
+		_, err = r.fixed32()
+	default:
+		err = fmt.Errorf("unsupported protobuf wire type %d", wireType)
+	}
+	return err
+}
+
+// zigzag decodes a zigzag-encoded signed integer.
+func zigzag(v uint64) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
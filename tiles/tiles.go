@@ -0,0 +1,27 @@
+package tiles
+
+import (
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+)
+
+const (
+	// API paths
+	tilesPath = "/v4"
+
+	// maxZoom is the highest zoom level served by the Tiles API.
+	maxZoom = 22
+)
+
+// Service provides access to the Mapbox vector and raster Tiles API.
+type Service struct {
+	token      string
+	httpClient *internalhttp.Client
+}
+
+// New creates a new Tiles service.
+func New(token string, httpClient *internalhttp.Client) *Service {
+	return &Service{
+		token:      token,
+		httpClient: httpClient,
+	}
+}
@@ -0,0 +1,26 @@
+package tiles
+
+import (
+	"testing"
+
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+)
+
+func TestNew(t *testing.T) {
+	token := "test-token"
+	httpClient := internalhttp.New("https://api.mapbox.com", nil)
+
+	service := New(token, httpClient)
+
+	if service == nil {
+		t.Fatal("expected non-nil service")
+	}
+
+	if service.token != token {
+		t.Errorf("expected token %q, got %q", token, service.token)
+	}
+
+	if service.httpClient != httpClient {
+		t.Error("expected httpClient to be set")
+	}
+}
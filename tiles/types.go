@@ -0,0 +1,43 @@
+// Package tiles provides access to the Mapbox vector and raster Tiles API
+// and a pure Go decoder for Mapbox Vector Tiles.
+package tiles
+
+// Raster tile formats supported by the Tiles API.
+const (
+	FormatPNG    = "png"
+	FormatPNG32  = "png32"
+	FormatPNG64  = "png64"
+	FormatPNG128 = "png128"
+	FormatPNG256 = "png256"
+	FormatJPG70  = "jpg70"
+	FormatJPG80  = "jpg80"
+	FormatJPG90  = "jpg90"
+	FormatWebP   = "webp"
+)
+
+// VectorRequest represents a request for a single vector tile.
+type VectorRequest struct {
+	Tileset string // required, e.g. "mapbox.mapbox-streets-v8"; comma-separate to composite
+	Z       int    // zoom level, 0-22
+	X       int    // tile column
+	Y       int    // tile row
+}
+
+// RasterRequest represents a request for a single raster tile.
+type RasterRequest struct {
+	Tileset string // required, e.g. "mapbox.satellite"
+	Z       int    // zoom level, 0-22
+	X       int    // tile column
+	Y       int    // tile row
+	Format  string // image format, defaults to "png"
+	HighDPI bool   // request a @2x tile (512x512 pixels)
+}
+
+// Tile is a raw tile as returned by the Tiles API.
+type Tile struct {
+	Z           int
+	X           int
+	Y           int
+	Data        []byte // encoded tile (MVT protobuf or image bytes)
+	ContentType string // Content-Type reported by the API
+}
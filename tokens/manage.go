@@ -0,0 +1,199 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+)
+
+// List returns the tokens belonging to an account.
+// The access token must have the tokens:read scope.
+func (s *Service) List(ctx context.Context, req *ListRequest) ([]Token, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.TokensList, req)
+
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+
+	username, err := s.resolveUsername(req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("%s/%s", tokensPath, username)
+	query := s.buildListQuery(req)
+
+	var result []Token
+	if err := s.httpClient.Get(ctx, path, query, &result); err != nil {
+		return nil, fmt.Errorf("list tokens failed: %w", err)
+	}
+
+	return result, nil
+}
+
+// Create creates a new permanent token with the given scopes and URL restrictions.
+// The access token must have the tokens:write scope.
+func (s *Service) Create(ctx context.Context, req *CreateRequest) (*Token, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.TokensCreate, req)
+
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+
+	username, err := s.resolveUsername(req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("%s/%s", tokensPath, username)
+
+	var result Token
+	if err := s.httpClient.Post(ctx, path, s.buildAuthQuery(), req, &result); err != nil {
+		return nil, fmt.Errorf("create token failed: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Update changes the note, scopes or URL restrictions of an existing token.
+// The access token must have the tokens:write scope.
+func (s *Service) Update(ctx context.Context, req *UpdateRequest) (*Token, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.TokensUpdate, req)
+
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+
+	username, err := s.resolveUsername(req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("%s/%s/%s", tokensPath, username, url.PathEscape(req.TokenID))
+
+	var result Token
+	if err := s.httpClient.Patch(ctx, path, s.buildAuthQuery(), req, &result); err != nil {
+		return nil, fmt.Errorf("update token failed: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Delete permanently deletes a token.
+// The access token must have the tokens:write scope.
+func (s *Service) Delete(ctx context.Context, req *DeleteRequest) error {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.TokensDelete, req)
+
+	if req.TokenID == "" {
+		return fmt.Errorf("token_id is required")
+	}
+
+	username, err := s.resolveUsername(req.Username)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("%s/%s/%s", tokensPath, username, url.PathEscape(req.TokenID))
+
+	if err := s.httpClient.Delete(ctx, path, s.buildAuthQuery()); err != nil {
+		return fmt.Errorf("delete token failed: %w", err)
+	}
+
+	return nil
+}
+
+// ListScopes returns the scopes the access token is allowed to grant.
+func (s *Service) ListScopes(ctx context.Context, req *ListScopesRequest) ([]Scope, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.TokensListScopes, req)
+
+	username, err := s.resolveUsername(req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("%s/%s", scopesPath, username)
+
+	var result []Scope
+	if err := s.httpClient.Get(ctx, path, s.buildAuthQuery(), &result); err != nil {
+		return nil, fmt.Errorf("list scopes failed: %w", err)
+	}
+
+	return result, nil
+}
+
+// validateListRequest validates the List request parameters.
+func validateListRequest(req *ListRequest) error {
+	if req.Limit != nil && (*req.Limit < 1 || *req.Limit > 100) {
+		return fmt.Errorf("limit must be between 1 and 100")
+	}
+
+	if req.SortBy != "" && req.SortBy != "created" && req.SortBy != "modified" {
+		return fmt.Errorf("sortby must be either created or modified")
+	}
+
+	if req.Usage != "" && req.Usage != UsagePublic && req.Usage != UsageSecret && req.Usage != UsageTemporary {
+		return fmt.Errorf("usage must be one of pk, sk or tk")
+	}
+
+	return nil
+}
+
+// validateCreateRequest validates the Create request parameters.
+func validateCreateRequest(req *CreateRequest) error {
+	if req.Scopes == nil {
+		return fmt.Errorf("scopes are required")
+	}
+
+	return nil
+}
+
+// validateUpdateRequest validates the Update request parameters.
+func validateUpdateRequest(req *UpdateRequest) error {
+	if req.TokenID == "" {
+		return fmt.Errorf("token_id is required")
+	}
+
+	if req.Note == nil && req.Scopes == nil && req.AllowedURLs == nil {
+		return fmt.Errorf("at least one of note, scopes or allowed URLs is required")
+	}
+
+	return nil
+}
+
+// buildAuthQuery builds the query parameters shared by all token endpoints.
+func (s *Service) buildAuthQuery() url.Values {
+	q := url.Values{}
+	q.Set("access_token", s.token)
+	return q
+}
+
+// buildListQuery builds query parameters for the List endpoint.
+func (s *Service) buildListQuery(req *ListRequest) url.Values {
+	q := s.buildAuthQuery()
+
+	if req.Default != nil {
+		q.Set("default", strconv.FormatBool(*req.Default))
+	}
+
+	if req.Limit != nil {
+		q.Set("limit", strconv.Itoa(*req.Limit))
+	}
+
+	if req.SortBy != "" {
+		q.Set("sortby", req.SortBy)
+	}
+
+	if req.Start != "" {
+		q.Set("start", req.Start)
+	}
+
+	if req.Usage != "" {
+		q.Set("usage", req.Usage)
+	}
+
+	return q
+}
@@ -0,0 +1,406 @@
+package tokens
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
+)
+
+func TestService_List(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        *ListRequest
+		mockStatus     int
+		mockResponse   string
+		wantErr        bool
+		validateResult func(*testing.T, []Token)
+	}{
+		{
+			name:         "successful list",
+			request:      &ListRequest{},
+			mockStatus:   http.StatusOK,
+			mockResponse: testutil.TokensListResponse,
+			wantErr:      false,
+			validateResult: func(t *testing.T, tokens []Token) {
+				if len(tokens) != 2 {
+					t.Fatalf("expected 2 tokens, got %d", len(tokens))
+				}
+				if !tokens[0].Default {
+					t.Error("expected first token to be the default token")
+				}
+				if len(tokens[1].AllowedURLs) != 1 {
+					t.Errorf("expected 1 allowed URL, got %d", len(tokens[1].AllowedURLs))
+				}
+				if tokens[1].Modified.IsZero() {
+					t.Error("expected modified timestamp to be parsed")
+				}
+			},
+		},
+		{
+			name: "list with filters",
+			request: &ListRequest{
+				Default: boolPtr(false),
+				Limit:   intPtr(50),
+				SortBy:  "modified",
+				Usage:   UsagePublic,
+			},
+			mockStatus:   http.StatusOK,
+			mockResponse: testutil.TokensListResponse,
+			wantErr:      false,
+		},
+		{
+			name:         "limit too high",
+			request:      &ListRequest{Limit: intPtr(101)},
+			mockStatus:   http.StatusOK,
+			mockResponse: "[]",
+			wantErr:      true,
+		},
+		{
+			name:         "invalid sortby",
+			request:      &ListRequest{SortBy: "name"},
+			mockStatus:   http.StatusOK,
+			mockResponse: "[]",
+			wantErr:      true,
+		},
+		{
+			name:         "invalid usage",
+			request:      &ListRequest{Usage: "xx"},
+			mockStatus:   http.StatusOK,
+			mockResponse: "[]",
+			wantErr:      true,
+		},
+		{
+			name:         "API error",
+			request:      &ListRequest{},
+			mockStatus:   http.StatusUnauthorized,
+			mockResponse: testutil.ErrorResponse,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.MockServer(t, testutil.MockResponse(tt.mockStatus, tt.mockResponse))
+			defer server.Close()
+
+			service := New(testToken, internalhttp.New(server.URL, nil))
+
+			result, err := service.List(context.Background(), tt.request)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && tt.validateResult != nil {
+				tt.validateResult(t, result)
+			}
+		})
+	}
+}
+
+func TestService_List_QueryParameters(t *testing.T) {
+	server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		testutil.AssertMethod(t, r, http.MethodGet)
+
+		if r.URL.Path != "/tokens/v2/testuser" {
+			t.Errorf("expected path /tokens/v2/testuser, got %s", r.URL.Path)
+		}
+
+		testutil.AssertQueryParam(t, r, "access_token", testToken)
+		testutil.AssertQueryParam(t, r, "default", "true")
+		testutil.AssertQueryParam(t, r, "limit", "10")
+		testutil.AssertQueryParam(t, r, "sortby", "created")
+		testutil.AssertQueryParam(t, r, "start", "cijucimbe000brbkt48d0dhcx")
+		testutil.AssertQueryParam(t, r, "usage", "sk")
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testutil.TokensListResponse))
+	})
+	defer server.Close()
+
+	service := New(testToken, internalhttp.New(server.URL, nil))
+
+	_, err := service.List(context.Background(), &ListRequest{
+		Default: boolPtr(true),
+		Limit:   intPtr(10),
+		SortBy:  "created",
+		Start:   "cijucimbe000brbkt48d0dhcx",
+		Usage:   UsageSecret,
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+}
+
+func TestService_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        *CreateRequest
+		mockStatus     int
+		mockResponse   string
+		wantErr        bool
+		validateResult func(*testing.T, *Token)
+	}{
+		{
+			name: "successful create",
+			request: &CreateRequest{
+				Note:        "Store locator",
+				Scopes:      []string{"styles:tiles", "styles:read"},
+				AllowedURLs: []string{"https://example.com"},
+			},
+			mockStatus:   http.StatusOK,
+			mockResponse: testutil.TokensTokenResponse,
+			wantErr:      false,
+			validateResult: func(t *testing.T, token *Token) {
+				if token.ID != "cijucimbe000brbkt48d0dhcy" {
+					t.Errorf("expected token ID cijucimbe000brbkt48d0dhcy, got %s", token.ID)
+				}
+				if token.Token == "" {
+					t.Error("expected non-empty token string")
+				}
+			},
+		},
+		{
+			name:         "missing scopes",
+			request:      &CreateRequest{Note: "no scopes"},
+			mockStatus:   http.StatusOK,
+			mockResponse: "{}",
+			wantErr:      true,
+		},
+		{
+			name: "API error",
+			request: &CreateRequest{
+				Scopes: []string{"secret:scope"},
+			},
+			mockStatus:   http.StatusUnprocessableEntity,
+			mockResponse: testutil.ValidationErrorResponse,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.MockServer(t, testutil.MockResponse(tt.mockStatus, tt.mockResponse))
+			defer server.Close()
+
+			service := New(testToken, internalhttp.New(server.URL, nil))
+
+			result, err := service.Create(context.Background(), tt.request)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && tt.validateResult != nil {
+				tt.validateResult(t, result)
+			}
+		})
+	}
+}
+
+func TestService_Create_RequestBody(t *testing.T) {
+	server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		testutil.AssertMethod(t, r, http.MethodPost)
+		testutil.AssertQueryParam(t, r, "access_token", testToken)
+
+		if r.URL.Path != "/tokens/v2/otheruser" {
+			t.Errorf("expected path /tokens/v2/otheruser, got %s", r.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+
+		if body["note"] != "Store locator" {
+			t.Errorf("expected note Store locator, got %v", body["note"])
+		}
+		if _, ok := body["allowedUrls"]; !ok {
+			t.Error("expected allowedUrls in body")
+		}
+		if _, ok := body["Username"]; ok {
+			t.Error("expected username to be excluded from body")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testutil.TokensTokenResponse))
+	})
+	defer server.Close()
+
+	service := New(testToken, internalhttp.New(server.URL, nil))
+
+	_, err := service.Create(context.Background(), &CreateRequest{
+		Username:    "otheruser",
+		Note:        "Store locator",
+		Scopes:      []string{"styles:read"},
+		AllowedURLs: []string{"https://example.com"},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	tests := []struct {
+		name         string
+		request      *UpdateRequest
+		mockStatus   int
+		mockResponse string
+		wantErr      bool
+	}{
+		{
+			name: "update note",
+			request: &UpdateRequest{
+				TokenID: "cijucimbe000brbkt48d0dhcy",
+				Note:    stringPtr("Store locator"),
+			},
+			mockStatus:   http.StatusOK,
+			mockResponse: testutil.TokensTokenResponse,
+			wantErr:      false,
+		},
+		{
+			name: "remove URL restrictions",
+			request: &UpdateRequest{
+				TokenID:     "cijucimbe000brbkt48d0dhcy",
+				AllowedURLs: &[]string{},
+			},
+			mockStatus:   http.StatusOK,
+			mockResponse: testutil.TokensTokenResponse,
+			wantErr:      false,
+		},
+		{
+			name:         "missing token ID",
+			request:      &UpdateRequest{Note: stringPtr("note")},
+			mockStatus:   http.StatusOK,
+			mockResponse: "{}",
+			wantErr:      true,
+		},
+		{
+			name:         "nothing to update",
+			request:      &UpdateRequest{TokenID: "cijucimbe000brbkt48d0dhcy"},
+			mockStatus:   http.StatusOK,
+			mockResponse: "{}",
+			wantErr:      true,
+		},
+		{
+			name: "token not found",
+			request: &UpdateRequest{
+				TokenID: "missing",
+				Scopes:  []string{"styles:read"},
+			},
+			mockStatus:   http.StatusNotFound,
+			mockResponse: testutil.NotFoundErrorResponse,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				testutil.AssertMethod(t, r, http.MethodPatch)
+				testutil.MockResponse(tt.mockStatus, tt.mockResponse)(w, r)
+			})
+			defer server.Close()
+
+			service := New(testToken, internalhttp.New(server.URL, nil))
+
+			_, err := service.Update(context.Background(), tt.request)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    *DeleteRequest
+		mockStatus int
+		wantErr    bool
+	}{
+		{
+			name:       "successful delete",
+			request:    &DeleteRequest{TokenID: "cijucimbe000brbkt48d0dhcy"},
+			mockStatus: http.StatusNoContent,
+			wantErr:    false,
+		},
+		{
+			name:       "missing token ID",
+			request:    &DeleteRequest{},
+			mockStatus: http.StatusNoContent,
+			wantErr:    true,
+		},
+		{
+			name:       "token not found",
+			request:    &DeleteRequest{TokenID: "missing"},
+			mockStatus: http.StatusNotFound,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				testutil.AssertMethod(t, r, http.MethodDelete)
+
+				if r.URL.Path != "/tokens/v2/testuser/"+tt.request.TokenID {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+
+				w.WriteHeader(tt.mockStatus)
+			})
+			defer server.Close()
+
+			service := New(testToken, internalhttp.New(server.URL, nil))
+
+			err := service.Delete(context.Background(), tt.request)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_ListScopes(t *testing.T) {
+	server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scopes/v1/testuser" {
+			t.Errorf("expected path /scopes/v1/testuser, got %s", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testutil.TokensScopesResponse))
+	})
+	defer server.Close()
+
+	service := New(testToken, internalhttp.New(server.URL, nil))
+
+	scopes, err := service.ListScopes(context.Background(), &ListScopesRequest{})
+	if err != nil {
+		t.Fatalf("ListScopes() error = %v", err)
+	}
+
+	if len(scopes) != 2 {
+		t.Fatalf("expected 2 scopes, got %d", len(scopes))
+	}
+
+	if !scopes[0].Public || scopes[1].Public {
+		t.Error("expected only the first scope to be public")
+	}
+}
+
+func TestService_UsernameRequired(t *testing.T) {
+	service := New("test-token", internalhttp.New("https://api.mapbox.com", nil))
+
+	if _, err := service.List(context.Background(), &ListRequest{}); err == nil {
+		t.Error("expected error when username cannot be derived from token")
+	}
+}
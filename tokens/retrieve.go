@@ -0,0 +1,23 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+)
+
+// Retrieve returns the metadata of the access token used by the service,
+// including its owner, usage and scopes. Use RetrieveResponse.Valid to check
+// whether the token can still be used.
+func (s *Service) Retrieve(ctx context.Context) (*RetrieveResponse, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.TokensRetrieve, nil)
+
+	var result RetrieveResponse
+	if err := s.httpClient.Get(ctx, tokensPath, s.buildAuthQuery(), &result); err != nil {
+		return nil, fmt.Errorf("retrieve token failed: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,84 @@
+package tokens
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
+)
+
+func TestService_Retrieve(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockStatus     int
+		mockResponse   string
+		wantErr        bool
+		validateResult func(*testing.T, *RetrieveResponse)
+	}{
+		{
+			name:         "valid token",
+			mockStatus:   http.StatusOK,
+			mockResponse: testutil.TokensRetrieveResponse,
+			wantErr:      false,
+			validateResult: func(t *testing.T, resp *RetrieveResponse) {
+				if !resp.Valid() {
+					t.Errorf("expected valid token, got code %s", resp.Code)
+				}
+				if resp.Token == nil {
+					t.Fatal("expected token metadata")
+				}
+				if resp.Token.User != "testuser" {
+					t.Errorf("expected user testuser, got %s", resp.Token.User)
+				}
+				if len(resp.Token.Scopes) != 3 {
+					t.Errorf("expected 3 scopes, got %d", len(resp.Token.Scopes))
+				}
+			},
+		},
+		{
+			name:         "expired token",
+			mockStatus:   http.StatusOK,
+			mockResponse: `{"code": "TokenExpired"}`,
+			wantErr:      false,
+			validateResult: func(t *testing.T, resp *RetrieveResponse) {
+				if resp.Valid() {
+					t.Error("expected invalid token")
+				}
+			},
+		},
+		{
+			name:         "unauthorized",
+			mockStatus:   http.StatusUnauthorized,
+			mockResponse: testutil.ErrorResponse,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/tokens/v2" {
+					t.Errorf("expected path /tokens/v2, got %s", r.URL.Path)
+				}
+				testutil.AssertQueryParam(t, r, "access_token", testToken)
+				testutil.MockResponse(tt.mockStatus, tt.mockResponse)(w, r)
+			})
+			defer server.Close()
+
+			service := New(testToken, internalhttp.New(server.URL, nil))
+
+			result, err := service.Retrieve(context.Background())
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Retrieve() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && tt.validateResult != nil {
+				tt.validateResult(t, result)
+			}
+		})
+	}
+}
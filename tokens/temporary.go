@@ -0,0 +1,57 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+)
+
+// CreateTemporary creates a short-lived token that expires at req.Expires.
+// Temporary tokens cannot be listed, updated or revoked, which makes them
+// suitable for handing out to untrusted clients for a single session.
+// The access token must have the tokens:write scope.
+func (s *Service) CreateTemporary(ctx context.Context, req *TemporaryRequest) (*TemporaryToken, error) {
+	ctx = internalhttp.WithEndpoint(ctx, middleware.TokensCreateTemporary, req)
+
+	if err := validateTemporaryRequest(req, time.Now()); err != nil {
+		return nil, err
+	}
+
+	username, err := s.resolveUsername(req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("%s/%s", tokensPath, username)
+
+	var result TemporaryToken
+	if err := s.httpClient.Post(ctx, path, s.buildAuthQuery(), req, &result); err != nil {
+		return nil, fmt.Errorf("create temporary token failed: %w", err)
+	}
+
+	return &result, nil
+}
+
+// validateTemporaryRequest validates the CreateTemporary request parameters.
+func validateTemporaryRequest(req *TemporaryRequest, now time.Time) error {
+	if len(req.Scopes) == 0 {
+		return fmt.Errorf("at least one scope is required")
+	}
+
+	if req.Expires.IsZero() {
+		return fmt.Errorf("expires is required")
+	}
+
+	if !req.Expires.After(now) {
+		return fmt.Errorf("expires must be in the future")
+	}
+
+	if req.Expires.Sub(now) > MaxTemporaryTokenLifetime {
+		return fmt.Errorf("expires must be at most %s from now", MaxTemporaryTokenLifetime)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package tokens
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/internal/testutil"
+)
+
+func TestService_CreateTemporary(t *testing.T) {
+	tests := []struct {
+		name         string
+		request      *TemporaryRequest
+		mockStatus   int
+		mockResponse string
+		wantErr      bool
+	}{
+		{
+			name: "successful create",
+			request: &TemporaryRequest{
+				Expires: time.Now().Add(30 * time.Minute),
+				Scopes:  []string{"styles:read"},
+			},
+			mockStatus:   http.StatusOK,
+			mockResponse: testutil.TokensTemporaryResponse,
+			wantErr:      false,
+		},
+		{
+			name: "missing scopes",
+			request: &TemporaryRequest{
+				Expires: time.Now().Add(30 * time.Minute),
+			},
+			mockStatus:   http.StatusOK,
+			mockResponse: "{}",
+			wantErr:      true,
+		},
+		{
+			name: "API error",
+			request: &TemporaryRequest{
+				Expires: time.Now().Add(30 * time.Minute),
+				Scopes:  []string{"styles:read"},
+			},
+			mockStatus:   http.StatusUnauthorized,
+			mockResponse: testutil.ErrorResponse,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.MockServer(t, testutil.MockResponse(tt.mockStatus, tt.mockResponse))
+			defer server.Close()
+
+			service := New(testToken, internalhttp.New(server.URL, nil))
+
+			result, err := service.CreateTemporary(context.Background(), tt.request)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateTemporary() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && !strings.HasPrefix(result.Token, UsageTemporary+".") {
+				t.Errorf("expected temporary token, got %q", result.Token)
+			}
+		})
+	}
+}
+
+func TestService_CreateTemporary_RequestBody(t *testing.T) {
+	expires := time.Now().Add(30 * time.Minute).UTC().Truncate(time.Second)
+
+	server := testutil.MockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		testutil.AssertMethod(t, r, http.MethodPost)
+
+		var body struct {
+			Expires string   `json:"expires"`
+			Scopes  []string `json:"scopes"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+
+		if body.Expires != expires.Format(time.RFC3339) {
+			t.Errorf("expected expires %s, got %s", expires.Format(time.RFC3339), body.Expires)
+		}
+		if len(body.Scopes) != 1 || body.Scopes[0] != "styles:read" {
+			t.Errorf("unexpected scopes %v", body.Scopes)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testutil.TokensTemporaryResponse))
+	})
+	defer server.Close()
+
+	service := New(testToken, internalhttp.New(server.URL, nil))
+
+	_, err := service.CreateTemporary(context.Background(), &TemporaryRequest{
+		Expires: expires,
+		Scopes:  []string{"styles:read"},
+	})
+	if err != nil {
+		t.Fatalf("CreateTemporary() error = %v", err)
+	}
+}
+
+func TestValidateTemporaryRequest(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		request *TemporaryRequest
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			request: &TemporaryRequest{Expires: now.Add(time.Minute), Scopes: []string{"styles:read"}},
+			wantErr: false,
+		},
+		{
+			name:    "maximum lifetime",
+			request: &TemporaryRequest{Expires: now.Add(time.Hour), Scopes: []string{"styles:read"}},
+			wantErr: false,
+		},
+		{
+			name:    "exceeds maximum lifetime",
+			request: &TemporaryRequest{Expires: now.Add(time.Hour + time.Second), Scopes: []string{"styles:read"}},
+			wantErr: true,
+		},
+		{
+			name:    "in the past",
+			request: &TemporaryRequest{Expires: now.Add(-time.Minute), Scopes: []string{"styles:read"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing expiry",
+			request: &TemporaryRequest{Scopes: []string{"styles:read"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing scopes",
+			request: &TemporaryRequest{Expires: now.Add(time.Minute)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTemporaryRequest(tt.request, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateTemporaryRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package tokens
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+)
+
+const (
+	// API paths
+	tokensPath = "/tokens/v2"
+	scopesPath = "/scopes/v1"
+)
+
+// Service provides access to the Mapbox Tokens API.
+type Service struct {
+	token      string
+	httpClient *internalhttp.Client
+}
+
+// New creates a new Tokens service.
+func New(token string, httpClient *internalhttp.Client) *Service {
+	return &Service{
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// resolveUsername returns the explicit username if set, otherwise the owner
+// encoded in the service's access token.
+func (s *Service) resolveUsername(username string) (string, error) {
+	if username != "" {
+		return username, nil
+	}
+
+	if u := usernameFromToken(s.token); u != "" {
+		return u, nil
+	}
+
+	return "", fmt.Errorf("username is required")
+}
+
+// usernameFromToken extracts the account name from a Mapbox access token.
+// Tokens have the form "<usage>.<base64url JSON payload>.<signature>", where
+// the payload carries the owner in the "u" field. It returns an empty string
+// if the token cannot be decoded.
+func usernameFromToken(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		User string `json:"u"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return ""
+	}
+
+	return payload.User
+}
@@ -0,0 +1,124 @@
+package tokens
+
+import (
+	"testing"
+
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+)
+
+// testToken is a syntactically valid public token owned by "testuser".
+const testToken = "pk.eyJ1IjoidGVzdHVzZXIiLCJhIjoiY2wwYWJjMTIzIn0.signature"
+
+func TestNew(t *testing.T) {
+	httpClient := internalhttp.New("https://api.mapbox.com", nil)
+
+	service := New(testToken, httpClient)
+
+	if service == nil {
+		t.Fatal("expected non-nil service")
+	}
+
+	if service.token != testToken {
+		t.Errorf("expected token %q, got %q", testToken, service.token)
+	}
+
+	if service.httpClient != httpClient {
+		t.Error("expected httpClient to be set")
+	}
+}
+
+func TestUsernameFromToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		expected string
+	}{
+		{
+			name:     "public token",
+			token:    testToken,
+			expected: "testuser",
+		},
+		{
+			name:     "padded payload",
+			token:    "sk.eyJ1IjoidGVzdHVzZXIiLCJhIjoiY2wwYWJjMTIzIn0=.signature",
+			expected: "testuser",
+		},
+		{
+			name:     "opaque token",
+			token:    "test-token",
+			expected: "",
+		},
+		{
+			name:     "invalid base64",
+			token:    "pk.!!!.signature",
+			expected: "",
+		},
+		{
+			name:     "payload is not JSON",
+			token:    "pk.bm90IGpzb24.signature",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := usernameFromToken(tt.token); got != tt.expected {
+				t.Errorf("usernameFromToken() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestService_ResolveUsername(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		username string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "explicit username",
+			token:    testToken,
+			username: "otheruser",
+			expected: "otheruser",
+		},
+		{
+			name:     "username from token",
+			token:    testToken,
+			expected: "testuser",
+		},
+		{
+			name:    "opaque token without username",
+			token:   "test-token",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := New(tt.token, internalhttp.New("https://api.mapbox.com", nil))
+
+			got, err := service.resolveUsername(tt.username)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveUsername() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.expected {
+				t.Errorf("resolveUsername() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
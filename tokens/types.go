@@ -0,0 +1,117 @@
+// Package tokens provides access to the Mapbox Tokens API.
+package tokens
+
+import "time"
+
+// Token usage prefixes.
+const (
+	UsagePublic    = "pk" // public token
+	UsageSecret    = "sk" // secret token
+	UsageTemporary = "tk" // temporary token
+)
+
+// Token validation codes returned by Retrieve.
+const (
+	CodeTokenValid     = "TokenValid"
+	CodeTokenMalformed = "TokenMalformed"
+	CodeTokenInvalid   = "TokenInvalid"
+	CodeTokenExpired   = "TokenExpired"
+	CodeTokenRevoked   = "TokenRevoked"
+)
+
+// MaxTemporaryTokenLifetime is the longest expiry the API accepts for temporary tokens.
+const MaxTemporaryTokenLifetime = time.Hour
+
+// Token represents an access token belonging to an account.
+type Token struct {
+	ID          string    `json:"id"`
+	Usage       string    `json:"usage"`
+	Client      string    `json:"client,omitempty"`
+	Default     bool      `json:"default"`
+	Note        string    `json:"note,omitempty"`
+	Scopes      []string  `json:"scopes"`
+	AllowedURLs []string  `json:"allowedUrls,omitempty"`
+	Created     time.Time `json:"created"`
+	Modified    time.Time `json:"modified"`
+	Token       string    `json:"token"`
+}
+
+// ListRequest represents a request to list the tokens of an account.
+type ListRequest struct {
+	Username string // optional, defaults to the owner of the access token
+	Default  *bool  // filter by default token
+	Limit    *int   // max 100
+	SortBy   string // "created" or "modified"
+	Start    string // token ID to start after, for pagination
+	Usage    string // "pk", "sk" or "tk"
+}
+
+// CreateRequest represents a request to create a permanent token.
+type CreateRequest struct {
+	Username    string   `json:"-"`                     // optional, defaults to the owner of the access token
+	Note        string   `json:"note,omitempty"`        // human-readable description
+	Scopes      []string `json:"scopes"`                // token scopes, e.g. "styles:read"
+	AllowedURLs []string `json:"allowedUrls,omitempty"` // URL restrictions, public tokens only
+}
+
+// UpdateRequest represents a request to update an existing token.
+// Only non-nil fields are changed.
+type UpdateRequest struct {
+	Username    string    `json:"-"`                     // optional, defaults to the owner of the access token
+	TokenID     string    `json:"-"`                     // required
+	Note        *string   `json:"note,omitempty"`        // new description
+	Scopes      []string  `json:"scopes,omitempty"`      // replaces the token scopes
+	AllowedURLs *[]string `json:"allowedUrls,omitempty"` // replaces the URL restrictions, empty to remove them
+}
+
+// DeleteRequest represents a request to delete a token.
+type DeleteRequest struct {
+	Username string // optional, defaults to the owner of the access token
+	TokenID  string // required
+}
+
+// TemporaryRequest represents a request to create a short-lived token.
+type TemporaryRequest struct {
+	Username string    `json:"-"`       // optional, defaults to the owner of the access token
+	Expires  time.Time `json:"expires"` // required, at most one hour from now
+	Scopes   []string  `json:"scopes"`  // required
+}
+
+// TemporaryToken represents a newly created temporary token.
+type TemporaryToken struct {
+	Token string `json:"token"`
+}
+
+// RetrieveResponse represents the metadata of the token used by the client.
+type RetrieveResponse struct {
+	Code  string     `json:"code"`
+	Token *TokenInfo `json:"token,omitempty"`
+}
+
+// Valid reports whether the API considers the token valid.
+func (r *RetrieveResponse) Valid() bool {
+	return r.Code == CodeTokenValid
+}
+
+// TokenInfo describes the token used to authenticate a request.
+type TokenInfo struct {
+	Usage         string    `json:"usage"`
+	User          string    `json:"user"`
+	Authorization string    `json:"authorization"`
+	Client        string    `json:"client,omitempty"`
+	Scopes        []string  `json:"scopes,omitempty"`
+	Created       time.Time `json:"created"`
+	Expires       time.Time `json:"expires"`
+}
+
+// ListScopesRequest represents a request to list the scopes available to an account.
+type ListScopesRequest struct {
+	Username string // optional, defaults to the owner of the access token
+}
+
+// Scope describes a token scope.
+type Scope struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Public      bool   `json:"public,omitempty"`
+}
@@ -1,33 +1,28 @@
 package mapbox
 
+import "github.com/pettinz/mapbox-go-sdk/geojson"
+
 // Point represents a GeoJSON Point geometry with coordinates [longitude, latitude].
-type Point struct {
-	Type        string    `json:"type"`
-	Coordinates []float64 `json:"coordinates"`
-}
+// It is the same type as geojson.Point, so values can be shared with the
+// service packages.
+type Point = geojson.Point
 
 // NewPoint creates a new Point with the given longitude and latitude.
 func NewPoint(longitude, latitude float64) *Point {
-	return &Point{
-		Type:        "Point",
-		Coordinates: []float64{longitude, latitude},
-	}
+	return geojson.NewPoint(longitude, latitude)
 }
 
-// Longitude returns the longitude coordinate.
-func (p *Point) Longitude() float64 {
-	if len(p.Coordinates) >= 1 {
-		return p.Coordinates[0]
-	}
-	return 0
-}
+// LngLat is a geographic position in WGS84 degrees, shared by the request and
+// response types of every service.
+type LngLat = geojson.LngLat
+
+// BBox is a bounding box in WGS84 degrees, shared by the request types of every
+// service. A West edge greater than East crosses the antimeridian.
+type BBox = geojson.BBox
 
-// Latitude returns the latitude coordinate.
-func (p *Point) Latitude() float64 {
-	if len(p.Coordinates) >= 2 {
-		return p.Coordinates[1]
-	}
-	return 0
+// NewBBox creates a bounding box from its edges.
+func NewBBox(west, south, east, north float64) *BBox {
+	return geojson.NewBBox(west, south, east, north)
 }
 
 // Feature represents a GeoJSON Feature.
@@ -0,0 +1,296 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+)
+
+// ErrBudgetExceeded is returned, wrapped in a *BudgetError, for calls that
+// would exceed a hard budget limit.
+var ErrBudgetExceeded = errors.New("usage: budget exceeded")
+
+// Period is the period over which a budget limit applies. Periods start at
+// midnight UTC.
+type Period int
+
+// Budget periods.
+const (
+	Hourly Period = iota + 1
+	Daily
+	Monthly
+)
+
+// Start returns the start of the period containing t.
+func (p Period) Start(t time.Time) time.Time {
+	t = t.UTC()
+	switch p {
+	case Hourly:
+		return t.Truncate(time.Hour)
+	case Daily:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// End returns the end of the period containing t.
+func (p Period) End(t time.Time) time.Time {
+	start := p.Start(t)
+	switch p {
+	case Hourly:
+		return start.Add(time.Hour)
+	case Daily:
+		return start.AddDate(0, 0, 1)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+// String returns the name of the period.
+func (p Period) String() string {
+	switch p {
+	case Hourly:
+		return "hourly"
+	case Daily:
+		return "daily"
+	case Monthly:
+		return "monthly"
+	default:
+		return fmt.Sprintf("Period(%d)", int(p))
+	}
+}
+
+// Limit caps the billable events of some kinds over a period, e.g. 100,000
+// geocoding requests a day.
+type Limit struct {
+	// Name identifies the limit in the store and in errors. Processes
+	// sharing a budget must use the same name.
+	Name string
+
+	// Events are the kinds of events counted against the limit.
+	Events []Event
+
+	// Tag, when set, restricts the limit to the calls with that tag.
+	Tag string
+
+	// Period is the period after which the count restarts from zero.
+	Period Period
+
+	// Soft is the count at which the soft limit handler is called, once
+	// per period. Zero disables it.
+	Soft int64
+
+	// Hard is the count that calls are not allowed to exceed. Zero
+	// disables it.
+	Hard int64
+}
+
+// Alert describes a soft limit being reached.
+type Alert struct {
+	Limit  Limit
+	Count  int64
+	Period time.Time // the start of the period
+}
+
+// BudgetError is the error returned for a call that would exceed a hard
+// limit. It wraps ErrBudgetExceeded.
+type BudgetError struct {
+	Limit  Limit
+	Count  int64 // the count before the call
+	Events int64 // the events the call would add
+	Reset  time.Time
+}
+
+// Error implements the error interface.
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("%v: %s limit %q reached (%d of %d), resets at %s",
+		ErrBudgetExceeded, e.Limit.Period, e.Limit.Name, e.Count, e.Limit.Hard, e.Reset.Format(time.RFC3339))
+}
+
+// Unwrap returns ErrBudgetExceeded.
+func (e *BudgetError) Unwrap() error {
+	return ErrBudgetExceeded
+}
+
+// Budget enforces limits on billable events. Install it with Middleware,
+// after any middleware retrying calls so that every attempt is checked. A
+// Budget is safe for concurrent use.
+//
+// Calls are checked against the hard limits before they are sent and
+// counted when they succeed, so concurrent calls may overshoot a hard limit
+// by the calls in flight.
+type Budget struct {
+	limits  []Limit
+	store   Store
+	onSoft  func(context.Context, Alert)
+	onError func(context.Context, error)
+	now     func() time.Time
+
+	mu      sync.Mutex
+	tracker *tracker
+}
+
+// BudgetOption configures a Budget.
+type BudgetOption func(*Budget)
+
+// WithStore sets the store of the counters. The default is a MemoryStore.
+func WithStore(store Store) BudgetOption {
+	return func(b *Budget) {
+		b.store = store
+	}
+}
+
+// OnSoftLimit sets the function called when a soft limit is reached. It is
+// called once per limit and period, by the process whose call reached it.
+func OnSoftLimit(fn func(ctx context.Context, alert Alert)) BudgetOption {
+	return func(b *Budget) {
+		b.onSoft = fn
+	}
+}
+
+// OnStoreError sets the function called when counting a successful call
+// fails. Such calls are not counted; their response is returned as usual.
+func OnStoreError(fn func(ctx context.Context, err error)) BudgetOption {
+	return func(b *Budget) {
+		b.onError = fn
+	}
+}
+
+// WithBudgetClock sets the clock used to find the current period. The
+// default is time.Now.
+func WithBudgetClock(now func() time.Time) BudgetOption {
+	return func(b *Budget) {
+		b.now = now
+	}
+}
+
+// NewBudget creates a budget enforcing limits.
+func NewBudget(limits []Limit, opts ...BudgetOption) (*Budget, error) {
+	names := map[string]bool{}
+	for i, limit := range limits {
+		switch {
+		case limit.Name == "":
+			return nil, fmt.Errorf("limit %d: name is required", i)
+		case names[limit.Name]:
+			return nil, fmt.Errorf("limit %q: duplicate name", limit.Name)
+		case len(limit.Events) == 0:
+			return nil, fmt.Errorf("limit %q: at least one event is required", limit.Name)
+		case limit.Period < Hourly || limit.Period > Monthly:
+			return nil, fmt.Errorf("limit %q: invalid period %v", limit.Name, limit.Period)
+		case limit.Soft < 0 || limit.Hard < 0:
+			return nil, fmt.Errorf("limit %q: limits must not be negative", limit.Name)
+		}
+		names[limit.Name] = true
+	}
+
+	b := &Budget{
+		limits:  slices.Clone(limits),
+		store:   &MemoryStore{},
+		onSoft:  func(context.Context, Alert) {},
+		onError: func(context.Context, error) {},
+		now:     time.Now,
+		tracker: newTracker(),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b, nil
+}
+
+// Middleware returns the middleware enforcing the budget. Calls that would
+// exceed a hard limit fail with a *BudgetError without being sent.
+func (b *Budget) Middleware() middleware.Middleware {
+	return func(next middleware.RoundTrip) middleware.RoundTrip {
+		return func(ctx context.Context, req *middleware.Request) (*middleware.Response, error) {
+			tag := TagFromContext(ctx)
+
+			b.mu.Lock()
+			event, n := b.tracker.events(req, b.now(), false)
+			b.mu.Unlock()
+			if err := b.check(ctx, event, tag, n); err != nil {
+				return nil, err
+			}
+
+			resp, err := next(ctx, req)
+			if successful(resp, err) {
+				b.record(ctx, req, tag)
+			}
+			return resp, err
+		}
+	}
+}
+
+// Count returns the current count of a limit.
+func (b *Budget) Count(ctx context.Context, name string) (int64, error) {
+	for _, limit := range b.limits {
+		if limit.Name == name {
+			return b.store.Get(ctx, b.key(&limit, b.now()))
+		}
+	}
+	return 0, fmt.Errorf("unknown limit %q", name)
+}
+
+// check returns a *BudgetError if n events would exceed a hard limit.
+func (b *Budget) check(ctx context.Context, event Event, tag string, n int64) error {
+	if n == 0 {
+		return nil
+	}
+
+	now := b.now()
+	for i := range b.limits {
+		limit := &b.limits[i]
+		if limit.Hard == 0 || !limit.applies(event, tag) {
+			continue
+		}
+		count, err := b.store.Get(ctx, b.key(limit, now))
+		if err != nil {
+			return fmt.Errorf("failed to read budget %q: %w", limit.Name, err)
+		}
+		if count+n > limit.Hard {
+			return &BudgetError{Limit: *limit, Count: count, Events: n, Reset: limit.Period.End(now)}
+		}
+	}
+	return nil
+}
+
+// record counts the events of a successful call.
+func (b *Budget) record(ctx context.Context, req *middleware.Request, tag string) {
+	now := b.now()
+	b.mu.Lock()
+	event, n := b.tracker.events(req, now, true)
+	b.mu.Unlock()
+	if n == 0 {
+		return
+	}
+
+	for i := range b.limits {
+		limit := &b.limits[i]
+		if !limit.applies(event, tag) {
+			continue
+		}
+		count, err := b.store.Add(ctx, b.key(limit, now), n, limit.Period.End(now))
+		if err != nil {
+			b.onError(ctx, fmt.Errorf("failed to update budget %q: %w", limit.Name, err))
+			continue
+		}
+		if limit.Soft > 0 && count-n < limit.Soft && count >= limit.Soft {
+			b.onSoft(ctx, Alert{Limit: *limit, Count: count, Period: limit.Period.Start(now)})
+		}
+	}
+}
+
+// key returns the store key of the limit's counter for the period
+// containing now.
+func (b *Budget) key(limit *Limit, now time.Time) string {
+	return "mapbox:budget:" + limit.Name + ":" + limit.Period.Start(now).Format("2006-01-02T15")
+}
+
+func (l *Limit) applies(event Event, tag string) bool {
+	return slices.Contains(l.Events, event) && (l.Tag == "" || l.Tag == tag)
+}
@@ -0,0 +1,273 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/mapboxtest"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+)
+
+// budgetCall sends a request for endpoint through the budget, answered with
+// 200, and returns the error and whether the request was sent.
+func budgetCall(b *Budget, ctx context.Context, endpoint string, query url.Values, params any) (error, bool) {
+	sent := false
+	rt := middleware.Chain(func(ctx context.Context, req *middleware.Request) (*middleware.Response, error) {
+		sent = true
+		return &middleware.Response{StatusCode: http.StatusOK, Header: http.Header{}}, nil
+	}, b.Middleware())
+	if query == nil {
+		query = url.Values{}
+	}
+	_, err := rt(ctx, &middleware.Request{Endpoint: endpoint, Query: query, Params: params, Header: http.Header{}})
+	return err, sent
+}
+
+func TestNewBudget_Validation(t *testing.T) {
+	geocodes := []Event{GeocodingTemporary}
+	tests := []struct {
+		name   string
+		limits []Limit
+	}{
+		{"missing name", []Limit{{Events: geocodes, Period: Daily, Hard: 1}}},
+		{"duplicate name", []Limit{{Name: "a", Events: geocodes, Period: Daily}, {Name: "a", Events: geocodes, Period: Daily}}},
+		{"no events", []Limit{{Name: "a", Period: Daily, Hard: 1}}},
+		{"no period", []Limit{{Name: "a", Events: geocodes, Hard: 1}}},
+		{"negative", []Limit{{Name: "a", Events: geocodes, Period: Daily, Hard: -1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewBudget(tt.limits); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestBudget_HardLimit(t *testing.T) {
+	c := newClock()
+	b, err := NewBudget([]Limit{
+		{Name: "geocodes", Events: []Event{GeocodingTemporary, GeocodingPermanent}, Period: Daily, Hard: 5},
+	}, WithBudgetClock(c.Now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for i := range 3 {
+		if err, _ := budgetCall(b, ctx, middleware.GeocodingForward, nil, nil); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+
+	// A batch of 3 queries would exceed the limit; one of 2 does not.
+	batch := func(n int) *geocoding.BatchRequest {
+		return &geocoding.BatchRequest{Queries: make([]geocoding.BatchQuery, n)}
+	}
+	err, sent := budgetCall(b, ctx, middleware.GeocodingBatch, nil, batch(3))
+	var budgetErr *BudgetError
+	if !errors.Is(err, ErrBudgetExceeded) || !errors.As(err, &budgetErr) || sent {
+		t.Fatalf("expected a BudgetError without sending, got %v (sent %v)", err, sent)
+	}
+	if budgetErr.Count != 3 || budgetErr.Events != 3 || budgetErr.Limit.Name != "geocodes" {
+		t.Errorf("unexpected error %+v", budgetErr)
+	}
+	if want := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC); !budgetErr.Reset.Equal(want) {
+		t.Errorf("Reset = %v, want %v", budgetErr.Reset, want)
+	}
+	if err, _ := budgetCall(b, ctx, middleware.GeocodingBatch, url.Values{"permanent": {"true"}}, batch(2)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err, _ := budgetCall(b, ctx, middleware.GeocodingReverse, nil, nil); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("expected ErrBudgetExceeded, got %v", err)
+	}
+
+	// Other events are not limited.
+	if err, _ := budgetCall(b, ctx, middleware.TilesVector, nil, nil); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// The count restarts the next day.
+	c.Advance(24 * time.Hour)
+	if err, _ := budgetCall(b, ctx, middleware.GeocodingForward, nil, nil); err != nil {
+		t.Errorf("unexpected error after the period ended: %v", err)
+	}
+	if count, _ := b.Count(ctx, "geocodes"); count != 1 {
+		t.Errorf("Count() = %d, want 1", count)
+	}
+}
+
+func TestBudget_SoftLimit(t *testing.T) {
+	var alerts []Alert
+	b, err := NewBudget([]Limit{
+		{Name: "sessions", Events: []Event{SearchBoxSession}, Period: Monthly, Soft: 2, Hard: 3},
+	}, OnSoftLimit(func(_ context.Context, alert Alert) {
+		alerts = append(alerts, alert)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for _, token := range []string{"a", "a", "b", "b", "c"} {
+		if err, _ := budgetCall(b, ctx, middleware.SearchBoxSuggest, sessionQuery(token), nil); err != nil {
+			t.Fatalf("suggest %s: %v", token, err)
+		}
+	}
+	if len(alerts) != 1 || alerts[0].Count != 2 || alerts[0].Limit.Name != "sessions" {
+		t.Fatalf("alerts = %+v, want one at 2", alerts)
+	}
+
+	// Suggest calls in an open session are not billed, so they are allowed
+	// once the hard limit is reached; new sessions are not.
+	if err, _ := budgetCall(b, ctx, middleware.SearchBoxSuggest, sessionQuery("c"), nil); err != nil {
+		t.Errorf("unexpected error in an open session: %v", err)
+	}
+	if err, _ := budgetCall(b, ctx, middleware.SearchBoxRetrieve, sessionQuery("c"), nil); err != nil {
+		t.Errorf("unexpected error ending an open session: %v", err)
+	}
+	if err, _ := budgetCall(b, ctx, middleware.SearchBoxSuggest, sessionQuery("d"), nil); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("expected ErrBudgetExceeded for a new session, got %v", err)
+	}
+	if len(alerts) != 1 {
+		t.Errorf("expected a single alert, got %d", len(alerts))
+	}
+}
+
+func TestBudget_Tag(t *testing.T) {
+	b, err := NewBudget([]Limit{
+		{Name: "import", Events: []Event{GeocodingTemporary}, Tag: "import", Period: Hourly, Hard: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	importCtx := WithTag(context.Background(), "import")
+
+	if err, _ := budgetCall(b, importCtx, middleware.GeocodingForward, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err, _ := budgetCall(b, importCtx, middleware.GeocodingForward, nil, nil); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("expected ErrBudgetExceeded, got %v", err)
+	}
+	if err, _ := budgetCall(b, context.Background(), middleware.GeocodingForward, nil, nil); err != nil {
+		t.Errorf("expected untagged calls to be allowed, got %v", err)
+	}
+}
+
+// failingStore fails every operation.
+type failingStore struct{}
+
+func (failingStore) Get(context.Context, string) (int64, error) {
+	return 0, errors.New("store unavailable")
+}
+
+func (failingStore) Add(context.Context, string, int64, time.Time) (int64, error) {
+	return 0, errors.New("store unavailable")
+}
+
+func TestBudget_StoreErrors(t *testing.T) {
+	limits := []Limit{{Name: "geocodes", Events: []Event{GeocodingTemporary}, Period: Daily, Soft: 1, Hard: 10}}
+
+	b, err := NewBudget(limits, WithStore(failingStore{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err, sent := budgetCall(b, context.Background(), middleware.GeocodingForward, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "store unavailable") || sent {
+		t.Errorf("expected the store error without sending, got %v (sent %v)", err, sent)
+	}
+
+	// Without hard limits the store is only written, and failures are
+	// reported to the handler.
+	limits[0].Hard = 0
+	var reported error
+	b, err = NewBudget(limits, WithStore(failingStore{}), OnStoreError(func(_ context.Context, err error) {
+		reported = err
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err, _ := budgetCall(b, context.Background(), middleware.GeocodingForward, nil, nil); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if reported == nil || !strings.Contains(reported.Error(), `budget "geocodes"`) {
+		t.Errorf("reported error = %v", reported)
+	}
+}
+
+func TestBudget_SharedStore(t *testing.T) {
+	srv := mapboxtest.NewServer()
+	defer srv.Close()
+
+	// Two clients, as in two processes, sharing a store.
+	store := &MemoryStore{}
+	limits := []Limit{{Name: "geocodes", Events: []Event{GeocodingTemporary}, Period: Daily, Hard: 10}}
+	var services []*geocoding.Service
+	for range 2 {
+		b, err := NewBudget(limits, WithStore(store))
+		if err != nil {
+			t.Fatal(err)
+		}
+		services = append(services, geocoding.New(mapboxtest.DefaultToken, internalhttp.New(srv.URL, nil, b.Middleware())))
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var exceeded int
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := services[i%2].Forward(context.Background(), &geocoding.ForwardRequest{Query: "Rome"})
+			if errors.Is(err, ErrBudgetExceeded) {
+				mu.Lock()
+				exceeded++
+				mu.Unlock()
+			} else if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if exceeded != 0 {
+		t.Fatalf("expected 10 calls within the budget, %d exceeded", exceeded)
+	}
+
+	_, err := services[0].Forward(context.Background(), &geocoding.ForwardRequest{Query: "Rome"})
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("expected ErrBudgetExceeded through the service, got %v", err)
+	}
+	if n := len(srv.Requests()); n != 10 {
+		t.Errorf("expected 10 requests to reach the server, got %d", n)
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	at := time.Date(2026, 2, 15, 13, 45, 0, 0, time.FixedZone("CET", 3600))
+	tests := []struct {
+		period     Period
+		start, end time.Time
+	}{
+		{Hourly, time.Date(2026, 2, 15, 12, 0, 0, 0, time.UTC), time.Date(2026, 2, 15, 13, 0, 0, 0, time.UTC)},
+		{Daily, time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC)},
+		{Monthly, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period.String(), func(t *testing.T) {
+			if got := tt.period.Start(at); !got.Equal(tt.start) {
+				t.Errorf("Start() = %v, want %v", got, tt.start)
+			}
+			if got := tt.period.End(at); !got.Equal(tt.end) {
+				t.Errorf("End() = %v, want %v", got, tt.end)
+			}
+		})
+	}
+}
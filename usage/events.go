@@ -0,0 +1,133 @@
+package usage
+
+import (
+	"context"
+	"time"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+)
+
+const (
+	// sessionSuggests and sessionDuration end a Search Box session.
+	sessionSuggests = 50
+	sessionDuration = time.Hour
+
+	// minSweep is the number of open sessions above which expired ones are
+	// dropped.
+	minSweep = 1024
+)
+
+// tracker classifies calls into billable events, keeping the state of
+// Search Box sessions. It is not safe for concurrent use.
+type tracker struct {
+	sessions map[string]*session
+	sweepAt  int
+}
+
+// session is an open Search Box session.
+type session struct {
+	started  time.Time
+	suggests int
+}
+
+func newTracker() *tracker {
+	return &tracker{sessions: map[string]*session{}, sweepAt: minSweep}
+}
+
+// events returns the billable events of a successful call. Unless commit is
+// set, the sessions are left unchanged, to predict the events of a call
+// before it is sent.
+func (t *tracker) events(req *middleware.Request, now time.Time, commit bool) (Event, int64) {
+	switch req.Endpoint {
+	case middleware.GeocodingForward, middleware.GeocodingForwardStructured, middleware.GeocodingReverse:
+		return geocodingEvent(req), 1
+	case middleware.GeocodingBatch:
+		if batch, ok := req.Params.(*geocoding.BatchRequest); ok {
+			return geocodingEvent(req), int64(len(batch.Queries))
+		}
+	case middleware.SearchBoxSuggest:
+		if t.suggest(req.Query.Get("session_token"), now, commit) {
+			return SearchBoxSession, 1
+		}
+	case middleware.SearchBoxRetrieve:
+		if t.retrieve(req.Query.Get("session_token"), now, commit) {
+			return SearchBoxSession, 1
+		}
+	case middleware.SearchBoxForward, middleware.SearchBoxCategory, middleware.SearchBoxReverse:
+		return SearchBoxRequest, 1
+	case middleware.TilesVector:
+		return VectorTiles, 1
+	case middleware.TilesRaster:
+		return RasterTiles, 1
+	}
+	return "", 0
+}
+
+func geocodingEvent(req *middleware.Request) Event {
+	if req.Query.Get("permanent") == "true" {
+		return GeocodingPermanent
+	}
+	return GeocodingTemporary
+}
+
+// suggest records a suggest call and reports whether it starts a session.
+func (t *tracker) suggest(token string, now time.Time, commit bool) bool {
+	if s, ok := t.sessions[token]; ok && !s.expired(now) && s.suggests < sessionSuggests {
+		if commit {
+			s.suggests++
+		}
+		return false
+	}
+
+	if commit {
+		if len(t.sessions) >= t.sweepAt {
+			t.sweep(now)
+		}
+		t.sessions[token] = &session{started: now, suggests: 1}
+	}
+	return true
+}
+
+// retrieve records a retrieve call, which ends its session, and reports
+// whether it starts one, as it does without a preceding suggest call.
+func (t *tracker) retrieve(token string, now time.Time, commit bool) bool {
+	s, ok := t.sessions[token]
+	if commit {
+		delete(t.sessions, token)
+	}
+	return !ok || s.expired(now)
+}
+
+// sweep drops the expired sessions.
+func (t *tracker) sweep(now time.Time) {
+	for token, s := range t.sessions {
+		if s.expired(now) {
+			delete(t.sessions, token)
+		}
+	}
+	t.sweepAt = max(minSweep, 2*len(t.sessions))
+}
+
+func (s *session) expired(now time.Time) bool {
+	return now.Sub(s.started) >= sessionDuration
+}
+
+// successful reports whether a call succeeded and is billed.
+func successful(resp *middleware.Response, err error) bool {
+	return err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300
+}
+
+type tagKey struct{}
+
+// WithTag returns a context attributing the API calls made with it to tag,
+// e.g. the product feature making them.
+func WithTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, tagKey{}, tag)
+}
+
+// TagFromContext returns the tag set with WithTag, or "".
+func TagFromContext(ctx context.Context) string {
+	tag, _ := ctx.Value(tagKey{}).(string)
+	return tag
+}
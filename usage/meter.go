@@ -0,0 +1,96 @@
+package usage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+)
+
+// Meter counts billable events. Install it with Middleware. A Meter is safe
+// for concurrent use.
+type Meter struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	counts  map[Key]int64
+	start   time.Time
+	tracker *tracker
+}
+
+// Option configures a Meter.
+type Option func(*Meter)
+
+// WithClock sets the clock used to expire sessions and time snapshots. The
+// default is time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(m *Meter) {
+		m.now = now
+	}
+}
+
+// NewMeter creates a meter with zero counters.
+func NewMeter(opts ...Option) *Meter {
+	m := &Meter{
+		now:     time.Now,
+		counts:  map[Key]int64{},
+		tracker: newTracker(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.start = m.now()
+	return m
+}
+
+// Middleware returns the middleware counting the billable events of
+// successful API calls.
+func (m *Meter) Middleware() middleware.Middleware {
+	return func(next middleware.RoundTrip) middleware.RoundTrip {
+		return func(ctx context.Context, req *middleware.Request) (*middleware.Response, error) {
+			resp, err := next(ctx, req)
+			if successful(resp, err) {
+				m.record(ctx, req)
+			}
+			return resp, err
+		}
+	}
+}
+
+// Add adds n events of a kind for tag, for events counted outside the
+// client.
+func (m *Meter) Add(event Event, tag string, n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counts[Key{Event: event, Tag: tag}] += n
+}
+
+// Snapshot returns the usage counted since the meter was created or reset.
+func (m *Meter) Snapshot() Snapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return newSnapshot(m.counts, m.start, m.now())
+}
+
+// Reset zeroes the counters and returns the usage counted before. Open
+// Search Box sessions are kept, so a session spanning a reset is counted
+// once, in the period it started.
+func (m *Meter) Reset() Snapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	s := newSnapshot(m.counts, m.start, now)
+	m.counts = map[Key]int64{}
+	m.start = now
+	return s
+}
+
+// record counts the events of a successful call.
+func (m *Meter) record(ctx context.Context, req *middleware.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if event, n := m.tracker.events(req, m.now(), true); n > 0 {
+		m.counts[Key{Event: event, Tag: TagFromContext(ctx)}] += n
+	}
+}
@@ -0,0 +1,247 @@
+package usage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pettinz/mapbox-go-sdk/geocoding"
+	internalhttp "github.com/pettinz/mapbox-go-sdk/internal/http"
+	"github.com/pettinz/mapbox-go-sdk/mapboxtest"
+	"github.com/pettinz/mapbox-go-sdk/middleware"
+	"github.com/pettinz/mapbox-go-sdk/searchbox"
+)
+
+// clock is a manually advanced clock.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newClock() *clock {
+	return &clock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// call sends a request for endpoint through the meter, answered with status.
+func call(m *Meter, ctx context.Context, endpoint string, query url.Values, params any, status int) {
+	rt := middleware.Chain(func(context.Context, *middleware.Request) (*middleware.Response, error) {
+		return &middleware.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}, m.Middleware())
+	if query == nil {
+		query = url.Values{}
+	}
+	rt(ctx, &middleware.Request{Endpoint: endpoint, Query: query, Params: params, Header: http.Header{}})
+}
+
+func sessionQuery(token string) url.Values {
+	return url.Values{"session_token": {token}}
+}
+
+func TestMeter_Geocoding(t *testing.T) {
+	m := NewMeter()
+	ctx := context.Background()
+
+	call(m, ctx, middleware.GeocodingForward, nil, nil, http.StatusOK)
+	call(m, ctx, middleware.GeocodingForwardStructured, nil, nil, http.StatusOK)
+	call(m, ctx, middleware.GeocodingReverse, url.Values{"permanent": {"true"}}, nil, http.StatusOK)
+	call(m, ctx, middleware.GeocodingBatch, nil, &geocoding.BatchRequest{Queries: make([]geocoding.BatchQuery, 3)}, http.StatusOK)
+	call(m, ctx, middleware.GeocodingBatch, url.Values{"permanent": {"true"}}, &geocoding.BatchRequest{Queries: make([]geocoding.BatchQuery, 2)}, http.StatusOK)
+	call(m, ctx, middleware.GeocodingForward, nil, nil, http.StatusUnauthorized)
+
+	s := m.Snapshot()
+	if got := s.Total(GeocodingTemporary); got != 5 {
+		t.Errorf("temporary = %d, want 5", got)
+	}
+	if got := s.Total(GeocodingPermanent); got != 3 {
+		t.Errorf("permanent = %d, want 3", got)
+	}
+}
+
+func TestMeter_SearchBoxSessions(t *testing.T) {
+	c := newClock()
+	m := NewMeter(WithClock(c.Now))
+	ctx := context.Background()
+
+	// A session ended by a retrieve call.
+	for range 3 {
+		call(m, ctx, middleware.SearchBoxSuggest, sessionQuery("a"), nil, http.StatusOK)
+	}
+	call(m, ctx, middleware.SearchBoxRetrieve, sessionQuery("a"), nil, http.StatusOK)
+	if got := m.Snapshot().Total(SearchBoxSession); got != 1 {
+		t.Fatalf("sessions = %d, want 1", got)
+	}
+
+	// Reusing the token after a retrieve starts a new session.
+	call(m, ctx, middleware.SearchBoxSuggest, sessionQuery("a"), nil, http.StatusOK)
+	if got := m.Snapshot().Total(SearchBoxSession); got != 2 {
+		t.Fatalf("sessions = %d, want 2", got)
+	}
+
+	// The 51st suggest call starts a new session.
+	for range 50 {
+		call(m, ctx, middleware.SearchBoxSuggest, sessionQuery("b"), nil, http.StatusOK)
+	}
+	call(m, ctx, middleware.SearchBoxSuggest, sessionQuery("b"), nil, http.StatusOK)
+	if got := m.Snapshot().Total(SearchBoxSession); got != 4 {
+		t.Fatalf("sessions = %d, want 4", got)
+	}
+
+	// Sessions expire after an hour.
+	c.Advance(time.Hour)
+	call(m, ctx, middleware.SearchBoxSuggest, sessionQuery("b"), nil, http.StatusOK)
+	if got := m.Snapshot().Total(SearchBoxSession); got != 5 {
+		t.Fatalf("sessions = %d, want 5", got)
+	}
+
+	// A retrieve call without suggest calls is a session of its own.
+	call(m, ctx, middleware.SearchBoxRetrieve, sessionQuery("c"), nil, http.StatusOK)
+	if got := m.Snapshot().Total(SearchBoxSession); got != 6 {
+		t.Fatalf("sessions = %d, want 6", got)
+	}
+
+	// Failed calls do not open sessions.
+	call(m, ctx, middleware.SearchBoxSuggest, sessionQuery("d"), nil, http.StatusBadRequest)
+	if got := m.Snapshot().Total(SearchBoxSession); got != 6 {
+		t.Fatalf("sessions = %d, want 6", got)
+	}
+}
+
+func TestMeter_PerRequest(t *testing.T) {
+	m := NewMeter()
+	ctx := context.Background()
+
+	for _, endpoint := range []string{
+		middleware.SearchBoxForward,
+		middleware.SearchBoxCategory,
+		middleware.SearchBoxReverse,
+		middleware.SearchBoxListCategories,
+		middleware.TilesVector,
+		middleware.TilesVector,
+		middleware.TilesRaster,
+		middleware.TokensRetrieve,
+	} {
+		call(m, ctx, endpoint, nil, nil, http.StatusOK)
+	}
+
+	want := map[Event]int64{SearchBoxRequest: 3, VectorTiles: 2, RasterTiles: 1}
+	got := m.Snapshot().ByEvent()
+	if len(got) != len(want) {
+		t.Errorf("ByEvent() = %v, want %v", got, want)
+	}
+	for event, n := range want {
+		if got[event] != n {
+			t.Errorf("%s = %d, want %d", event, got[event], n)
+		}
+	}
+}
+
+func TestMeter_Tags(t *testing.T) {
+	m := NewMeter()
+	checkout := WithTag(context.Background(), "checkout")
+	search := WithTag(context.Background(), "search")
+
+	call(m, checkout, middleware.GeocodingForward, nil, nil, http.StatusOK)
+	call(m, checkout, middleware.GeocodingForward, nil, nil, http.StatusOK)
+	call(m, search, middleware.SearchBoxSuggest, sessionQuery("a"), nil, http.StatusOK)
+	call(m, search, middleware.GeocodingForward, nil, nil, http.StatusOK)
+	call(m, context.Background(), middleware.TilesVector, nil, nil, http.StatusOK)
+	m.Add(VectorTiles, "offline", 10)
+
+	s := m.Snapshot()
+	byTag := s.ByTag()
+	if byTag["checkout"][GeocodingTemporary] != 2 || byTag["search"][GeocodingTemporary] != 1 || byTag["search"][SearchBoxSession] != 1 {
+		t.Errorf("ByTag() = %v", byTag)
+	}
+	if byTag[""][VectorTiles] != 1 || byTag["offline"][VectorTiles] != 10 {
+		t.Errorf("ByTag() = %v", byTag)
+	}
+
+	byService := s.ByService()
+	if byService["geocoding"] != 3 || byService["searchbox"] != 1 || byService["tiles"] != 11 {
+		t.Errorf("ByService() = %v", byService)
+	}
+
+	first := s.Counters[0]
+	if first.Event != GeocodingTemporary || first.Tag != "checkout" || first.Count != 2 {
+		t.Errorf("expected counters sorted by event and tag, got %v", s.Counters)
+	}
+}
+
+func TestMeter_Reset(t *testing.T) {
+	c := newClock()
+	m := NewMeter(WithClock(c.Now))
+	ctx := context.Background()
+
+	call(m, ctx, middleware.GeocodingForward, nil, nil, http.StatusOK)
+	call(m, ctx, middleware.SearchBoxSuggest, sessionQuery("a"), nil, http.StatusOK)
+	c.Advance(time.Minute)
+
+	before := m.Reset()
+	if before.Total(GeocodingTemporary) != 1 || before.Total(SearchBoxSession) != 1 {
+		t.Errorf("Reset() = %v", before.Counters)
+	}
+	if before.End.Sub(before.Start) != time.Minute {
+		t.Errorf("snapshot period = %v, want 1m", before.End.Sub(before.Start))
+	}
+
+	// The open session is not counted again after the reset.
+	call(m, ctx, middleware.SearchBoxSuggest, sessionQuery("a"), nil, http.StatusOK)
+	after := m.Snapshot()
+	if len(after.Counters) != 0 {
+		t.Errorf("expected no usage after reset, got %v", after.Counters)
+	}
+	if !after.Start.Equal(before.End) {
+		t.Errorf("expected the new period to start at the reset")
+	}
+}
+
+func TestMeter_Client(t *testing.T) {
+	srv := mapboxtest.NewServer()
+	defer srv.Close()
+
+	m := NewMeter()
+	httpClient := internalhttp.New(srv.URL, nil, m.Middleware())
+	geo := geocoding.New(mapboxtest.DefaultToken, httpClient)
+	sb := searchbox.New(mapboxtest.DefaultToken, httpClient)
+	ctx := WithTag(context.Background(), "store-locator")
+
+	if _, err := geo.Forward(ctx, &geocoding.ForwardRequest{Query: "Rome", Permanent: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := geo.Batch(ctx, &geocoding.BatchRequest{Queries: []geocoding.BatchQuery{{Query: "Paris"}, {Query: "Berlin"}}}); err != nil {
+		t.Fatal(err)
+	}
+	token := searchbox.NewSessionToken()
+	suggestions, err := sb.Suggest(ctx, &searchbox.SuggestRequest{Query: "philz", SessionToken: token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sb.Retrieve(ctx, &searchbox.RetrieveRequest{MapboxID: suggestions.Suggestions[0].MapboxID, SessionToken: token}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := m.Snapshot().ByTag()["store-locator"]
+	want := map[Event]int64{GeocodingPermanent: 1, GeocodingTemporary: 2, SearchBoxSession: 1}
+	for event, n := range want {
+		if got[event] != n {
+			t.Errorf("%s = %d, want %d", event, got[event], n)
+		}
+	}
+}
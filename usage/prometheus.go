@@ -0,0 +1,49 @@
+package usage
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// MetricName is the name of the counter in the Prometheus export.
+const MetricName = "mapbox_billable_events_total"
+
+// WritePrometheus writes the counters in the Prometheus text exposition
+// format, with service, event and tag labels:
+//
+//	mapbox_billable_events_total{service="geocoding",event="geocoding_temporary",tag="checkout"} 42
+//
+// Counters restart from zero after Reset.
+func (m *Meter) WritePrometheus(w io.Writer) error {
+	return m.Snapshot().WritePrometheus(w)
+}
+
+// WritePrometheus writes the snapshot in the Prometheus text exposition
+// format. See Meter.WritePrometheus.
+func (s Snapshot) WritePrometheus(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# HELP %s Billable Mapbox API events.\n", MetricName)
+	fmt.Fprintf(bw, "# TYPE %s counter\n", MetricName)
+	for _, c := range s.Counters {
+		fmt.Fprintf(bw, "%s{service=%s,event=%s,tag=%s} %d\n", MetricName,
+			labelValue(c.Event.Service()), labelValue(string(c.Event)), labelValue(c.Tag), c.Count)
+	}
+	return bw.Flush()
+}
+
+// ServeHTTP serves the Prometheus export, so that the meter can be
+// registered as a scrape endpoint.
+func (m *Meter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WritePrometheus(w)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labelValue quotes a label value, escaping it as the format requires.
+func labelValue(s string) string {
+	return `"` + labelEscaper.Replace(s) + `"`
+}
@@ -0,0 +1,45 @@
+package usage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMeter_WritePrometheus(t *testing.T) {
+	m := NewMeter()
+	m.Add(GeocodingTemporary, "checkout", 42)
+	m.Add(SearchBoxSession, "", 7)
+	m.Add(VectorTiles, `say "hi"\n`, 1)
+
+	var b strings.Builder
+	if err := m.WritePrometheus(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP mapbox_billable_events_total Billable Mapbox API events.
+# TYPE mapbox_billable_events_total counter
+mapbox_billable_events_total{service="geocoding",event="geocoding_temporary",tag="checkout"} 42
+mapbox_billable_events_total{service="searchbox",event="searchbox_session",tag=""} 7
+mapbox_billable_events_total{service="tiles",event="vector_tiles",tag="say \"hi\"\\n"} 1
+`
+	if b.String() != want {
+		t.Errorf("WritePrometheus() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestMeter_ServeHTTP(t *testing.T) {
+	m := NewMeter()
+	m.Add(RasterTiles, "", 3)
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), `event="raster_tiles",tag=""} 3`) {
+		t.Errorf("unexpected body:\n%s", rec.Body.String())
+	}
+}
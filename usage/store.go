@@ -0,0 +1,66 @@
+package usage
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store persists budget counters. Implement it on a shared database, e.g.
+// with Redis INCRBY and EXPIREAT, to enforce budgets across processes.
+type Store interface {
+	// Get returns the value of the counter for key, or 0 if it does not
+	// exist.
+	Get(ctx context.Context, key string) (int64, error)
+
+	// Add atomically adds n to the counter for key and returns the new
+	// value. The counter is no longer needed after expires.
+	Add(ctx context.Context, key string, n int64, expires time.Time) (int64, error)
+}
+
+// MemoryStore is a Store keeping counters in memory, for budgets enforced by
+// a single process. The zero value is ready to use.
+type MemoryStore struct {
+	mu       sync.Mutex
+	counters map[string]*memoryCounter
+}
+
+type memoryCounter struct {
+	value   int64
+	expires time.Time
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(_ context.Context, key string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.counters[key]; ok {
+		return c.value, nil
+	}
+	return 0, nil
+}
+
+// Add implements Store. Expired counters are dropped when a counter is
+// created.
+func (s *MemoryStore) Add(_ context.Context, key string, n int64, expires time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counters[key]
+	if !ok {
+		if s.counters == nil {
+			s.counters = map[string]*memoryCounter{}
+		}
+		now := time.Now()
+		for k, old := range s.counters {
+			if now.After(old.expires) {
+				delete(s.counters, k)
+			}
+		}
+		c = &memoryCounter{}
+		s.counters[key] = c
+	}
+	c.value += n
+	c.expires = expires
+	return c.value, nil
+}
@@ -0,0 +1,47 @@
+package usage
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	var s MemoryStore
+	ctx := context.Background()
+	expires := time.Now().Add(time.Hour)
+
+	if v, err := s.Get(ctx, "a"); v != 0 || err != nil {
+		t.Errorf("Get() = %d, %v; want 0", v, err)
+	}
+
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Add(ctx, "a", 2, expires)
+		}()
+	}
+	wg.Wait()
+
+	if v, _ := s.Get(ctx, "a"); v != 200 {
+		t.Errorf("Get() = %d, want 200", v)
+	}
+}
+
+func TestMemoryStore_Expiry(t *testing.T) {
+	var s MemoryStore
+	ctx := context.Background()
+
+	s.Add(ctx, "old", 5, time.Now().Add(-time.Minute))
+	s.Add(ctx, "new", 1, time.Now().Add(time.Hour))
+
+	if v, _ := s.Get(ctx, "old"); v != 0 {
+		t.Errorf("expected the expired counter to be dropped, got %d", v)
+	}
+	if v, _ := s.Get(ctx, "new"); v != 1 {
+		t.Errorf("Get() = %d, want 1", v)
+	}
+}
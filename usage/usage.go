@@ -0,0 +1,142 @@
+// Package usage counts the billable events of Mapbox API calls the way
+// Mapbox bills them, per event and per caller-supplied tag:
+//
+//	meter := usage.NewMeter()
+//	client := mapbox.NewClient(token, mapbox.WithMiddleware(meter.Middleware()))
+//
+//	ctx := usage.WithTag(ctx, "checkout")
+//	client.Geocoding().Forward(ctx, req)
+//
+//	fmt.Println(meter.Snapshot().ByTag())
+//
+// Geocoding requests are billed as temporary or permanent, and each query of
+// a batch request counts as one request. Search Box suggest and retrieve
+// calls are billed per session; the other Search Box calls per request.
+// Tiles are billed per request. Only successful calls are counted.
+package usage
+
+import (
+	"sort"
+	"time"
+)
+
+// Event is a kind of billable event.
+type Event string
+
+// Billable events.
+const (
+	// GeocodingTemporary is a Geocoding API request, or batch query, whose
+	// results may not be stored.
+	GeocodingTemporary Event = "geocoding_temporary"
+
+	// GeocodingPermanent is a Geocoding API request, or batch query, made
+	// with Permanent set.
+	GeocodingPermanent Event = "geocoding_permanent"
+
+	// SearchBoxSession is a Search Box session: suggest calls sharing a
+	// session token, ended by a retrieve call, after 50 suggest calls or
+	// after an hour.
+	SearchBoxSession Event = "searchbox_session"
+
+	// SearchBoxRequest is a Search Box forward, category or reverse call.
+	SearchBoxRequest Event = "searchbox_request"
+
+	// VectorTiles and RasterTiles are tile requests.
+	VectorTiles Event = "vector_tiles"
+	RasterTiles Event = "raster_tiles"
+)
+
+// Service returns the service billing the event: "geocoding", "searchbox"
+// or "tiles".
+func (e Event) Service() string {
+	switch e {
+	case GeocodingTemporary, GeocodingPermanent:
+		return "geocoding"
+	case SearchBoxSession, SearchBoxRequest:
+		return "searchbox"
+	case VectorTiles, RasterTiles:
+		return "tiles"
+	default:
+		return ""
+	}
+}
+
+// Key identifies a counter.
+type Key struct {
+	Event Event
+	Tag   string
+}
+
+// Counter is the number of billable events of one kind for one tag.
+type Counter struct {
+	Key
+	Count int64
+}
+
+// Snapshot is the usage counted over a period.
+type Snapshot struct {
+	// Start is when counting started, at the creation of the meter or its
+	// last reset, and End when the snapshot was taken.
+	Start, End time.Time
+
+	// Counters holds the non-zero counters, sorted by event and tag.
+	Counters []Counter
+}
+
+func newSnapshot(counts map[Key]int64, start, end time.Time) Snapshot {
+	s := Snapshot{Start: start, End: end}
+	for key, count := range counts {
+		if count != 0 {
+			s.Counters = append(s.Counters, Counter{Key: key, Count: count})
+		}
+	}
+	sort.Slice(s.Counters, func(i, j int) bool {
+		a, b := s.Counters[i], s.Counters[j]
+		if a.Event != b.Event {
+			return a.Event < b.Event
+		}
+		return a.Tag < b.Tag
+	})
+	return s
+}
+
+// Total returns the number of events of a kind across tags.
+func (s Snapshot) Total(event Event) int64 {
+	var total int64
+	for _, c := range s.Counters {
+		if c.Event == event {
+			total += c.Count
+		}
+	}
+	return total
+}
+
+// ByEvent returns the number of events of each kind across tags.
+func (s Snapshot) ByEvent() map[Event]int64 {
+	totals := map[Event]int64{}
+	for _, c := range s.Counters {
+		totals[c.Event] += c.Count
+	}
+	return totals
+}
+
+// ByService returns the number of events billed by each service.
+func (s Snapshot) ByService() map[string]int64 {
+	totals := map[string]int64{}
+	for _, c := range s.Counters {
+		totals[c.Event.Service()] += c.Count
+	}
+	return totals
+}
+
+// ByTag returns the number of events of each kind per tag.
+func (s Snapshot) ByTag() map[string]map[Event]int64 {
+	totals := map[string]map[Event]int64{}
+	for _, c := range s.Counters {
+		if totals[c.Tag] == nil {
+			totals[c.Tag] = map[Event]int64{}
+		}
+		totals[c.Tag][c.Event] += c.Count
+	}
+	return totals
+}